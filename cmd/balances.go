@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"conintracker-hiring/pkg/models"
+	"conintracker-hiring/pkg/providers"
+)
+
+// balanceToken is an ERC-20 token seen in an export, for --with-balances
+type balanceToken struct {
+	contract        string
+	symbol          string
+	decimals        int
+	unknownDecimals bool // The token reported missing or invalid decimals, so decimals means nothing
+}
+
+// exportedTokens returns the distinct ERC-20 tokens in txs, ordered by symbol, then contract
+func exportedTokens(txs []*models.Transaction) []balanceToken {
+	seen := make(map[string]bool)
+	var tokens []balanceToken
+	for _, tx := range txs {
+		contract := strings.ToLower(tx.AssetContractAddress)
+		if tx.Type != models.TypeERC20Transfer || contract == "" || seen[contract] {
+			continue
+		}
+		seen[contract] = true
+		tokens = append(tokens, balanceToken{
+			contract:        contract,
+			symbol:          tx.AssetSymbol,
+			decimals:        tx.Decimals,
+			unknownDecimals: tx.UnknownDecimals,
+		})
+	}
+
+	sort.Slice(tokens, func(i, j int) bool {
+		if tokens[i].symbol != tokens[j].symbol {
+			return tokens[i].symbol < tokens[j].symbol
+		}
+		return tokens[i].contract < tokens[j].contract
+	})
+	return tokens
+}
+
+// printBalances prints owner's current balance of each token, adjusted for its
+// decimals. A token with unknown decimals gets its raw integer balance, marked as
+// such, matching how its transfers were exported. A balance that cannot be fetched
+// is reported and skipped, since the export itself has already been written.
+func printBalances(ctx context.Context, w io.Writer, balancer providers.TokenBalancer, owner string, tokens []balanceToken) {
+	if len(tokens) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, "\nToken balances:")
+	for _, token := range tokens {
+		raw, err := balancer.FetchTokenBalance(ctx, owner, token.contract)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not fetch the %s (%s) balance: %v\n", token.symbol, token.contract, err)
+			continue
+		}
+		if token.unknownDecimals {
+			fmt.Fprintf(w, "  %s: %s (raw, decimals unknown)\n", token.symbol, raw)
+			continue
+		}
+		balance, err := providers.FormatTokenAmount(raw, token.decimals)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not read the %s (%s) balance: %v\n", token.symbol, token.contract, err)
+			continue
+		}
+		fmt.Fprintf(w, "  %s: %s\n", token.symbol, balance)
+	}
+}
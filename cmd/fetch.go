@@ -1,25 +1,104 @@
 package cmd
 
 import (
+	"conintracker-hiring/pkg/analysis"
+	"conintracker-hiring/pkg/filter"
+	"conintracker-hiring/pkg/models"
 	"conintracker-hiring/pkg/output"
 	"conintracker-hiring/pkg/providers"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"net/http"
+	"io"
+	"math"
+	"math/rand/v2"
 	"os"
+	"path/filepath"
 	"regexp"
+	"slices"
+	"sort"
 	"strings"
 	"time"
+	_ "time/tzdata" // Embed the zone database so --timezone works on hosts without one
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var (
-	address    string
-	outputFile string
-	startPage  int
-	endPage    int
-	provider   string
+	address     string
+	outputFile  string
+	startPage   int
+	endPage     int
+	pageSize    int
+	provider    string
+	chain       string
+	countOnly   bool
+	explain     bool
+	noHeader    bool
+	human       bool
+	timezone    string
+	timeFormat  string
+	appendMode  bool
+	columns     []string
+	decimals    int
+	compactAmts bool
+	addrCase    string
+	failOnEmpty bool
+	includeMeta bool
+	includeConf bool
+	splitAmount bool
+	redactAddrs bool
+	redactAll   bool
+	onlyParty   bool
+	approvOnly  bool
+	minAmount   string
+	txTypes     []string
+	maxTxs      int
+	noInternal  bool
+	noERC20     bool
+	noERC721    bool
+	noERC1155   bool
+	maxAmount   string
+	manifest    string
+	errorsFile  string
+	statsFile   string
+	shards      int
+	sanitize    bool
+	formats     []string
+	sortOrder   string
+	layout      string
+	saveRaw     string
+	quiet       bool
+	withBals    bool
+	partitionBy string
+	maxErrRate  float64
+	strictNorm  bool
+	checksum    bool
+	sampleSize  int
+	sampleSeed  uint64
+	jsonSummary bool
+
+	// etherscanBaseURL and moralisBaseURL are the API endpoints used by fetch; tests point them at a local server
+	etherscanBaseURL = providers.EtherscanBaseURL
+	moralisBaseURL   = providers.MoralisBaseURL
+
+	// etherscanRateLimit is the minimum spacing between Etherscan requests; 0 uses
+	// the client's default. Tests shorten it.
+	etherscanRateLimit time.Duration
+
+	// etherscanClock paces Etherscan requests; nil uses the real clock. Tests swap
+	// in a fake so rate limit assertions do not depend on sleeps.
+	etherscanClock providers.Clock
+
+	// openSink opens each output destination. s3:// paths need an uploader, which
+	// this build does not configure; embedders and tests swap in one that does.
+	openSink = output.NewSinkFactory(nil)
+
+	// fetchNormalizer wraps the configured normalizer before fetching; tests swap it
+	// to inject normalization failures
+	fetchNormalizer = func(n *providers.EtherscanNormalizer) providers.Normalizer { return n }
 )
 
 // fetchCmd represents the fetch command
@@ -35,94 +114,410 @@ func init() {
 
 	// Command-specific flags
 	fetchCmd.Flags().StringVarP(&address, "address", "a", "", "Ethereum wallet address (required)")
-	fetchCmd.Flags().StringVarP(&outputFile, "output", "o", "", "Output CSV file path (default: transactions.csv)")
+	fetchCmd.Flags().StringVarP(&outputFile, "output", "o", "", "Output file path; with several formats each gets its extension (default: transactions.csv)")
+	fetchCmd.Flags().StringSliceVar(&formats, "format", []string{output.FormatCSV}, "Output formats, comma-separated ("+strings.Join(output.Formats, ", ")+")")
 	fetchCmd.Flags().IntVar(&startPage, "start-page", 1, "Starting page for pagination")
 	fetchCmd.Flags().IntVar(&endPage, "end-page", 1, "Ending page for pagination")
-	fetchCmd.Flags().StringVarP(&provider, "provider", "p", "etherscan", "Data provider (currently only 'etherscan' supported)")
+	fetchCmd.Flags().IntVar(&pageSize, "page-size", providers.DefaultPageSize, "Records per page (Etherscan offset, max 10000)")
+	fetchCmd.Flags().StringVarP(&provider, "provider", "p", "etherscan", "Data provider: etherscan or moralis")
+	fetchCmd.Flags().StringVar(&chain, "chain", providers.DefaultChain, "Chain to query ("+strings.Join(providers.SupportedChains(), ", ")+")")
+	fetchCmd.Flags().BoolVar(&appendMode, "append", false, "Append to an existing output file, skipping rows it or earlier runs already wrote (tracked in <output>.seen). A file without <output>.seen is matched on its rows as written, so keep --decimals, --compact-amounts and --redact-addresses the same or rows will be appended again")
+	fetchCmd.Flags().StringVar(&timezone, "timezone", "UTC", "IANA time zone for exported timestamps (e.g. America/New_York)")
+	fetchCmd.Flags().StringVar(&timeFormat, "time-format", output.TimeFormatRFC3339, "Timestamp format ("+strings.Join(output.TimeFormats, ", ")+"); rfc3339nano keeps sub-second precision")
+	fetchCmd.Flags().StringSliceVar(&columns, "columns", nil, "Optional CSV columns to include ("+strings.Join(output.UnpricedColumns(), ", ")+")")
+	fetchCmd.Flags().BoolVar(&includeMeta, "include-metadata", false, "Include Block Number, Gas Used, Gas Price (Gwei) and Nonce columns")
+	fetchCmd.Flags().BoolVar(&includeConf, "include-confirmations", false, "Include a Confirmations column (blocks mined on top as of the fetch)")
+	fetchCmd.Flags().BoolVar(&splitAmount, "split-amount", false, "Include Amount Whole and Amount Fraction columns splitting Value / Amount at the decimal point")
+	fetchCmd.Flags().IntVar(&maxTxs, "max-transactions", 0, "Stop fetching after this many transactions to bound memory (0 for no limit)")
+	fetchCmd.Flags().IntVar(&sampleSize, "sample", 0, "Export a random sample of this many transactions, in order, instead of all of them (0 for all)")
+	fetchCmd.Flags().Uint64Var(&sampleSeed, "sample-seed", 0, "Seed for --sample, to draw the same rows again (0 for a random seed, which is printed)")
+	fetchCmd.Flags().IntVar(&shards, "shards", 1, "Split each type's block range into this many shards fetched concurrently (etherscan only)")
+	fetchCmd.Flags().BoolVar(&redactAddrs, "redact-addresses", false, "Mask counterparty and contract addresses as 0x1234…abcd (the queried address stays visible)")
+	fetchCmd.Flags().BoolVar(&redactAll, "redact-all", false, "Mask every address, including the queried one")
+	fetchCmd.Flags().BoolVar(&human, "human", false, "Group amounts with thousands separators (1,234.56) for reports")
+	fetchCmd.Flags().StringVar(&layout, "layout", output.LayoutDefault, "CSV column layout: default, or etherscan (Etherscan's own CSV export columns, with value split into IN/OUT)")
+	fetchCmd.Flags().StringVar(&partitionBy, "partition-by", "", "Write one file per year or month of the transactions' dates, e.g. transactions-2023.csv (year, month)")
+	fetchCmd.Flags().BoolVar(&sanitize, "sanitize", false, "Prefix cells starting with =, +, - or @ with ' so spreadsheets don't run them as formulas")
+	fetchCmd.Flags().BoolVar(&noHeader, "no-header", false, "Omit the CSV header row (useful when concatenating exports)")
+	fetchCmd.Flags().BoolVar(&withBals, "with-balances", false, "Append each exported ERC-20 token's current balance to the summary (one extra request per token, etherscan only)")
+	addFilterFlags(fetchCmd.Flags())
+	addNormalizerFlags(fetchCmd.Flags())
+	fetchCmd.Flags().BoolVar(&quiet, "quiet", false, "Hide fetch and write progress (shown as a live bar on a terminal, as lines otherwise)")
+	fetchCmd.Flags().BoolVar(&failOnEmpty, "fail-on-empty", false, "Exit with a non-zero status (2) when no transactions are found")
+	fetchCmd.Flags().StringVar(&saveRaw, "save-raw", "", "Also save the raw provider records to this directory, one JSON file per type, for the normalize command")
+	fetchCmd.Flags().Float64Var(&maxErrRate, "max-error-rate", 1, "Exit non-zero after writing the export when more than this share of records fails to normalize, e.g. 0.01 for 1%")
+	fetchCmd.Flags().BoolVar(&strictNorm, "strict", false, "Fail before writing if any record cannot be normalized exactly, including tokens with invalid decimals; with --max-error-rate, fail only above that rate")
+	fetchCmd.Flags().StringVar(&errorsFile, "errors-file", "", "Write transactions that failed to normalize, with their errors, to this JSON file")
+	fetchCmd.Flags().StringVar(&statsFile, "stats-file", "", "Write per-type normalization counts (processed, success, errors) to this JSON file")
+	fetchCmd.Flags().StringVar(&manifest, "manifest", "", "Write a JSON manifest describing the export to this path")
+	fetchCmd.Flags().BoolVar(&jsonSummary, "json-summary", false, "After a successful run, print a one-line JSON summary (address, total, by_type, gas_eth, counterparties, truncated) to stderr")
+	fetchCmd.Flags().BoolVar(&checksum, "checksum", false, "Write each output file's SHA-256 digest to <output>.sha256 and print it")
+	fetchCmd.Flags().BoolVar(&countOnly, "count-only", false, "Only count transactions per type without exporting them")
+	fetchCmd.Flags().BoolVar(&explain, "explain", false, "Print the resolved configuration and planned requests, then exit without making network calls")
 
 	// Mark required flags
 	fetchCmd.MarkFlagRequired("address")
 }
 
-func runFetch(cmd *cobra.Command, args []string) error {
+func runFetch(cmd *cobra.Command, args []string) (retErr error) {
 	// Validate address format
 	if !isValidEthereumAddress(address) {
 		return fmt.Errorf("invalid Ethereum address format: %s", address)
 	}
 
-	// Get API key from flag or environment variable
-	etherscanKey := apiKey
-	if etherscanKey == "" {
-		etherscanKey = os.Getenv("ETHERSCAN_API_KEY")
+	if _, ok := providers.ChainID(chain); !ok {
+		return fmt.Errorf("unsupported chain %q (supported: %s)", chain, strings.Join(providers.SupportedChains(), ", "))
+	}
+
+	if startPage < 1 || endPage < 1 {
+		return fmt.Errorf("invalid page range %d-%d: --start-page and --end-page must be at least 1", startPage, endPage)
+	}
+	if startPage > endPage {
+		return fmt.Errorf("invalid page range: --start-page %d is after --end-page %d", startPage, endPage)
+	}
+
+	if pageSize < 1 || pageSize > providers.MaxPageSize {
+		return fmt.Errorf("invalid page size %d: must be between 1 and %d", pageSize, providers.MaxPageSize)
+	}
+
+	if shards < 1 {
+		return fmt.Errorf("invalid shard count %d: must be at least 1", shards)
+	}
+	if shards > 1 && maxTxs > 0 {
+		return fmt.Errorf("--shards cannot be combined with --max-transactions")
+	}
+	if shards > 1 && saveRaw != "" {
+		return fmt.Errorf("--shards cannot be combined with --save-raw")
+	}
+
+	if sampleSize < 0 {
+		return fmt.Errorf("invalid --sample %d: must be at least 0", sampleSize)
 	}
-	if etherscanKey == "" {
-		return fmt.Errorf("Etherscan API key is required (set via --api-key flag or ETHERSCAN_API_KEY env var)")
+
+	if maxErrRate < 0 || maxErrRate > 1 {
+		return fmt.Errorf("invalid --max-error-rate %g: must be between 0 and 1", maxErrRate)
 	}
 
-	// Set default output file
-	if outputFile == "" {
-		outputFile = "transactions.csv"
+	rows, err := parseRowFilters(address)
+	if err != nil {
+		return err
 	}
 
-	// Create output file
-	file, err := os.Create(outputFile)
+	normalizer, err := newNormalizer()
 	if err != nil {
-		return fmt.Errorf("failed to create output file: %w", err)
+		return err
 	}
-	defer file.Close()
 
-	// Print progress
-	fmt.Printf("Fetching transactions for address: %s\n", address)
-	fmt.Printf("Output file: %s\n\n", outputFile)
-
-	// Create Etherscan client
-	client := providers.NewEtherscanClient(providers.ClientConfig{
-		APIKey: etherscanKey,
-		HTTPClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-	})
+	fetchTypes, err := selectedTypes()
+	if err != nil {
+		return err
+	}
 
-	// Create normalizer and fetcher
-	normalizer := providers.NewEtherscanNormalizer()
-	fetcher := providers.NewTransactionFetcher(client, normalizer)
+	// Resolve output time zone before doing any network work
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return fmt.Errorf("invalid timezone %q: %w", timezone, err)
+	}
+	timeLayout, err := output.LookupTimeFormat(timeFormat)
+	if err != nil {
+		return err
+	}
 
-	// Fetch transactions
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	columnNames := columns
+	if includeMeta {
+		columnNames = append(columnNames, output.MetadataColumns...)
+	}
+	if includeConf {
+		columnNames = append(columnNames, "confirmations")
+	}
+	if splitAmount {
+		columnNames = append(columnNames, output.SplitAmountColumns...)
+	}
+	extraColumns, err := output.LookupUnpricedColumns(columnNames)
+	if err != nil {
+		return err
+	}
+
+	providerName := strings.ToLower(provider)
+	providerKey, err := resolveAPIKey(providerName)
+	if err != nil {
+		return err
+	}
+	if providerName == "etherscan" && endPage*pageSize > providers.ResultWindow {
+		return fmt.Errorf("--end-page %d at --page-size %d passes Etherscan's %d-record result window; use --shards to split larger histories by block range", endPage, pageSize, providers.ResultWindow)
+	}
+	if withBals && providerName != "etherscan" {
+		return fmt.Errorf("--with-balances requires the etherscan provider")
+	}
+
+	// Set default output file, named for the format when there is only one
+	outputFile = defaultOutputFile(outputFile, formats)
+	outputs, err := outputPaths(outputFile, formats)
+	if err != nil {
+		return err
+	}
+	if appendMode && (len(outputs) > 1 || outputs[0].format != output.FormatCSV) {
+		return fmt.Errorf("--append only supports --format csv")
+	}
+	if !slices.Contains(output.Layouts, layout) {
+		return fmt.Errorf("invalid layout %q (available: %s)", layout, strings.Join(output.Layouts, ", "))
+	}
+	var layoutColumns []output.Column
+	if layout == output.LayoutEtherscan {
+		if appendMode || len(outputs) > 1 || outputs[0].format != output.FormatCSV {
+			return fmt.Errorf("--layout %s only supports --format csv without --append", layout)
+		}
+		layoutColumns = output.EtherscanLayout(address)
+	}
+	if appendMode && output.IsS3Path(outputFile) {
+		return fmt.Errorf("--append only supports local output files")
+	}
+	if checksum && output.IsS3Path(outputFile) {
+		return fmt.Errorf("--checksum only supports local output files")
+	}
+	if partitionBy != "" {
+		if !slices.Contains(output.Partitions, partitionBy) {
+			return fmt.Errorf("invalid partition %q (available: %s)", partitionBy, strings.Join(output.Partitions, ", "))
+		}
+		if appendMode {
+			return fmt.Errorf("--partition-by cannot be combined with --append")
+		}
+	}
+
+	// Create the provider client. --page-size is Etherscan's offset; Moralis pages
+	// by cursor at its own fixed size.
+	var client providers.Provider
+	providerPageSize := pageSize
+	switch providerName {
+	case "moralis":
+		client = providers.NewMoralisClient(providers.MoralisConfig{
+			APIKey:     providerKey,
+			BaseURL:    moralisBaseURL,
+			Chain:      chain,
+			HTTPClient: newHTTPClient(),
+		})
+		providerPageSize = providers.DefaultMoralisPageSize
+	default:
+		client = providers.NewEtherscanClient(providers.ClientConfig{
+			APIKeys:    splitAPIKeys(providerKey),
+			BaseURL:    etherscanBaseURL,
+			Chain:      chain,
+			PageSize:   pageSize,
+			RateLimit:  etherscanRateLimit,
+			Clock:      etherscanClock,
+			HTTPClient: newHTTPClient(),
+		})
+	}
+
+	// Wrap the normalizer and create the fetcher
+	txNormalizer := fetchNormalizer(normalizer)
+	balancer, _ := client.(providers.TokenBalancer)
+	var recorder *providers.RecordingProvider
+	if saveRaw != "" {
+		recorder = providers.NewRecordingProvider(client)
+		client = recorder
+	}
+	fetcher := providers.NewTransactionFetcher(client, txNormalizer)
+	// A fetch returns at most pageSize records for each requested page
+	fetcher.SetWindowSize(providerPageSize * (endPage - startPage + 1))
+	fetcher.SetTypes(fetchTypes)
+	fetcher.SetMaxTransactions(maxTxs)
+	progress := newCommandProgress(quiet)
+	fetcher.SetProgressCallback(progress.Fetched)
+
+	if explain {
+		printPlan(cmd.OutOrStdout(), providerName, providerPageSize, len(splitAPIKeys(providerKey)), fetchTypes, outputs, extraColumns)
+		return nil
+	}
+
+	// Fetch transactions within --timeout; the command context is canceled on SIGINT/SIGTERM
+	ctx, cancel, err := commandContext(cmd)
+	if err != nil {
+		return err
+	}
 	defer cancel()
 
+	if countOnly {
+		return runCount(ctx, fetcher)
+	}
+
+	// Create the output files, or open the existing CSV for appending. Partition
+	// files depend on the transactions' dates and are created once they are known.
+	var appendFile *output.AppendFile
+	for i := range outputs {
+		if partitionBy != "" {
+			break
+		}
+		if appendMode {
+			appendFile, err = output.OpenAppendFile(outputs[i].path)
+			outputs[i].file = appendFile
+		} else {
+			outputs[i].file, err = openSink(ctx, outputs[i].path)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer func(file io.WriteCloser) { closeOutput(file, retErr) }(outputs[i].file)
+	}
+
+	// Print progress
+	fmt.Printf("Fetching transactions for address: %s\n", address)
+	for _, out := range outputs {
+		if partitionBy != "" {
+			fmt.Printf("Output files: %s, one per %s\n", output.PartitionPath(out.path, "<"+partitionBy+">"), partitionBy)
+			continue
+		}
+		fmt.Printf("Output file: %s\n", out.path)
+	}
+	fmt.Println()
+
 	fmt.Println("Fetching transactions...")
-	txs, err := fetcher.FetchAllTransactions(ctx, address, startPage, endPage)
-	if err != nil {
-		return fmt.Errorf("failed to fetch transactions: %w", err)
+	var result *providers.FetchResult
+	if shards > 1 {
+		result, err = fetchSharded(ctx, client, txNormalizer, fetchTypes, providerPageSize*(endPage-startPage+1), progress.Fetched)
+	} else {
+		result, err = fetcher.FetchAll(ctx, address, startPage, endPage)
+	}
+	progress.Finish()
+	// On interruption FetchAll returns what it had; export that rather than leave an empty file
+	interrupted := err != nil && result != nil && cmd.Context().Err() != nil
+	if err != nil && !interrupted {
+		return explainChainAccess(fmt.Errorf("failed to fetch transactions: %w", err))
+	}
+	if interrupted {
+		fmt.Fprintln(os.Stderr, "Interrupted, writing the transactions fetched so far...")
+	}
+	if err := writeErrorsFile(result.NormalizationStats.Errors); err != nil {
+		return err
+	}
+	if err := writeStatsFile(result); err != nil {
+		return err
+	}
+	if err := checkStrict(cmd, result.NormalizationStats); err != nil {
+		return err
+	}
+	if recorder != nil {
+		if err := providers.SaveRawDump(saveRaw, recorder.Dump()); err != nil {
+			return err
+		}
+		fmt.Printf("Saved raw responses to %s\n", saveRaw)
+	}
+	txs := result.Transactions
+	analysis.LabelWalletExecutions(txs)
+	analysis.CategorizeAll(txs)
+	analysis.LinkInternalParents(txs)
+	analysis.LinkApprovals(txs)
+	analysis.FlagUnlimitedApprovals(txs)
+
+	txs = rows.apply(txs)
+
+	if sampleSize > 0 && sampleSize < len(txs) {
+		seed := sampleSeed
+		if seed == 0 {
+			seed = rand.Uint64()
+		}
+		fmt.Printf("Sampling %d of %d transactions (--sample-seed %d)\n", sampleSize, len(txs), seed)
+		txs = filter.Sample(txs, sampleSize, seed)
 	}
 
+	// Note held tokens while their contract addresses are still unredacted
+	var tokens []balanceToken
+	if withBals {
+		tokens = exportedTokens(txs)
+	}
+
+	// Count counterparties before redaction masks the owner and can merge addresses
+	counterparties := analysis.UniqueCounterparties(txs, address)
+
+	// Redact before the append check so keys match rows already written redacted
+	if redactAddrs || redactAll {
+		keep := address
+		if redactAll {
+			keep = ""
+		}
+		for _, tx := range txs {
+			tx.RedactAddresses(keep)
+		}
+	}
+
+	sortRows(txs)
+
 	fmt.Printf("Found %d transactions\n", len(txs))
+	printTruncationWarning(result, "")
+	printUnknownDecimalsWarning(txs)
+	if result.Capped {
+		fmt.Fprintf(os.Stderr, "Warning: stopped fetching at --max-transactions %d; the export is incomplete\n", maxTxs)
+	}
+
+	// The manifest and JSON summary describe every fetched row, including those
+	// --append finds already written
+	fetched := txs
+	if appendFile != nil {
+		fresh := appendFile.FilterNew(txs)
+		if skipped := len(txs) - len(fresh); skipped > 0 {
+			fmt.Printf("Skipping %d transactions already in %s\n", skipped, outputFile)
+		}
+		txs = fresh
+	}
 
 	if len(txs) == 0 {
-		fmt.Println("No transactions found for this address")
-		return nil
+		if interrupted {
+			return interruptedError(len(txs))
+		}
+		if len(fetched) > 0 {
+			// Every fetched row is already in the append file; record any it matched
+			if err := appendFile.SaveSeen(); err != nil {
+				return err
+			}
+			fmt.Printf("No new transactions; %d already in %s\n", len(fetched), outputFile)
+		} else {
+			if failOnEmpty {
+				return fmt.Errorf("%w for address %s", ErrNoTransactions, address)
+			}
+			fmt.Println("No transactions found for this address")
+		}
+		if err := writeManifest(cmd, fetched); err != nil {
+			return err
+		}
+		if err := writeJSONSummary(cmd, fetched, result, counterparties); err != nil {
+			return err
+		}
+		return checkErrorRate(result.NormalizationStats)
 	}
 
-	// Write to CSV
-	fmt.Println("Writing to CSV...")
-	csvWriter, err := output.NewCSVWriter(output.CSVConfig{Writer: file})
+	// Write every requested format from the same transactions
+	config := output.CSVConfig{
+		OmitHeader:    noHeader || (appendFile != nil && !appendFile.NeedsHeader()),
+		Location:      location,
+		TimeLayout:    timeLayout,
+		Layout:        layoutColumns,
+		Columns:       extraColumns,
+		HumanReadable: human,
+		Sanitize:      sanitize,
+	}
+	if partitionBy != "" {
+		err = writePartitions(ctx, outputs, txs, config, progress.Wrote)
+	} else {
+		err = writeOutputs(outputs, txs, config, progress.Wrote)
+	}
+	progress.Finish()
 	if err != nil {
-		return fmt.Errorf("failed to create CSV writer: %w", err)
+		return err
 	}
-
-	if err := csvWriter.WriteTransactions(txs); err != nil {
-		csvWriter.Close()
-		return fmt.Errorf("failed to write transactions to CSV: %w", err)
+	if appendFile != nil {
+		if err := appendFile.SaveSeen(); err != nil {
+			return err
+		}
 	}
 
-	if err := csvWriter.Close(); err != nil {
-		return fmt.Errorf("failed to close CSV writer: %w", err)
+	if interrupted {
+		return interruptedError(len(txs))
 	}
 
 	// Print summary
-	fmt.Println("\n✓ Successfully exported transactions to CSV")
+	fmt.Printf("\n✓ Successfully exported transactions to %s\n", strings.ToUpper(strings.Join(formats, ", ")))
 	fmt.Printf("Total transactions: %d\n", len(txs))
+	fmt.Printf("Unique counterparties: %d\n", counterparties)
 
 	// Count by type
 	typeCounts := make(map[string]int)
@@ -134,10 +529,551 @@ func runFetch(cmd *cobra.Command, args []string) error {
 	for txType, count := range typeCounts {
 		fmt.Printf("  %s: %d\n", txType, count)
 	}
+	if withBals {
+		printBalances(ctx, cmd.OutOrStdout(), balancer, address, tokens)
+	}
 
+	if err := writeManifest(cmd, fetched); err != nil {
+		return err
+	}
+	if err := writeJSONSummary(cmd, fetched, result, counterparties); err != nil {
+		return err
+	}
+	return checkErrorRate(result.NormalizationStats)
+}
+
+// checkStrict fails a --strict fetch on its first normalization error, before anything
+// is written; a --max-error-rate below 1 defers the decision to checkErrorRate. Otherwise
+// the failed records are skipped and counted.
+func checkStrict(cmd *cobra.Command, stats providers.NormalizationStats) error {
+	if stats.ErrorCount == 0 {
+		return nil
+	}
+	if strictNorm && maxErrRate >= 1 {
+		hint := ""
+		if errorsFile == "" {
+			hint = "; rerun with --errors-file to inspect them"
+		}
+		return fmt.Errorf("%w: %d of %d records, first: %v%s",
+			ErrStrictNormalization, stats.ErrorCount, stats.TotalProcessed, stats.Errors[0], hint)
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Skipped %d records that failed to normalize\n", stats.ErrorCount)
 	return nil
 }
 
+// checkErrorRate fails when more than --max-error-rate of the fetched records
+// failed to normalize, summarizing the failures by type. It runs after the export
+// is written, so the rows that did normalize are kept.
+func checkErrorRate(stats providers.NormalizationStats) error {
+	rate := stats.ErrorRate()
+	if rate <= maxErrRate {
+		return nil
+	}
+
+	byType := make(map[string]int)
+	for _, err := range stats.Errors {
+		var normErr *providers.NormalizationError
+		if errors.As(err, &normErr) {
+			byType[normErr.Type]++
+		}
+	}
+	summary := make([]string, 0, len(byType))
+	for txType, count := range byType {
+		summary = append(summary, fmt.Sprintf("%s: %d", txType, count))
+	}
+	sort.Strings(summary)
+
+	hint := ""
+	if errorsFile == "" {
+		hint = "; rerun with --errors-file to inspect them"
+	}
+	return fmt.Errorf("%w: %d of %d records (%.1f%%) failed to normalize, above --max-error-rate %g (%s)%s",
+		ErrErrorRateExceeded, stats.ErrorCount, stats.TotalProcessed, rate*100, maxErrRate, strings.Join(summary, ", "), hint)
+}
+
+// Row orders accepted by --sort
+const (
+	sortBlock    = "block"
+	sortTime     = "time"
+	sortTimeDesc = "time-desc"
+)
+
+var sortOrders = []string{sortBlock, sortTime, sortTimeDesc}
+
+// outputTarget is an export destination for one format
+type outputTarget struct {
+	format string
+	path   string
+	file   io.WriteCloser
+}
+
+// defaultOutputFile returns path, or when it is empty transactions.csv, named for
+// the format when there is only one
+func defaultOutputFile(path string, formats []string) string {
+	if path != "" {
+		return path
+	}
+	if len(formats) == 1 {
+		return "transactions." + strings.ToLower(strings.TrimSpace(formats[0]))
+	}
+	return "transactions.csv"
+}
+
+// outputPaths resolves a destination for each requested format. A single format
+// writes to base as given; several formats each replace base's extension with
+// their own, e.g. transactions.csv and transactions.json.
+func outputPaths(base string, formats []string) ([]outputTarget, error) {
+	if len(formats) == 0 {
+		formats = []string{output.FormatCSV}
+	}
+
+	var targets []outputTarget
+	seen := make(map[string]bool)
+	for _, format := range formats {
+		format = strings.ToLower(strings.TrimSpace(format))
+		if !slices.Contains(output.Formats, format) {
+			return nil, fmt.Errorf("unsupported format %q (supported: %s)", format, strings.Join(output.Formats, ", "))
+		}
+		if seen[format] {
+			continue
+		}
+		seen[format] = true
+		targets = append(targets, outputTarget{format: format})
+	}
+
+	for i := range targets {
+		targets[i].path = base
+		if len(targets) > 1 {
+			targets[i].path = strings.TrimSuffix(base, filepath.Ext(base)) + "." + targets[i].format
+		}
+	}
+	return targets, nil
+}
+
+// writeProgressBatch is how many rows writeOutputs writes between progress reports
+const writeProgressBatch = 1000
+
+// writeOutputs writes txs to each opened output in its format. CSV outputs use
+// config with the target's writer; JSON outputs share its location, time layout and columns.
+// onWrite, when non-nil, is called with each output's format as rows are written.
+// With --checksum, each file's digest is saved next to it once it is complete.
+// A failed write aborts its output and those after it (see closeOutput).
+func writeOutputs(outputs []outputTarget, txs []*models.Transaction, config output.CSVConfig, onWrite func(format string, p output.WriteProgress)) error {
+	for i, out := range outputs {
+		name := strings.ToUpper(out.format)
+		fmt.Printf("Writing to %s...\n", name)
+
+		var exporter output.Exporter
+		var err error
+		switch out.format {
+		case output.FormatJSON:
+			exporter, err = output.NewJSONWriter(output.JSONConfig{
+				Writer:     out.file,
+				Location:   config.Location,
+				TimeLayout: config.TimeLayout,
+				Columns:    config.Columns,
+			})
+		default:
+			config.Writer = out.file
+			exporter, err = output.NewCSVWriter(config)
+		}
+		if err != nil {
+			err = fmt.Errorf("failed to create %s writer: %w", name, err)
+			abortOutputs(outputs[i:], err)
+			return err
+		}
+
+		for start := 0; start < len(txs); start += writeProgressBatch {
+			end := min(start+writeProgressBatch, len(txs))
+			if err := exporter.WriteTransactions(txs[start:end]); err != nil {
+				err = fmt.Errorf("failed to write transactions to %s: %w", name, err)
+				abortOutputs(outputs[i:], err)
+				exporter.Close()
+				return err
+			}
+			if onWrite != nil {
+				onWrite(out.format, output.WriteProgress{Written: end, Total: len(txs)})
+			}
+		}
+
+		if err := exporter.Close(); err != nil {
+			return fmt.Errorf("failed to close %s writer: %w", name, err)
+		}
+
+		if checksum {
+			// Hash the whole file, so appended exports cover the rows already in it
+			digest, err := output.WriteChecksumFile(out.path)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("SHA-256 %s  %s (saved to %s%s)\n", digest, out.path, out.path, output.ChecksumSuffix)
+		}
+	}
+	return nil
+}
+
+// closeOutput finishes an output when the command returns: after a failure it is
+// aborted, so an s3:// object isn't replaced by an empty or partial export
+func closeOutput(file io.WriteCloser, err error) {
+	if err != nil {
+		output.AbortSink(file, err)
+		return
+	}
+	file.Close()
+}
+
+// abortOutputs aborts outputs that a failed write leaves unfinished
+func abortOutputs(outputs []outputTarget, err error) {
+	for _, out := range outputs {
+		output.AbortSink(out.file, err)
+	}
+}
+
+// writePartitions writes txs to one set of outputs per --partition-by period, each
+// file named for its period and written with its own header. Periods without
+// transactions get no file.
+func writePartitions(ctx context.Context, outputs []outputTarget, txs []*models.Transaction, config output.CSVConfig, onWrite func(format string, p output.WriteProgress)) error {
+	partitions, err := output.PartitionByDate(txs, partitionBy, config.Location)
+	if err != nil {
+		return err
+	}
+
+	for _, part := range partitions {
+		targets := make([]outputTarget, len(outputs))
+		for i, out := range outputs {
+			targets[i] = outputTarget{format: out.format, path: output.PartitionPath(out.path, part.Key)}
+			targets[i].file, err = openSink(ctx, targets[i].path)
+			if err != nil {
+				return fmt.Errorf("failed to create output file: %w", err)
+			}
+			defer targets[i].file.Close()
+		}
+
+		fmt.Printf("%s: %d transactions\n", part.Key, len(part.Transactions))
+		if err := writeOutputs(targets, part.Transactions, config, onWrite); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// interruptedError reports a fetch cut short by a signal after count rows were written
+func interruptedError(count int) error {
+	return fmt.Errorf("%w: %d transactions in %s", ErrInterrupted, count, outputFile)
+}
+
+// manifestExcludedFlags are flags recorded elsewhere in the manifest or too sensitive to record
+var manifestExcludedFlags = map[string]bool{
+	"api-key":      true,
+	"address":      true,
+	"output":       true,
+	"provider":     true,
+	"chain":        true,
+	"manifest":     true,
+	"errors-file":  true,
+	"stats-file":   true,
+	"json-summary": true,
+}
+
+// writeManifest writes the --manifest sidecar for the exported transactions, if requested.
+// Filters are the flags explicitly set on the command line.
+func writeManifest(cmd *cobra.Command, txs []*models.Transaction) error {
+	if manifest == "" {
+		return nil
+	}
+
+	m := output.NewManifest(txs)
+	m.Addresses = []string{address}
+	m.Chain = strings.ToLower(chain)
+	m.Provider = provider
+	m.Output = outputFile
+	m.ToolVersion = version
+
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if manifestExcludedFlags[f.Name] {
+			return
+		}
+		if m.Filters == nil {
+			m.Filters = make(map[string]string)
+		}
+		m.Filters[f.Name] = f.Value.String()
+	})
+
+	if err := m.WriteFile(manifest); err != nil {
+		return err
+	}
+	fmt.Printf("Manifest written to %s\n", manifest)
+	return nil
+}
+
+// statsFileContents is the --stats-file layout: counts per transaction type name
+// (normal, erc20, ...) and their total
+type statsFileContents struct {
+	Types map[string]providers.NormalizationStats `json:"types"`
+	Total providers.NormalizationStats            `json:"total"`
+}
+
+// writeStatsFile writes the --stats-file sidecar with the run's normalization counts
+// for each fetched type, if requested
+func writeStatsFile(result *providers.FetchResult) error {
+	if statsFile == "" {
+		return nil
+	}
+
+	contents := statsFileContents{
+		Types: make(map[string]providers.NormalizationStats, len(result.TypeStats)),
+		Total: result.NormalizationStats,
+	}
+	for txType, stats := range result.TypeStats {
+		contents.Types[txType.Name()] = stats
+	}
+	data, err := json.MarshalIndent(contents, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode normalization stats: %w", err)
+	}
+	if err := os.WriteFile(statsFile, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write stats file: %w", err)
+	}
+	return nil
+}
+
+// writeJSONSummary prints the --json-summary line to stderr, if requested. Stopping
+// at --max-transactions counts as truncated, like a full result window.
+func writeJSONSummary(cmd *cobra.Command, txs []*models.Transaction, result *providers.FetchResult, counterparties int) error {
+	if !jsonSummary {
+		return nil
+	}
+	summary := output.NewRunSummary(address, txs, result.Truncated || result.Capped)
+	summary.Counterparties = counterparties
+	return summary.WriteLine(cmd.ErrOrStderr())
+}
+
+// writeErrorsFile writes the --errors-file sidecar listing each raw transaction that
+// failed to normalize, if requested. An empty list is written when nothing failed.
+func writeErrorsFile(errs []error) error {
+	if errorsFile == "" {
+		return nil
+	}
+
+	if errs == nil {
+		errs = []error{} // Encode as [] rather than null
+	}
+	data, err := json.MarshalIndent(errs, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode normalization errors: %w", err)
+	}
+	if err := os.WriteFile(errorsFile, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write errors file: %w", err)
+	}
+	if len(errs) > 0 {
+		fmt.Fprintf(os.Stderr, "Skipped %d transactions that failed to normalize; details in %s\n", len(errs), errorsFile)
+	}
+	return nil
+}
+
+// fetchSharded fetches each selected type in turn, splitting its block range into
+// --shards pieces that are fetched concurrently. Only providers that can query a
+// block range are split; others are fetched whole. windowSize is the most raw
+// records one shard can return; a type with a shard that fills it is truncated.
+func fetchSharded(ctx context.Context, client providers.Provider, normalizer providers.Normalizer, types []providers.TransactionType, windowSize int, onProgress providers.ProgressFunc) (*providers.FetchResult, error) {
+	pf := providers.NewParallelFetcher(client, normalizer)
+	pf.SetShards(shards)
+	pf.SetWindowSize(windowSize)
+	pf.SetTimeout(time.Duration(math.MaxInt64)) // The command context already carries --timeout
+
+	if _, ok := client.(providers.BlockRanger); !ok {
+		fmt.Fprintf(os.Stderr, "Warning: the %s provider cannot split block ranges; ignoring --shards\n", provider)
+	} else if etherscan, ok := client.(*providers.EtherscanClient); ok {
+		// Shard up to the chain head rather than the far-future default end block
+		latest, err := etherscan.LatestBlockNumber(ctx)
+		if err != nil {
+			return nil, err
+		}
+		pf.SetBlockRange(providers.DefaultStartBlock, latest)
+	}
+
+	result := &providers.FetchResult{}
+	for i, txType := range types {
+		typeResult := pf.FetchTypeSharded(ctx, txType, address, startPage, endPage)
+		count := len(typeResult.Txs)
+		if typeResult.Err != nil {
+			count = 0
+		}
+		onProgress(providers.FetchProgress{
+			TypesComplete: i + 1,
+			TypesTotal:    len(types),
+			Transactions:  len(result.Transactions) + count,
+			LastType:      txType,
+			LastCount:     count,
+			LastErr:       typeResult.Err,
+		})
+		if typeResult.Err != nil {
+			return nil, fmt.Errorf("%s fetch failed: %w", txType, typeResult.Err)
+		}
+		result.Transactions = append(result.Transactions, typeResult.Txs...)
+		result.AddTypeStats(txType, typeResult.NormalizationStats)
+		if typeResult.Truncated {
+			result.Truncated = true
+			result.TruncatedTypes = append(result.TruncatedTypes, txType)
+		}
+	}
+	sort.Stable(models.TransactionList(result.Transactions))
+	return result, nil
+}
+
+// printPlan prints what a fetch with the current flags would do, for --explain.
+// It runs after validation, so an explained plan is one the fetch would accept.
+func printPlan(w io.Writer, providerName string, pageSize, keys int, fetchTypes []providers.TransactionType, outputs []outputTarget, extraColumns []output.Column) {
+	baseURL := etherscanBaseURL
+	if providerName == "moralis" {
+		baseURL = moralisBaseURL
+	}
+	chainID, _ := providers.ChainID(chain)
+	pages := endPage - startPage + 1
+
+	typeNames := make([]string, 0, len(fetchTypes))
+	for _, txType := range fetchTypes {
+		typeNames = append(typeNames, txType.Name())
+	}
+
+	fmt.Fprintln(w, "Fetch plan (no requests made):")
+	fmt.Fprintf(w, "  Address:      %s\n", address)
+	fmt.Fprintf(w, "  Provider:     %s (%d API key(s))\n", providerName, keys)
+	fmt.Fprintf(w, "  Chain:        %s (chain ID %d)\n", chain, chainID)
+	fmt.Fprintf(w, "  Base URL:     %s\n", baseURL)
+	fmt.Fprintf(w, "  Types:        %s\n", strings.Join(typeNames, ", "))
+	fmt.Fprintf(w, "  Pages:        %d-%d, %d records per page\n", startPage, endPage, pageSize)
+
+	switch {
+	case shards > 1:
+		fmt.Fprintf(w, "  Requests:     each type's block range split into %d shards, paged within each shard\n", shards)
+	case providerName == "moralis":
+		fmt.Fprintf(w, "  Requests:     up to %d (%d types x %d pages, following Moralis cursors)\n", len(fetchTypes)*pages, len(fetchTypes), pages)
+	default:
+		fmt.Fprintf(w, "  Requests:     up to %d (%d types x %d pages)\n", len(fetchTypes)*pages, len(fetchTypes), pages)
+	}
+	if maxTxs > 0 {
+		fmt.Fprintf(w, "  Stop after:   %d transactions\n", maxTxs)
+	}
+
+	var filters []string
+	if onlyParty {
+		filters = append(filters, "only-party")
+	}
+	if approvOnly {
+		filters = append(filters, "approvals-only")
+	}
+	if minAmount != "" {
+		filters = append(filters, "min-amount "+minAmount)
+	}
+	if maxAmount != "" {
+		filters = append(filters, "max-amount "+maxAmount)
+	}
+	if sampleSize > 0 {
+		filters = append(filters, fmt.Sprintf("sample %d", sampleSize))
+	}
+	if len(filters) == 0 {
+		filters = append(filters, "none")
+	}
+	fmt.Fprintf(w, "  Filters:      %s\n", strings.Join(filters, ", "))
+
+	for _, out := range outputs {
+		path := out.path
+		if partitionBy != "" {
+			path = output.PartitionPath(out.path, "<"+partitionBy+">")
+		}
+		mode := ""
+		if appendMode {
+			mode = ", appending"
+		}
+		fmt.Fprintf(w, "  Output:       %s (%s%s)\n", path, out.format, mode)
+	}
+
+	columnNames := make([]string, 0, len(extraColumns))
+	for _, col := range extraColumns {
+		columnNames = append(columnNames, col.Name)
+	}
+	if len(columnNames) == 0 {
+		columnNames = append(columnNames, "none")
+	}
+	fmt.Fprintf(w, "  Layout:       %s, extra columns: %s\n", layout, strings.Join(columnNames, ", "))
+	fmt.Fprintf(w, "  Timestamps:   %s in %s\n", timeFormat, timezone)
+	fmt.Fprintf(w, "  Sort:         %s\n", sortOrder)
+}
+
+// runCount prints per-type transaction counts without writing any output file
+func runCount(ctx context.Context, fetcher *providers.TransactionFetcher) error {
+	fmt.Printf("Counting transactions for address: %s\n\n", address)
+
+	counts, err := fetcher.CountTransactions(ctx, address)
+	if err != nil {
+		return fmt.Errorf("failed to count transactions: %w", err)
+	}
+
+	var total providers.TransactionCount
+	fmt.Println("Transaction counts:")
+	for _, txType := range []providers.TransactionType{
+		providers.TxTypeNormal,
+		providers.TxTypeInternal,
+		providers.TxTypeToken,
+		providers.TxTypeNFT,
+		providers.TxTypeERC1155,
+		providers.TxTypeWithdrawal,
+	} {
+		fmt.Printf("  %s: %s\n", txType, counts[txType])
+		total.Count += counts[txType].Count
+		total.AtLeast = total.AtLeast || counts[txType].AtLeast
+	}
+	fmt.Printf("\nTotal transactions: %s\n", total)
+	if total.AtLeast {
+		fmt.Fprintln(os.Stderr, "Warning: counts marked ≥ stopped at the provider's last page; the true totals may be higher")
+	}
+
+	return nil
+}
+
+// printTruncationWarning warns when a transaction type filled its whole result window.
+// Pages can only reach further while they stay within Etherscan's result window;
+// past it, the block range must be split with --shards. A non-empty address names
+// the wallet the warning is about, for commands that export several.
+func printTruncationWarning(result *providers.FetchResult, address string) {
+	if !result.Truncated {
+		return
+	}
+
+	types := make([]string, 0, len(result.TruncatedTypes))
+	for _, txType := range result.TruncatedTypes {
+		types = append(types, txType.String())
+	}
+	hint := "split the block range with --shards"
+	if shards <= 1 && endPage*pageSize < providers.ResultWindow {
+		if (endPage+1)*pageSize <= providers.ResultWindow {
+			hint += " or raise --end-page"
+		} else {
+			hint += " or use a smaller --page-size with a higher --end-page"
+		}
+	}
+	what := strings.Join(types, ", ")
+	if address != "" {
+		what += " of " + address
+	}
+	fmt.Fprintf(os.Stderr, "Warning: results may be truncated for %s; %s\n", what, hint)
+}
+
+// printUnknownDecimalsWarning warns when token transfers were exported with raw
+// integer amounts because their token reported no valid decimals
+func printUnknownDecimalsWarning(txs []*models.Transaction) {
+	unknown := 0
+	for _, tx := range txs {
+		if tx.UnknownDecimals {
+			unknown++
+		}
+	}
+	if unknown > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: %d token transfers report missing or invalid decimals; their amounts are raw integers\n", unknown)
+	}
+}
+
 // isValidEthereumAddress validates Ethereum address format
 func isValidEthereumAddress(addr string) bool {
 	// Ethereum addresses are 42 characters long (0x + 40 hex chars)
@@ -0,0 +1,229 @@
+package cmd
+
+import (
+	"conintracker-hiring/pkg/analysis"
+	"conintracker-hiring/pkg/output"
+	"conintracker-hiring/pkg/providers"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	outputDir   string
+	addrWorkers int
+)
+
+// fetchManyCmd exports several wallets in one run, one file per address
+var fetchManyCmd = &cobra.Command{
+	Use:   "fetch-many <address>...",
+	Short: "Fetch transaction history for several Ethereum wallet addresses",
+	Long:  `Fetches all transactions for each address concurrently through one Etherscan client, so every address shares its rate limiter, and exports each address to its own file.`,
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runFetchMany,
+}
+
+func init() {
+	rootCmd.AddCommand(fetchManyCmd)
+
+	fetchManyCmd.Flags().IntVar(&addrWorkers, "address-concurrency", providers.DefaultFetchManyConcurrency, "Addresses fetched at once; all share the request rate limit")
+	fetchManyCmd.Flags().StringVar(&outputDir, "output-dir", ".", "Directory for the exports, named <address>.<format>")
+	fetchManyCmd.Flags().StringSliceVar(&formats, "format", []string{output.FormatCSV}, "Output formats, comma-separated ("+strings.Join(output.Formats, ", ")+")")
+	fetchManyCmd.Flags().IntVar(&startPage, "start-page", 1, "Starting page for pagination")
+	fetchManyCmd.Flags().IntVar(&endPage, "end-page", 1, "Ending page for pagination")
+	fetchManyCmd.Flags().IntVar(&pageSize, "page-size", providers.DefaultPageSize, "Records per page (Etherscan offset, max 10000)")
+	fetchManyCmd.Flags().StringVar(&chain, "chain", providers.DefaultChain, "Chain to query ("+strings.Join(providers.SupportedChains(), ", ")+")")
+	fetchManyCmd.Flags().StringVar(&timezone, "timezone", "UTC", "IANA time zone for exported timestamps (e.g. America/New_York)")
+	fetchManyCmd.Flags().StringVar(&timeFormat, "time-format", output.TimeFormatRFC3339, "Timestamp format ("+strings.Join(output.TimeFormats, ", ")+"); rfc3339nano keeps sub-second precision")
+	fetchManyCmd.Flags().StringSliceVar(&columns, "columns", nil, "Optional CSV columns to include ("+strings.Join(output.UnpricedColumns(), ", ")+")")
+	fetchManyCmd.Flags().Float64Var(&maxErrRate, "max-error-rate", 1, "Exit non-zero after writing the exports when more than this share of records fails to normalize, across all addresses")
+	fetchManyCmd.Flags().BoolVar(&strictNorm, "strict", false, "Fail before writing if any record of any address cannot be normalized exactly; with --max-error-rate, fail only above that rate")
+	fetchManyCmd.Flags().StringVar(&errorsFile, "errors-file", "", "Write transactions that failed to normalize, with their errors, to this JSON file")
+	addFilterFlags(fetchManyCmd.Flags())
+	addNormalizerFlags(fetchManyCmd.Flags())
+}
+
+func runFetchMany(cmd *cobra.Command, args []string) error {
+	if addrWorkers < 1 {
+		return fmt.Errorf("invalid --address-concurrency %d: must be at least 1", addrWorkers)
+	}
+
+	// Lowercase so the same wallet given in two casings is fetched once
+	addresses := make([]string, 0, len(args))
+	for _, addr := range args {
+		if !isValidEthereumAddress(addr) {
+			return fmt.Errorf("invalid Ethereum address format: %s", addr)
+		}
+		addresses = append(addresses, strings.ToLower(addr))
+	}
+
+	if _, ok := providers.ChainID(chain); !ok {
+		return fmt.Errorf("unsupported chain %q (supported: %s)", chain, strings.Join(providers.SupportedChains(), ", "))
+	}
+	if startPage < 1 || endPage < 1 {
+		return fmt.Errorf("invalid page range %d-%d: --start-page and --end-page must be at least 1", startPage, endPage)
+	}
+	if startPage > endPage {
+		return fmt.Errorf("invalid page range: --start-page %d is after --end-page %d", startPage, endPage)
+	}
+	if pageSize < 1 || pageSize > providers.MaxPageSize {
+		return fmt.Errorf("invalid page size %d: must be between 1 and %d", pageSize, providers.MaxPageSize)
+	}
+	if endPage*pageSize > providers.ResultWindow {
+		return fmt.Errorf("--end-page %d at --page-size %d passes Etherscan's %d-record result window", endPage, pageSize, providers.ResultWindow)
+	}
+	if maxErrRate < 0 || maxErrRate > 1 {
+		return fmt.Errorf("invalid --max-error-rate %g: must be between 0 and 1", maxErrRate)
+	}
+
+	rows, err := parseRowFilters("")
+	if err != nil {
+		return err
+	}
+	normalizer, err := newNormalizer()
+	if err != nil {
+		return err
+	}
+	fetchTypes, err := selectedTypes()
+	if err != nil {
+		return err
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return fmt.Errorf("invalid timezone %q: %w", timezone, err)
+	}
+	timeLayout, err := output.LookupTimeFormat(timeFormat)
+	if err != nil {
+		return err
+	}
+	extraColumns, err := output.LookupUnpricedColumns(columns)
+	if err != nil {
+		return err
+	}
+	// Resolve one address's paths up front so a bad --format fails before fetching
+	if _, err := addressOutputs(addresses[0]); err != nil {
+		return err
+	}
+
+	if !output.IsS3Path(outputDir) {
+		if err := os.MkdirAll(outputDir, 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+
+	etherscanKey, err := resolveAPIKey("etherscan")
+	if err != nil {
+		return err
+	}
+	client := providers.NewEtherscanClient(providers.ClientConfig{
+		APIKeys:    splitAPIKeys(etherscanKey),
+		BaseURL:    etherscanBaseURL,
+		Chain:      chain,
+		PageSize:   pageSize,
+		RateLimit:  etherscanRateLimit,
+		Clock:      etherscanClock,
+		HTTPClient: newHTTPClient(),
+	})
+	fetcher := providers.NewTransactionFetcher(client, normalizer)
+	// A fetch returns at most pageSize records for each requested page
+	fetcher.SetWindowSize(pageSize * (endPage - startPage + 1))
+	fetcher.SetTypes(fetchTypes)
+
+	ctx, cancel, err := commandContext(cmd)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	fmt.Printf("Fetching %d addresses, %d at a time...\n", len(addresses), addrWorkers)
+	results, err := fetcher.FetchMany(ctx, addresses, providers.FetchManyOptions{
+		Concurrency: addrWorkers,
+		StartPage:   startPage,
+		EndPage:     endPage,
+	})
+	if err != nil {
+		return explainChainAccess(fmt.Errorf("failed to fetch transactions: %w", err))
+	}
+	stats := combinedStats(addresses, results)
+	if err := writeErrorsFile(stats.Errors); err != nil {
+		return err
+	}
+	if err := checkStrict(cmd, stats); err != nil {
+		return err
+	}
+
+	config := output.CSVConfig{Location: location, TimeLayout: timeLayout, Columns: extraColumns}
+	for _, addr := range addresses {
+		result, ok := results[addr]
+		if !ok {
+			continue // Listed twice; already written
+		}
+		delete(results, addr)
+		txs := result.Transactions
+
+		analysis.LabelWalletExecutions(txs)
+		analysis.CategorizeAll(txs)
+		analysis.LinkInternalParents(txs)
+		analysis.LinkApprovals(txs)
+		analysis.FlagUnlimitedApprovals(txs)
+		filters := *rows
+		filters.party = addr
+		txs = filters.apply(txs)
+		sortRows(txs)
+
+		outputs, err := addressOutputs(addr)
+		if err != nil {
+			return err
+		}
+		for i := range outputs {
+			outputs[i].file, err = openSink(ctx, outputs[i].path)
+			if err != nil {
+				return fmt.Errorf("failed to create output file: %w", err)
+			}
+			defer outputs[i].file.Close()
+		}
+		if err := writeOutputs(outputs, txs, config, nil); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Exported %d transactions for %s to %s\n", len(txs), addr, outputs[0].path)
+		printTruncationWarning(result, addr)
+	}
+	return checkErrorRate(stats)
+}
+
+// combinedStats sums the normalization stats of every address, in argument order,
+// so --strict and --max-error-rate judge the run as a whole
+func combinedStats(addresses []string, results map[string]*providers.FetchResult) providers.NormalizationStats {
+	var total providers.NormalizationStats
+	counted := make(map[string]bool, len(results))
+	for _, addr := range addresses {
+		result, ok := results[addr]
+		if !ok || counted[addr] {
+			continue
+		}
+		counted[addr] = true
+		stats := result.NormalizationStats
+		total.TotalProcessed += stats.TotalProcessed
+		total.SuccessCount += stats.SuccessCount
+		total.ErrorCount += stats.ErrorCount
+		total.Errors = append(total.Errors, stats.Errors...)
+	}
+	return total
+}
+
+// addressOutputs resolves addr's export paths in --output-dir, e.g. <dir>/0xabc….csv
+func addressOutputs(addr string) ([]outputTarget, error) {
+	ext := output.FormatCSV
+	if len(formats) > 0 {
+		ext = strings.ToLower(strings.TrimSpace(formats[0]))
+	}
+	name := addr + "." + ext
+	if output.IsS3Path(outputDir) {
+		return outputPaths(strings.TrimSuffix(outputDir, "/")+"/"+name, formats)
+	}
+	return outputPaths(filepath.Join(outputDir, name), formats)
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"conintracker-hiring/internal/testdata"
+)
+
+// fakeClock is a providers.Clock whose After advances time immediately instead of sleeping
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (f *fakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *fakeClock) After(d time.Duration) <-chan time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+	ch := make(chan time.Time, 1)
+	ch <- f.now
+	return ch
+}
+
+func TestFetchManyHonorsAddressConcurrency(t *testing.T) {
+	const (
+		concurrency = 2
+		rateLimit   = time.Hour // Would time the test out if actually slept
+	)
+
+	var (
+		mu       sync.Mutex
+		inFlight = map[string]bool{}
+		peak     int
+		requests int
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		address := r.URL.Query().Get("address")
+		mu.Lock()
+		requests++
+		inFlight[address] = true
+		peak = max(peak, len(inFlight))
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond) // Gives other workers a chance to overlap
+
+		mu.Lock()
+		delete(inFlight, address)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(testdata.EmptyResultResponse))
+	}))
+	defer server.Close()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := &fakeClock{now: start}
+	previousURL, previousRate, previousClock := etherscanBaseURL, etherscanRateLimit, etherscanClock
+	etherscanBaseURL, etherscanRateLimit, etherscanClock = server.URL, rateLimit, clock
+	defer func() {
+		etherscanBaseURL, etherscanRateLimit, etherscanClock = previousURL, previousRate, previousClock
+	}()
+	resetFlags(t, fetchManyCmd)
+
+	wallets := []string{
+		"0x1111111111111111111111111111111111111111",
+		"0x2222222222222222222222222222222222222222",
+		"0x3333333333333333333333333333333333333333",
+		"0x4444444444444444444444444444444444444444",
+	}
+	dir := t.TempDir()
+	args := append([]string{
+		"fetch-many",
+		"--api-key", "test-key",
+		"--output-dir", dir,
+		"--address-concurrency", strconv.Itoa(concurrency),
+		"--start-page", "1",
+		"--end-page", "1",
+	}, wallets...)
+	rootCmd.SetArgs(args)
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("fetch-many error = %v", err)
+	}
+
+	if peak < 1 || peak > concurrency {
+		t.Errorf("Peak addresses in flight = %d, want between 1 and %d", peak, concurrency)
+	}
+	// Concurrent addresses share one rate limit rather than getting one each, so
+	// every request after the first waits for its own slot on the shared clock
+	if elapsed, minSpan := clock.Now().Sub(start), time.Duration(requests-1)*rateLimit; elapsed < minSpan {
+		t.Errorf("%d requests were paced over %v, want at least %v", requests, elapsed, minSpan)
+	}
+	for _, wallet := range wallets {
+		if _, err := os.Stat(filepath.Join(dir, wallet+".csv")); err != nil {
+			t.Errorf("Expected an export for %s: %v", wallet, err)
+		}
+	}
+}
+
+func TestFetchManyStrict(t *testing.T) {
+	// The first normal transaction has an unparseable value
+	badNormal := strings.Replace(testdata.NormalTxResponse, `"value": "1000000000000000000"`, `"value": "1.5 ETH"`, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("action") == "txlist" {
+			w.Write([]byte(badNormal))
+			return
+		}
+		w.Write([]byte(testdata.EmptyResultResponse))
+	}))
+	defer server.Close()
+
+	useEtherscanServer(t, server.URL)
+	resetFlags(t, fetchManyCmd)
+
+	dir := t.TempDir()
+	wallet := "0xa39b189482f984388a34460636fea9eb181ad1a6"
+	rootCmd.SetArgs([]string{
+		"fetch-many",
+		"--api-key", "test-key",
+		"--output-dir", dir,
+		"--strict",
+		wallet,
+	})
+	err := rootCmd.Execute()
+	if !errors.Is(err, ErrStrictNormalization) {
+		t.Fatalf("fetch-many --strict error = %v, want %v", err, ErrStrictNormalization)
+	}
+	if _, statErr := os.Stat(filepath.Join(dir, wallet+".csv")); !os.IsNotExist(statErr) {
+		t.Errorf("Expected no export after a strict failure, stat error = %v", statErr)
+	}
+}
+
+func TestFetchManyRejectsInvalidAddressConcurrency(t *testing.T) {
+	resetFlags(t, fetchManyCmd)
+
+	for _, value := range []string{"0", "-1"} {
+		rootCmd.SetArgs([]string{
+			"fetch-many",
+			"--api-key", "test-key",
+			"--output-dir", t.TempDir(),
+			"--address-concurrency", value,
+			"0x1111111111111111111111111111111111111111",
+		})
+		if err := rootCmd.Execute(); err == nil {
+			t.Errorf("Expected an error for --address-concurrency %s, got none", value)
+		}
+	}
+}
@@ -0,0 +1,1230 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"conintracker-hiring/internal/testdata"
+	"conintracker-hiring/pkg/models"
+	"conintracker-hiring/pkg/output"
+	"conintracker-hiring/pkg/providers"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// useEtherscanServer points Etherscan requests at url for the rest of the test, with
+// a rate limit short enough that fake servers don't wait out the real one. The fetch
+// flags are reset afterwards, since Execute leaves them set on their globals.
+func useEtherscanServer(t *testing.T, url string) {
+	t.Helper()
+	previousURL, previousRate := etherscanBaseURL, etherscanRateLimit
+	etherscanBaseURL, etherscanRateLimit = url, time.Millisecond
+	t.Cleanup(func() { etherscanBaseURL, etherscanRateLimit = previousURL, previousRate })
+	resetFlags(t, fetchCmd)
+}
+
+// serveActions starts a fake Etherscan answering each action with its response in
+// responses, and every other action with no transactions, for the rest of the test
+func serveActions(t *testing.T, responses map[string]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if body, ok := responses[r.URL.Query().Get("action")]; ok {
+			w.Write([]byte(body))
+			return
+		}
+		w.Write([]byte(testdata.EmptyResultResponse))
+	}))
+	t.Cleanup(server.Close)
+	useEtherscanServer(t, server.URL)
+	return server
+}
+
+// resetFlags restores cmd's flags and the root's persistent flags to their defaults
+// once the test ends, clearing Changed so the next Execute starts clean
+func resetFlags(t *testing.T, cmd *cobra.Command) {
+	t.Helper()
+	t.Cleanup(func() {
+		reset := func(flag *pflag.Flag) {
+			value := flag.Value
+			if fresh, ok := value.(*freshSlice); ok {
+				value = fresh.Value
+			}
+			if slice, ok := value.(pflag.SliceValue); ok {
+				var defaults []string
+				if def := strings.Trim(flag.DefValue, "[]"); def != "" {
+					defaults = strings.Split(def, ",")
+				}
+				slice.Replace(defaults)
+				flag.Value = &freshSlice{Value: value, fresh: true}
+			} else if err := value.Set(flag.DefValue); err != nil {
+				t.Errorf("failed to reset --%s: %v", flag.Name, err)
+			}
+			flag.Changed = false
+		}
+		cmd.Flags().VisitAll(reset)
+		rootCmd.PersistentFlags().VisitAll(reset)
+	})
+}
+
+// freshSlice makes the first Set after resetFlags replace a slice flag's value, as
+// in a new process. pflag otherwise appends to it once the flag has been set.
+type freshSlice struct {
+	pflag.Value
+	fresh bool
+}
+
+func (f *freshSlice) Set(val string) error {
+	if !f.fresh {
+		return f.Value.Set(val)
+	}
+	values, err := csv.NewReader(strings.NewReader(val)).Read()
+	if err != nil {
+		return err
+	}
+	f.fresh = false
+	return f.Value.(pflag.SliceValue).Replace(values)
+}
+
+// runFetchAgainst executes the fetch command against a server that returns no transactions
+func runFetchAgainst(t *testing.T, args ...string) error {
+	t.Helper()
+
+	serveActions(t, nil)
+
+	base := []string{
+		"fetch",
+		"--api-key", "test-key",
+		"--address", "0xa39b189482f984388a34460636fea9eb181ad1a6",
+		"--output", filepath.Join(t.TempDir(), "transactions.csv"),
+	}
+	rootCmd.SetArgs(append(base, args...))
+	return rootCmd.Execute()
+}
+
+func TestFetchEmptyResultSucceedsByDefault(t *testing.T) {
+	if err := runFetchAgainst(t); err != nil {
+		t.Fatalf("Expected success for empty result, got %v", err)
+	}
+}
+
+func TestFetchFailOnEmpty(t *testing.T) {
+	err := runFetchAgainst(t, "--fail-on-empty")
+	if !errors.Is(err, ErrNoTransactions) {
+		t.Fatalf("Expected ErrNoTransactions, got %v", err)
+	}
+	if code := ExitCode(err); code != ExitCodeNoTransactions {
+		t.Errorf("Exit code mismatch: got %d, want %d", code, ExitCodeNoTransactions)
+	}
+	if code := ExitCode(errors.New("other failure")); code != ExitCodeError {
+		t.Errorf("Exit code mismatch: got %d, want %d", code, ExitCodeError)
+	}
+}
+
+func TestFetchWritesManifest(t *testing.T) {
+	serveActions(t, map[string]string{"txlist": testdata.NormalTxResponse})
+
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, "manifest.json")
+
+	rootCmd.SetArgs([]string{
+		"fetch",
+		"--api-key", "test-key",
+		"--address", "0xa39b189482f984388a34460636fea9eb181ad1a6",
+		"--output", filepath.Join(dir, "transactions.csv"),
+		"--manifest", manifestPath,
+		"--page-size", "50",
+	})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("fetch error = %v", err)
+	}
+
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		t.Fatalf("failed to read manifest: %v", err)
+	}
+
+	var m struct {
+		Addresses   []string          `json:"addresses"`
+		Chain       string            `json:"chain"`
+		Provider    string            `json:"provider"`
+		StartBlock  uint64            `json:"start_block"`
+		EndBlock    uint64            `json:"end_block"`
+		Filters     map[string]string `json:"filters"`
+		Counts      map[string]int    `json:"counts"`
+		Total       int               `json:"total"`
+		ToolVersion string            `json:"tool_version"`
+		ExportedAt  string            `json:"exported_at"`
+	}
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.Fatalf("manifest is not valid JSON: %v", err)
+	}
+
+	if len(m.Addresses) != 1 || m.Addresses[0] != "0xa39b189482f984388a34460636fea9eb181ad1a6" {
+		t.Errorf("Addresses mismatch: got %v", m.Addresses)
+	}
+	if m.Chain != "ethereum" || m.Provider != "etherscan" {
+		t.Errorf("Chain/provider mismatch: got %s/%s", m.Chain, m.Provider)
+	}
+	if m.Total != 2 || m.Counts["ETH"] != 2 {
+		t.Errorf("Counts mismatch: total %d, counts %v", m.Total, m.Counts)
+	}
+	if m.StartBlock == 0 || m.EndBlock < m.StartBlock {
+		t.Errorf("Block range mismatch: %d-%d", m.StartBlock, m.EndBlock)
+	}
+	if m.Filters["page-size"] != "50" {
+		t.Errorf("Expected page-size filter, got %v", m.Filters)
+	}
+	if _, ok := m.Filters["api-key"]; ok {
+		t.Error("API key must not be recorded in the manifest")
+	}
+	if m.ToolVersion != version || m.ExportedAt == "" {
+		t.Errorf("Version/timestamp missing: %s %s", m.ToolVersion, m.ExportedAt)
+	}
+}
+
+func TestFetchInterruptedWritesPartialCSV(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Serve normal transactions, then "press Ctrl-C" once internal transactions are requested
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("action") {
+		case "txlist":
+			w.Write([]byte(testdata.NormalTxResponse))
+		default:
+			cancel()
+			w.Write([]byte(testdata.EmptyResultResponse))
+		}
+	}))
+	defer server.Close()
+
+	useEtherscanServer(t, server.URL)
+
+	outputPath := filepath.Join(t.TempDir(), "transactions.csv")
+	rootCmd.SetArgs([]string{
+		"fetch",
+		"--api-key", "test-key",
+		"--address", "0xa39b189482f984388a34460636fea9eb181ad1a6",
+		"--output", outputPath,
+		"--fail-on-empty=false",
+	})
+	// cobra keeps a subcommand's context from earlier runs, so set it directly
+	fetchCmd.SetContext(ctx)
+	defer fetchCmd.SetContext(context.Background())
+	err := rootCmd.Execute()
+	if !errors.Is(err, ErrInterrupted) {
+		t.Fatalf("Expected ErrInterrupted, got %v", err)
+	}
+	if code := ExitCode(err); code != ExitCodeInterrupted {
+		t.Errorf("Exit code mismatch: got %d, want %d", code, ExitCodeInterrupted)
+	}
+
+	file, err := os.Open(outputPath)
+	if err != nil {
+		t.Fatalf("failed to open partial export: %v", err)
+	}
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatalf("partial export is not valid CSV: %v", err)
+	}
+	// Header plus the two normal transactions fetched before the interruption
+	if len(records) != 3 {
+		t.Fatalf("Expected 3 rows in partial export, got %d", len(records))
+	}
+	if records[0][0] != "Transaction Hash" {
+		t.Errorf("Header mismatch: got %s, want Transaction Hash", records[0][0])
+	}
+}
+
+func TestFetchNoInternalSkipsProviderCall(t *testing.T) {
+	var actions []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actions = append(actions, r.URL.Query().Get("action"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(testdata.EmptyResultResponse))
+	}))
+	defer server.Close()
+
+	useEtherscanServer(t, server.URL)
+
+	// The exclusion wins over the explicit inclusion
+	rootCmd.SetArgs([]string{
+		"fetch",
+		"--api-key", "test-key",
+		"--address", "0xa39b189482f984388a34460636fea9eb181ad1a6",
+		"--output", filepath.Join(t.TempDir(), "transactions.csv"),
+		"--types", "normal,internal",
+		"--no-internal",
+	})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("fetch error = %v", err)
+	}
+
+	if len(actions) != 1 || actions[0] != "txlist" {
+		t.Errorf("Expected only the txlist request, got %v", actions)
+	}
+}
+
+// rejectingNormalizer fails the ERC-20 transfer with a given hash
+type rejectingNormalizer struct {
+	*providers.EtherscanNormalizer
+	badHash string
+}
+
+func (rn *rejectingNormalizer) NormalizeERC20Tx(tx providers.EtherscanTokenTx) (*models.Transaction, error) {
+	if tx.Hash == rn.badHash {
+		return nil, errors.New("token transfer rejected for the test")
+	}
+	return rn.EtherscanNormalizer.NormalizeERC20Tx(tx)
+}
+
+// rejectTokenTransfer makes fetch fail to normalize the ERC-20 transfer with hash
+// for the rest of the test
+func rejectTokenTransfer(t *testing.T, hash string) {
+	previous := fetchNormalizer
+	fetchNormalizer = func(n *providers.EtherscanNormalizer) providers.Normalizer {
+		return &rejectingNormalizer{EtherscanNormalizer: n, badHash: hash}
+	}
+	t.Cleanup(func() { fetchNormalizer = previous })
+}
+
+func TestFetchWritesErrorsFile(t *testing.T) {
+	// The first USDC transfer fails to normalize
+	rejectTokenTransfer(t, "0x8888888888888888888888888888888888888888888888888888888888888888")
+	serveActions(t, map[string]string{"tokentx": testdata.ERC20TokenTxResponse})
+
+	dir := t.TempDir()
+	errorsPath := filepath.Join(dir, "errors.json")
+
+	rootCmd.SetArgs([]string{
+		"fetch",
+		"--api-key", "test-key",
+		"--address", "0xa39b189482f984388a34460636fea9eb181ad1a6",
+		"--output", filepath.Join(dir, "transactions.csv"),
+		"--errors-file", errorsPath,
+	})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("fetch error = %v", err)
+	}
+
+	data, err := os.ReadFile(errorsPath)
+	if err != nil {
+		t.Fatalf("failed to read errors file: %v", err)
+	}
+	var entries []struct {
+		Type  string            `json:"type"`
+		Hash  string            `json:"hash"`
+		Error string            `json:"error"`
+		Raw   map[string]string `json:"raw"`
+	}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		t.Fatalf("errors file is not valid JSON: %v", err)
+	}
+
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(entries))
+	}
+	entry := entries[0]
+	if entry.Hash != "0x8888888888888888888888888888888888888888888888888888888888888888" {
+		t.Errorf("Hash mismatch: got %s", entry.Hash)
+	}
+	if entry.Type != "ERC-20" || !strings.Contains(entry.Error, "rejected for the test") {
+		t.Errorf("Entry mismatch: %+v", entry)
+	}
+	if entry.Raw["tokenSymbol"] != "USDC" {
+		t.Errorf("Expected raw transaction in entry, got %v", entry.Raw)
+	}
+}
+
+func TestFetchWithMoralisProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") != "moralis-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(testdata.MoralisErrorResponse))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/erc20/transfers"):
+			w.Write([]byte(testdata.MoralisERC20Response))
+		case strings.HasSuffix(r.URL.Path, "/nft/transfers"):
+			w.Write([]byte(`{"cursor":null,"result":[]}`))
+		default:
+			w.Write([]byte(testdata.MoralisWalletPage2Response))
+		}
+	}))
+	defer server.Close()
+
+	previousURL := moralisBaseURL
+	moralisBaseURL = server.URL
+	defer func() { moralisBaseURL = previousURL }()
+	resetFlags(t, fetchCmd)
+	t.Setenv("MORALIS_API_KEY", "moralis-key")
+	apiKey = "" // Use MORALIS_API_KEY even if an earlier test left --api-key set
+
+	outputPath := filepath.Join(t.TempDir(), "transactions.csv")
+	rootCmd.SetArgs([]string{
+		"fetch",
+		"--provider", "moralis",
+		"--address", "0xa39b189482f984388a34460636fea9eb181ad1a6",
+		"--output", outputPath,
+	})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("fetch error = %v", err)
+	}
+
+	file, err := os.Open(outputPath)
+	if err != nil {
+		t.Fatalf("failed to open output: %v", err)
+	}
+	defer file.Close()
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse CSV: %v", err)
+	}
+
+	// Header, the wallet transaction, and the USDC transfer
+	if len(records) != 3 {
+		t.Fatalf("Expected 3 CSV records, got %d", len(records))
+	}
+	if records[2][6] != "USDC" || records[2][8] != "2500" {
+		t.Errorf("USDC row mismatch: %v", records[2])
+	}
+}
+
+func TestFetchWithShards(t *testing.T) {
+	var ranges []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		query := r.URL.Query()
+		switch query.Get("action") {
+		case "eth_blockNumber":
+			w.Write([]byte(testdata.ProxyBlockNumberResponse))
+		case "txlist":
+			// Every shard sees the same transactions, so the merge must drop the copies
+			ranges = append(ranges, query.Get("startblock")+"-"+query.Get("endblock"))
+			w.Write([]byte(testdata.NormalTxResponse))
+		default:
+			w.Write([]byte(testdata.EmptyResultResponse))
+		}
+	}))
+	defer server.Close()
+
+	useEtherscanServer(t, server.URL)
+
+	outputPath := filepath.Join(t.TempDir(), "transactions.csv")
+	rootCmd.SetArgs([]string{
+		"fetch",
+		"--api-key", "test-key",
+		"--address", "0xa39b189482f984388a34460636fea9eb181ad1a6",
+		"--output", outputPath,
+		"--types", "normal",
+		"--shards", "2",
+	})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("fetch error = %v", err)
+	}
+
+	sort.Strings(ranges)
+	if got := strings.Join(ranges, ","); got != "0-10000000,10000001-20000000" {
+		t.Errorf("Expected two shards up to the latest block, got %v", ranges)
+	}
+
+	file, err := os.Open(outputPath)
+	if err != nil {
+		t.Fatalf("failed to open output: %v", err)
+	}
+	defer file.Close()
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read CSV: %v", err)
+	}
+	seen := make(map[string]bool)
+	for _, record := range records[1:] {
+		row := strings.Join(record, ",")
+		if seen[row] {
+			t.Errorf("Duplicate row after merging shards: %s", row)
+		}
+		seen[row] = true
+	}
+	if len(seen) != 2 {
+		t.Errorf("Expected the 2 distinct transactions, got %d rows", len(seen))
+	}
+}
+
+func TestFetchTimeoutFlag(t *testing.T) {
+
+	// The server holds the first request longer than the timeout allows
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+	useEtherscanServer(t, server.URL)
+
+	start := time.Now()
+	rootCmd.SetArgs([]string{
+		"fetch",
+		"--api-key", "test-key",
+		"--address", "0xa39b189482f984388a34460636fea9eb181ad1a6",
+		"--output", filepath.Join(t.TempDir(), "transactions.csv"),
+		"--timeout", "50ms",
+	})
+	err := rootCmd.Execute()
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 400*time.Millisecond {
+		t.Errorf("Fetch took %v, expected it to stop at the 50ms timeout", elapsed)
+	}
+}
+
+func TestFetchWritesMultipleFormats(t *testing.T) {
+	serveActions(t, map[string]string{
+		"txlist":  testdata.NormalTxResponse,
+		"tokentx": testdata.ERC20TokenTxResponse,
+	})
+
+	dir := t.TempDir()
+	rootCmd.SetArgs([]string{
+		"fetch",
+		"--api-key", "test-key",
+		"--address", "0xa39b189482f984388a34460636fea9eb181ad1a6",
+		"--output", filepath.Join(dir, "export.csv"),
+		"--format", "csv,json",
+	})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("fetch error = %v", err)
+	}
+
+	csvFile, err := os.Open(filepath.Join(dir, "export.csv"))
+	if err != nil {
+		t.Fatalf("failed to open CSV output: %v", err)
+	}
+	defer csvFile.Close()
+	rows, err := csv.NewReader(csvFile).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read CSV: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "export.json"))
+	if err != nil {
+		t.Fatalf("failed to read JSON output: %v", err)
+	}
+	var records []map[string]any
+	if err := json.Unmarshal(data, &records); err != nil {
+		t.Fatalf("JSON output is not valid: %v", err)
+	}
+
+	if len(records) == 0 || len(records) != len(rows)-1 {
+		t.Errorf("Row count mismatch: CSV has %d data rows, JSON has %d records", len(rows)-1, len(records))
+	}
+	for i, record := range records {
+		if record["hash"] != rows[i+1][0] {
+			t.Errorf("Record %d hash mismatch: got %v, want %s", i, record["hash"], rows[i+1][0])
+		}
+	}
+}
+
+func TestFetchRejectsUnknownFormat(t *testing.T) {
+
+	err := runFetchAgainst(t, "--format", "csv,xml")
+	if err == nil || !strings.Contains(err.Error(), `unsupported format "xml"`) {
+		t.Errorf("Expected unsupported format error, got %v", err)
+	}
+}
+
+func TestFetchRejectsInvalidPageRange(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "inverted", args: []string{"--start-page", "3", "--end-page", "2"}, wantErr: "--start-page 3 is after --end-page 2"},
+		{name: "zero_start", args: []string{"--start-page", "0", "--end-page", "2"}, wantErr: "must be at least 1"},
+		{name: "zero_end", args: []string{"--start-page", "1", "--end-page", "0"}, wantErr: "must be at least 1"},
+		{name: "negative", args: []string{"--start-page", "-1", "--end-page", "1"}, wantErr: "must be at least 1"},
+		{name: "past_result_window", args: []string{"--start-page", "1", "--end-page", "2", "--page-size", "10000"}, wantErr: "passes Etherscan's 10000-record result window"},
+		{name: "past_result_window_small_pages", args: []string{"--start-page", "1", "--end-page", "101", "--page-size", "100"}, wantErr: "use --shards"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runFetchAgainst(t, tt.args...)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestFetchEtherscanLayout(t *testing.T) {
+	serveActions(t, map[string]string{"txlist": testdata.NormalTxResponse})
+
+	path := filepath.Join(t.TempDir(), "transactions.csv")
+	rootCmd.SetArgs([]string{
+		"fetch",
+		"--api-key", "test-key",
+		"--address", "0xa39b189482f984388a34460636fea9eb181ad1a6",
+		"--output", path,
+		"--layout", "etherscan",
+	})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("fetch error = %v", err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open output: %v", err)
+	}
+	defer file.Close()
+	rows, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read CSV: %v", err)
+	}
+	if len(rows) != 3 || rows[0][0] != "Txhash" || rows[0][7] != "Value_IN(ETH)" {
+		t.Errorf("Etherscan layout mismatch: got %d rows with header %v", len(rows), rows[0])
+	}
+}
+
+func TestFetchRejectsInvalidLayout(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "unknown", args: []string{"--layout", "koinly"}, wantErr: `invalid layout "koinly"`},
+		{name: "json_format", args: []string{"--layout", "etherscan", "--format", "json"}, wantErr: "only supports --format csv"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runFetchAgainst(t, tt.args...)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// memoryUploader keeps uploaded objects in memory, keyed by bucket/key
+type memoryUploader struct {
+	objects map[string]string
+}
+
+func (u *memoryUploader) Upload(ctx context.Context, bucket, key string, body io.Reader) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	u.objects[bucket+"/"+key] = string(data)
+	return nil
+}
+
+func TestFetchWritesToS3Sink(t *testing.T) {
+	serveActions(t, map[string]string{"txlist": testdata.NormalTxResponse})
+
+	uploader := &memoryUploader{objects: make(map[string]string)}
+	previousSink := openSink
+	openSink = output.NewSinkFactory(uploader)
+	defer func() { openSink = previousSink }()
+
+	rootCmd.SetArgs([]string{
+		"fetch",
+		"--api-key", "test-key",
+		"--address", "0xa39b189482f984388a34460636fea9eb181ad1a6",
+		"--output", "s3://exports/wallet/transactions.csv",
+	})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("fetch error = %v", err)
+	}
+
+	object, ok := uploader.objects["exports/wallet/transactions.csv"]
+	if !ok {
+		t.Fatalf("No object uploaded: %v", uploader.objects)
+	}
+	rows, err := csv.NewReader(strings.NewReader(object)).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read uploaded CSV: %v", err)
+	}
+	if len(rows) != 3 || rows[0][0] != "Transaction Hash" {
+		t.Errorf("Uploaded CSV mismatch: got %d rows starting %v", len(rows), rows[0])
+	}
+}
+
+func TestFetchAppendWithNothingNew(t *testing.T) {
+	serveActions(t, map[string]string{"txlist": testdata.NormalTxResponse})
+
+	outputPath := filepath.Join(t.TempDir(), "transactions.csv")
+	args := []string{
+		"fetch",
+		"--api-key", "test-key",
+		"--address", "0xa39b189482f984388a34460636fea9eb181ad1a6",
+		"--output", outputPath,
+	}
+	// A plain export first, so the append run matches its rows without a seen store
+	rootCmd.SetArgs(args)
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("fetch error = %v", err)
+	}
+
+	var stderr bytes.Buffer
+	rootCmd.SetErr(&stderr)
+	defer rootCmd.SetErr(nil)
+	rootCmd.SetArgs(append(args, "--append", "--fail-on-empty", "--json-summary"))
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("Expected success with nothing new to append, got %v", err)
+	}
+
+	seen, err := os.ReadFile(output.SeenPath(outputPath))
+	if err != nil {
+		t.Fatalf("Expected the matched rows in the seen store: %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(string(seen)), "\n"); len(lines) != 2 {
+		t.Errorf("Expected 2 keys in the seen store, got %d:\n%s", len(lines), seen)
+	}
+
+	lines := strings.Split(strings.TrimSpace(stderr.String()), "\n")
+	var summary struct {
+		Total int `json:"total"`
+	}
+	if err := json.Unmarshal([]byte(lines[len(lines)-1]), &summary); err != nil {
+		t.Fatalf("Expected a JSON summary as the last stderr line, got %q: %v", stderr.String(), err)
+	}
+	if summary.Total != 2 {
+		t.Errorf("Total mismatch: got %d, want the 2 fetched rows", summary.Total)
+	}
+}
+
+func TestFetchFailureKeepsS3Object(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(testdata.ChainNotSupportedResponse))
+	}))
+	defer server.Close()
+
+	useEtherscanServer(t, server.URL)
+
+	uploader := &memoryUploader{objects: map[string]string{"exports/wallet/transactions.csv": "previous export"}}
+	previousSink := openSink
+	openSink = output.NewSinkFactory(uploader)
+	defer func() { openSink = previousSink }()
+
+	rootCmd.SetArgs([]string{
+		"fetch",
+		"--api-key", "test-key",
+		"--address", "0xa39b189482f984388a34460636fea9eb181ad1a6",
+		"--output", "s3://exports/wallet/transactions.csv",
+	})
+	if err := rootCmd.Execute(); err == nil {
+		t.Fatal("Expected the fetch to fail")
+	}
+
+	if got := uploader.objects["exports/wallet/transactions.csv"]; got != "previous export" {
+		t.Errorf("Expected the failed fetch to leave the object alone, got %q", got)
+	}
+}
+
+func TestFetchS3OutputNeedsUploader(t *testing.T) {
+	err := runFetchAgainst(t, "--output", "s3://exports/transactions.csv")
+	if !errors.Is(err, output.ErrNoUploader) {
+		t.Errorf("Expected ErrNoUploader, got %v", err)
+	}
+}
+
+func TestFetchExplainsChainAccessError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(testdata.ChainNotSupportedResponse))
+	}))
+	defer server.Close()
+
+	useEtherscanServer(t, server.URL)
+
+	rootCmd.SetArgs([]string{
+		"fetch",
+		"--api-key", "test-key",
+		"--address", "0xa39b189482f984388a34460636fea9eb181ad1a6",
+		"--output", filepath.Join(t.TempDir(), "transactions.csv"),
+		"--chain", "polygon",
+	})
+	err := rootCmd.Execute()
+
+	var chainErr *providers.ErrChainNotSupported
+	if !errors.As(err, &chainErr) {
+		t.Fatalf("Expected *providers.ErrChainNotSupported, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "does not cover polygon (chain ID 137)") {
+		t.Errorf("Expected advice naming the chain, got %v", err)
+	}
+}
+
+func TestFetchRejectsUnknownSortOrder(t *testing.T) {
+
+	err := runFetchAgainst(t, "--sort", "amount")
+	if err == nil || !strings.Contains(err.Error(), `invalid sort order "amount"`) {
+		t.Errorf("Expected invalid sort order error, got %v", err)
+	}
+}
+
+func TestFetchWithBalances(t *testing.T) {
+	serveActions(t, map[string]string{
+		"tokentx":      testdata.ERC20TokenTxResponse,
+		"tokenbalance": testdata.TokenBalanceResponse,
+	})
+
+	out := &strings.Builder{}
+	rootCmd.SetOut(out)
+	defer rootCmd.SetOut(nil)
+
+	rootCmd.SetArgs([]string{
+		"fetch",
+		"--api-key", "test-key",
+		"--address", "0xa39b189482f984388a34460636fea9eb181ad1a6",
+		"--output", filepath.Join(t.TempDir(), "transactions.csv"),
+		"--with-balances",
+	})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("fetch error = %v", err)
+	}
+	if !strings.Contains(out.String(), "Token balances:\n  USDC: 1234.5\n") {
+		t.Errorf("Expected decimal-adjusted USDC balance, got %q", out.String())
+	}
+}
+
+func TestFetchWithBalancesUnknownDecimals(t *testing.T) {
+	// USDC reports no decimals, so neither its transfers nor its balance can be scaled
+	noDecimals := strings.Replace(testdata.ERC20TokenTxResponse, `"tokenDecimal": "6"`, `"tokenDecimal": ""`, 1)
+	serveActions(t, map[string]string{
+		"tokentx":      noDecimals,
+		"tokenbalance": testdata.TokenBalanceResponse,
+	})
+
+	out := &strings.Builder{}
+	rootCmd.SetOut(out)
+	defer rootCmd.SetOut(nil)
+
+	rootCmd.SetArgs([]string{
+		"fetch",
+		"--api-key", "test-key",
+		"--address", "0xa39b189482f984388a34460636fea9eb181ad1a6",
+		"--output", filepath.Join(t.TempDir(), "transactions.csv"),
+		"--with-balances",
+	})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("fetch error = %v", err)
+	}
+	if !strings.Contains(out.String(), "  USDC: 1234500000 (raw, decimals unknown)\n") {
+		t.Errorf("Expected a raw USDC balance marked as such, got %q", out.String())
+	}
+	if !strings.Contains(out.String(), "  USDT: 1234.5\n") {
+		t.Errorf("Expected decimal-adjusted USDT balance, got %q", out.String())
+	}
+}
+
+func TestFetchPartitionByYear(t *testing.T) {
+	const twoYears = `{"status":"1","message":"OK","result":[
+		{"blockNumber":"18500000","timeStamp":"1699999970","hash":"0xaaa1","from":"0xa39b189482f984388a34460636fea9eb181ad1a6","to":"0xb","value":"1000000000000000000","gasUsed":"21000","gasPrice":"1000000000","isError":"0"},
+		{"blockNumber":"18900000","timeStamp":"1703980800","hash":"0xaaa2","from":"0xb","to":"0xa39b189482f984388a34460636fea9eb181ad1a6","value":"2000000000000000000","gasUsed":"21000","gasPrice":"1000000000","isError":"0"},
+		{"blockNumber":"19000000","timeStamp":"1705000000","hash":"0xaaa3","from":"0xa39b189482f984388a34460636fea9eb181ad1a6","to":"0xc","value":"3000000000000000000","gasUsed":"21000","gasPrice":"1000000000","isError":"0"}
+	]}`
+	serveActions(t, map[string]string{"txlist": twoYears})
+
+	dir := t.TempDir()
+	rootCmd.SetArgs([]string{
+		"fetch",
+		"--api-key", "test-key",
+		"--address", "0xa39b189482f984388a34460636fea9eb181ad1a6",
+		"--output", filepath.Join(dir, "transactions.csv"),
+		"--partition-by", "year",
+	})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("fetch error = %v", err)
+	}
+
+	wantHashes := map[string][]string{
+		"transactions-2023.csv": {"0xaaa1", "0xaaa2"},
+		"transactions-2024.csv": {"0xaaa3"},
+	}
+	for name, hashes := range wantHashes {
+		file, err := os.Open(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("failed to open %s: %v", name, err)
+		}
+		rows, err := csv.NewReader(file).ReadAll()
+		file.Close()
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", name, err)
+		}
+		if len(rows) != len(hashes)+1 || rows[0][0] != "Transaction Hash" {
+			t.Fatalf("%s mismatch: got %d rows with header %v, want %d rows with a header", name, len(rows), rows[0], len(hashes)+1)
+		}
+		for i, hash := range hashes {
+			if rows[i+1][0] != hash {
+				t.Errorf("%s row %d hash mismatch: got %s, want %s", name, i+1, rows[i+1][0], hash)
+			}
+		}
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != len(wantHashes) {
+		t.Errorf("Expected only the partition files, got %d entries", len(entries))
+	}
+}
+
+func TestFetchRejectsInvalidPartition(t *testing.T) {
+
+	if err := runFetchAgainst(t, "--partition-by", "week"); err == nil || !strings.Contains(err.Error(), "invalid partition") {
+		t.Errorf("Expected invalid partition error, got %v", err)
+	}
+	if err := runFetchAgainst(t, "--partition-by", "year", "--append"); err == nil || !strings.Contains(err.Error(), "--append") {
+		t.Errorf("Expected --append conflict error, got %v", err)
+	}
+}
+
+func TestFetchMaxErrorRate(t *testing.T) {
+	// One of the two USDC transfers fails to normalize: a 50% error rate
+	rejectTokenTransfer(t, "0x8888888888888888888888888888888888888888888888888888888888888888")
+	serveActions(t, map[string]string{"tokentx": testdata.ERC20TokenTxResponse})
+
+	tests := []struct {
+		rate    string
+		wantErr bool
+	}{
+		{rate: "0.1", wantErr: true},
+		{rate: "0.5", wantErr: false},
+	}
+
+	for _, tt := range tests {
+		path := filepath.Join(t.TempDir(), "transactions.csv")
+		rootCmd.SetArgs([]string{
+			"fetch",
+			"--api-key", "test-key",
+			"--address", "0xa39b189482f984388a34460636fea9eb181ad1a6",
+			"--output", path,
+			"--max-error-rate", tt.rate,
+		})
+		err := rootCmd.Execute()
+		if tt.wantErr != errors.Is(err, ErrErrorRateExceeded) {
+			t.Fatalf("--max-error-rate %s: expected ErrErrorRateExceeded %v, got %v", tt.rate, tt.wantErr, err)
+		}
+		if tt.wantErr && !strings.Contains(err.Error(), "1 of 2 records (50.0%)") {
+			t.Errorf("Expected an error summary, got %v", err)
+		}
+
+		// The rows that did normalize are written either way
+		data, readErr := os.ReadFile(path)
+		if readErr != nil {
+			t.Fatalf("failed to read output: %v", readErr)
+		}
+		if rows := strings.Count(string(data), "\n"); rows != 2 {
+			t.Errorf("--max-error-rate %s: expected a header and 1 row, got %d lines", tt.rate, rows)
+		}
+	}
+}
+
+func TestFetchStrict(t *testing.T) {
+	// The first normal transaction has an unparseable value
+	badNormal := strings.Replace(testdata.NormalTxResponse, `"value": "1000000000000000000"`, `"value": "1.5 ETH"`, 1)
+	serveActions(t, map[string]string{"txlist": badNormal})
+	defer rootCmd.SetErr(nil)
+
+	tests := []struct {
+		name     string
+		strict   bool
+		wantErr  bool
+		wantRows int
+	}{
+		{name: "lenient", strict: false, wantErr: false, wantRows: 1},
+		{name: "strict", strict: true, wantErr: true, wantRows: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strictNorm = false
+			var stderr bytes.Buffer
+			rootCmd.SetErr(&stderr)
+
+			path := filepath.Join(t.TempDir(), "transactions.csv")
+			args := []string{
+				"fetch",
+				"--api-key", "test-key",
+				"--address", "0xa39b189482f984388a34460636fea9eb181ad1a6",
+				"--output", path,
+			}
+			if tt.strict {
+				args = append(args, "--strict")
+			}
+			rootCmd.SetArgs(args)
+
+			err := rootCmd.Execute()
+			if tt.wantErr != errors.Is(err, ErrStrictNormalization) {
+				t.Fatalf("Expected ErrStrictNormalization %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr && !strings.Contains(err.Error(), `unparseable value "1.5 ETH"`) {
+				t.Errorf("Expected the failing record in the error, got %v", err)
+			}
+			if !tt.wantErr && !strings.Contains(stderr.String(), "Skipped 1 records that failed to normalize") {
+				t.Errorf("Expected a skip count, got %q", stderr.String())
+			}
+
+			data, readErr := os.ReadFile(path)
+			if readErr != nil {
+				t.Fatalf("failed to read output: %v", readErr)
+			}
+			lines := strings.Count(string(data), "\n")
+			if rows := max(lines-1, 0); rows != tt.wantRows {
+				t.Errorf("Rows mismatch: got %d, want %d", rows, tt.wantRows)
+			}
+		})
+	}
+}
+
+func TestFetchChecksum(t *testing.T) {
+	serveActions(t, map[string]string{"txlist": testdata.NormalTxResponse})
+
+	dir := t.TempDir()
+	rootCmd.SetArgs([]string{
+		"fetch",
+		"--api-key", "test-key",
+		"--address", "0xa39b189482f984388a34460636fea9eb181ad1a6",
+		"--output", filepath.Join(dir, "transactions.csv"),
+		"--format", "csv,json",
+		"--checksum",
+	})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("fetch error = %v", err)
+	}
+
+	for _, name := range []string{"transactions.csv", "transactions.json"} {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", name, err)
+		}
+		sum := sha256.Sum256(data)
+		want := hex.EncodeToString(sum[:]) + "  " + name + "\n"
+
+		got, err := os.ReadFile(filepath.Join(dir, name+".sha256"))
+		if err != nil {
+			t.Fatalf("failed to read checksum for %s: %v", name, err)
+		}
+		if string(got) != want {
+			t.Errorf("Checksum mismatch for %s: got %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestFetchJSONSummary(t *testing.T) {
+	serveActions(t, map[string]string{"txlist": testdata.NormalTxResponse})
+
+	var stderr bytes.Buffer
+	rootCmd.SetErr(&stderr)
+	defer rootCmd.SetErr(nil)
+
+	rootCmd.SetArgs([]string{
+		"fetch",
+		"--api-key", "test-key",
+		"--address", "0xa39b189482f984388a34460636fea9eb181ad1a6",
+		"--output", filepath.Join(t.TempDir(), "transactions.csv"),
+		"--json-summary",
+	})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("fetch error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(stderr.String()), "\n")
+	var summary struct {
+		Address   string         `json:"address"`
+		Total     int            `json:"total"`
+		ByType    map[string]int `json:"by_type"`
+		GasETH    string         `json:"gas_eth"`
+		Truncated bool           `json:"truncated"`
+	}
+	if err := json.Unmarshal([]byte(lines[len(lines)-1]), &summary); err != nil {
+		t.Fatalf("Expected a JSON summary as the last stderr line, got %q: %v", stderr.String(), err)
+	}
+
+	if summary.Address != "0xa39b189482f984388a34460636fea9eb181ad1a6" {
+		t.Errorf("Address mismatch: got %s", summary.Address)
+	}
+	if summary.Total != 2 {
+		t.Errorf("Total mismatch: got %d, want 2", summary.Total)
+	}
+	if got := summary.ByType[string(models.TypeEthTransfer)]; got != 2 || len(summary.ByType) != 1 {
+		t.Errorf("ByType mismatch: got %v, want 2 ETH rows", summary.ByType)
+	}
+	// 21000 gas at 50 and 45 gwei
+	if summary.GasETH != "0.001995" {
+		t.Errorf("GasETH mismatch: got %s, want 0.001995", summary.GasETH)
+	}
+	if summary.Truncated {
+		t.Error("Expected an untruncated run")
+	}
+}
+
+func TestFetchJSONSummaryCountsCounterpartiesBeforeRedaction(t *testing.T) {
+	serveActions(t, map[string]string{"txlist": testdata.NormalTxResponse})
+
+	var stderr bytes.Buffer
+	rootCmd.SetErr(&stderr)
+	defer rootCmd.SetErr(nil)
+
+	// Masking the owner must not turn it into a counterparty
+	rootCmd.SetArgs([]string{
+		"fetch",
+		"--api-key", "test-key",
+		"--address", "0xa39b189482f984388a34460636fea9eb181ad1a6",
+		"--output", filepath.Join(t.TempDir(), "transactions.csv"),
+		"--json-summary",
+		"--redact-all",
+	})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("fetch error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(stderr.String()), "\n")
+	var summary struct {
+		Counterparties int `json:"counterparties"`
+	}
+	if err := json.Unmarshal([]byte(lines[len(lines)-1]), &summary); err != nil {
+		t.Fatalf("Expected a JSON summary as the last stderr line, got %q: %v", stderr.String(), err)
+	}
+	if summary.Counterparties != 2 {
+		t.Errorf("Counterparties mismatch: got %d, want 2", summary.Counterparties)
+	}
+}
+
+func TestFetchExplain(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(testdata.EmptyResultResponse))
+	}))
+	defer server.Close()
+
+	useEtherscanServer(t, server.URL)
+
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	defer rootCmd.SetOut(nil)
+
+	dir := t.TempDir()
+	rootCmd.SetArgs([]string{
+		"fetch",
+		"--api-key", "key-1,key-2",
+		"--address", "0xa39b189482f984388a34460636fea9eb181ad1a6",
+		"--chain", "polygon",
+		"--output", filepath.Join(dir, "transactions.csv"),
+		"--format", "csv,json",
+		"--types", "normal,erc20",
+		"--start-page", "2",
+		"--end-page", "4",
+		"--page-size", "500",
+		"--only-party",
+		"--min-amount", "0.5",
+		"--explain",
+	})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("fetch error = %v", err)
+	}
+
+	if requests != 0 {
+		t.Errorf("Expected no requests, got %d", requests)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "transactions.csv")); !os.IsNotExist(err) {
+		t.Errorf("Expected no output file to be created, got %v", err)
+	}
+
+	plan := stdout.String()
+	for _, want := range []string{
+		"Address:      0xa39b189482f984388a34460636fea9eb181ad1a6",
+		"Provider:     etherscan (2 API key(s))",
+		"Chain:        polygon (chain ID 137)",
+		"Base URL:     " + server.URL,
+		"Types:        normal, erc20",
+		"Pages:        2-4, 500 records per page",
+		"Requests:     up to 6 (2 types x 3 pages)",
+		"Filters:      only-party, min-amount 0.5",
+		"Output:       " + filepath.Join(dir, "transactions.csv") + " (csv)",
+		"Output:       " + filepath.Join(dir, "transactions.json") + " (json)",
+	} {
+		if !strings.Contains(plan, want) {
+			t.Errorf("Expected plan to contain %q, got:\n%s", want, plan)
+		}
+	}
+	if strings.Contains(plan, "key-1") {
+		t.Error("Expected the plan not to print API keys")
+	}
+}
+
+func TestFetchWritesStatsFile(t *testing.T) {
+	// The first USDC transfer fails to normalize
+	rejectTokenTransfer(t, "0x8888888888888888888888888888888888888888888888888888888888888888")
+	serveActions(t, map[string]string{
+		"txlist":  testdata.NormalTxResponse,
+		"tokentx": testdata.ERC20TokenTxResponse,
+	})
+
+	dir := t.TempDir()
+	statsPath := filepath.Join(dir, "stats.json")
+	rootCmd.SetArgs([]string{
+		"fetch",
+		"--api-key", "test-key",
+		"--address", "0xa39b189482f984388a34460636fea9eb181ad1a6",
+		"--output", filepath.Join(dir, "transactions.csv"),
+		"--types", "normal,internal,erc20",
+		"--stats-file", statsPath,
+	})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("fetch error = %v", err)
+	}
+
+	data, err := os.ReadFile(statsPath)
+	if err != nil {
+		t.Fatalf("failed to read stats file: %v", err)
+	}
+	var stats struct {
+		Types map[string]providers.NormalizationStats `json:"types"`
+		Total providers.NormalizationStats            `json:"total"`
+	}
+	if err := json.Unmarshal(data, &stats); err != nil {
+		t.Fatalf("failed to decode stats file: %v", err)
+	}
+
+	want := map[string]providers.NormalizationStats{
+		"normal":   {TotalProcessed: 2, SuccessCount: 2},
+		"internal": {},
+		"erc20":    {TotalProcessed: 2, SuccessCount: 1, ErrorCount: 1},
+	}
+	if len(stats.Types) != len(want) {
+		t.Errorf("Expected stats for %d types, got %v", len(want), stats.Types)
+	}
+	for name, wantStats := range want {
+		if got := stats.Types[name]; got.TotalProcessed != wantStats.TotalProcessed || got.SuccessCount != wantStats.SuccessCount || got.ErrorCount != wantStats.ErrorCount {
+			t.Errorf("%s stats mismatch: got %+v, want %+v", name, got, wantStats)
+		}
+	}
+	if stats.Total.TotalProcessed != 4 || stats.Total.SuccessCount != 3 || stats.Total.ErrorCount != 1 {
+		t.Errorf("Total stats mismatch: got %+v", stats.Total)
+	}
+}
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"conintracker-hiring/pkg/filter"
+	"conintracker-hiring/pkg/models"
+	"conintracker-hiring/pkg/providers"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// addFilterFlags registers the type selection, row filter and ordering flags
+// shared by fetch and normalize
+func addFilterFlags(flags *pflag.FlagSet) {
+	flags.StringSliceVar(&txTypes, "types", nil, "Transaction types to include ("+strings.Join(providers.TransactionTypeNames(), ", ")+"; default: all)")
+	flags.BoolVar(&noInternal, "no-internal", false, "Skip internal transactions (overrides --types)")
+	flags.BoolVar(&noERC20, "no-erc20", false, "Skip ERC-20 transfers (overrides --types)")
+	flags.BoolVar(&noERC721, "no-erc721", false, "Skip ERC-721 transfers (overrides --types)")
+	flags.BoolVar(&noERC1155, "no-erc1155", false, "Skip ERC-1155 transfers (overrides --types)")
+	flags.BoolVar(&onlyParty, "only-party", false, "Keep only rows where the address is the sender or receiver")
+	flags.BoolVar(&approvOnly, "approvals-only", false, "Keep only token approval transactions")
+	flags.StringVar(&minAmount, "min-amount", "", "Keep only rows moving at least this amount, in the row's asset units (not USD)")
+	flags.StringVar(&maxAmount, "max-amount", "", "Keep only rows moving at most this amount, in the row's asset units (not USD)")
+	flags.StringVar(&sortOrder, "sort", sortBlock, "Row order: block (block number, then time), time, or time-desc (wall clock; use for multichain merges)")
+}
+
+// addNormalizerFlags registers the amount and address formatting flags shared by
+// fetch and normalize
+func addNormalizerFlags(flags *pflag.FlagSet) {
+	flags.IntVar(&decimals, "decimals", providers.FullPrecision, "Round amounts and gas fees to this many decimal places (-1 for full precision)")
+	flags.BoolVar(&compactAmts, "compact-amounts", true, "Trim trailing zeros from amounts and gas fees; =false pads them to --decimals or the asset's decimals")
+	flags.StringVar(&addrCase, "address-case", string(providers.AddressCaseLower), "Address casing: lower, checksum (EIP-55), or asis")
+}
+
+// selectedTypes resolves --types and the --no-* flags into the types to include.
+// --types picks the starting set (all types when empty); exclusions are then
+// removed from it, so --no-internal wins over --types internal. Beacon withdrawals
+// are dropped on chains that have none.
+func selectedTypes() ([]providers.TransactionType, error) {
+	names := txTypes
+	if len(names) == 0 {
+		names = providers.TransactionTypeNames()
+	}
+
+	excluded := map[providers.TransactionType]bool{
+		providers.TxTypeInternal:   noInternal,
+		providers.TxTypeToken:      noERC20,
+		providers.TxTypeNFT:        noERC721,
+		providers.TxTypeERC1155:    noERC1155,
+		providers.TxTypeWithdrawal: !providers.ChainHasBeaconWithdrawals(chain),
+	}
+
+	var selected []providers.TransactionType
+	seen := make(map[providers.TransactionType]bool)
+	for _, name := range names {
+		txType, err := providers.ParseTransactionType(name)
+		if err != nil {
+			return nil, err
+		}
+		if excluded[txType] || seen[txType] {
+			continue
+		}
+		seen[txType] = true
+		selected = append(selected, txType)
+	}
+
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no transaction types left to fetch on %s after applying --types and --no-* flags", chain)
+	}
+	return selected, nil
+}
+
+// parseAmountBound parses an amount threshold flag; an empty value means no bound
+func parseAmountBound(flag, value string) (*big.Rat, error) {
+	if value == "" {
+		return nil, nil
+	}
+	bound, ok := new(big.Rat).SetString(value)
+	if !ok || bound.Sign() < 0 {
+		return nil, fmt.Errorf("invalid --%s %q: must be a non-negative number", flag, value)
+	}
+	return bound, nil
+}
+
+// rowFilters holds the parsed --only-party, --approvals-only and amount range
+// filters, applied to normalized rows before export
+type rowFilters struct {
+	party    string // Address --only-party keeps rows for
+	minBound *big.Rat
+	maxBound *big.Rat
+}
+
+// parseRowFilters validates --sort and the amount bounds; party is the address
+// --only-party keeps rows for
+func parseRowFilters(party string) (*rowFilters, error) {
+	if !slices.Contains(sortOrders, sortOrder) {
+		return nil, fmt.Errorf("invalid sort order %q (available: %s)", sortOrder, strings.Join(sortOrders, ", "))
+	}
+
+	minBound, err := parseAmountBound("min-amount", minAmount)
+	if err != nil {
+		return nil, err
+	}
+	maxBound, err := parseAmountBound("max-amount", maxAmount)
+	if err != nil {
+		return nil, err
+	}
+	if minBound != nil && maxBound != nil && minBound.Cmp(maxBound) > 0 {
+		return nil, fmt.Errorf("--min-amount %s is greater than --max-amount %s", minAmount, maxAmount)
+	}
+	return &rowFilters{party: party, minBound: minBound, maxBound: maxBound}, nil
+}
+
+// apply drops the rows the filters exclude, reporting how many each one dropped
+func (f *rowFilters) apply(txs []*models.Transaction) []*models.Transaction {
+	if onlyParty {
+		kept := filter.OnlyParty(txs, f.party)
+		if dropped := len(txs) - len(kept); dropped > 0 {
+			fmt.Printf("Dropping %d transactions where %s is neither sender nor receiver\n", dropped, f.party)
+		}
+		txs = kept
+	}
+
+	if f.minBound != nil || f.maxBound != nil {
+		kept := filter.FilterByAmount(txs, f.minBound, f.maxBound)
+		if dropped := len(txs) - len(kept); dropped > 0 {
+			fmt.Printf("Dropping %d transactions outside the amount range\n", dropped)
+		}
+		txs = kept
+	}
+
+	if approvOnly {
+		kept := filter.ApprovalsOnly(txs)
+		if dropped := len(txs) - len(kept); dropped > 0 {
+			fmt.Printf("Dropping %d transactions that are not approvals\n", dropped)
+		}
+		txs = kept
+	}
+	return txs
+}
+
+// sortRows reorders txs by wall clock when --sort asks for it; rows are already
+// in block order otherwise
+func sortRows(txs []*models.Transaction) {
+	if sortOrder != sortBlock {
+		models.SortByTimestamp(txs, sortOrder == sortTimeDesc)
+	}
+}
+
+// newNormalizer builds a normalizer configured from the shared formatting flags,
+// --strict and chain's native symbol
+func newNormalizer() (*providers.EtherscanNormalizer, error) {
+	addressCase, err := providers.ParseAddressCase(addrCase)
+	if err != nil {
+		return nil, err
+	}
+
+	normalizer := providers.NewEtherscanNormalizer()
+	normalizer.SetDecimalPlaces(decimals)
+	normalizer.SetTrimTrailingZeros(compactAmts)
+	normalizer.SetAddressCase(addressCase)
+	normalizer.SetNativeSymbol(providers.ChainNativeSymbol(chain))
+	normalizer.SetStrict(strictNorm)
+	return normalizer, nil
+}
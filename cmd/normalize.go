@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"conintracker-hiring/pkg/analysis"
+	"conintracker-hiring/pkg/output"
+	"conintracker-hiring/pkg/providers"
+
+	"github.com/spf13/cobra"
+)
+
+// inputDir is the raw dump directory read by normalize
+var inputDir string
+
+// normalizeCmd re-exports raw records saved by fetch --save-raw without network calls
+var normalizeCmd = &cobra.Command{
+	Use:   "normalize",
+	Short: "Normalize raw records saved by fetch --save-raw",
+	Long:  `Reads the raw records fetch --save-raw wrote (one JSON file per transaction type) and exports them through the same normalizer, without any network calls.`,
+	RunE:  runNormalize,
+}
+
+func init() {
+	rootCmd.AddCommand(normalizeCmd)
+
+	normalizeCmd.Flags().StringVar(&inputDir, "input-dir", "", "Directory of raw records saved by fetch --save-raw (required)")
+	normalizeCmd.Flags().StringVarP(&outputFile, "output", "o", "", "Output file path; with several formats each gets its extension (default: transactions.csv)")
+	normalizeCmd.Flags().StringSliceVar(&formats, "format", []string{output.FormatCSV}, "Output formats, comma-separated ("+strings.Join(output.Formats, ", ")+")")
+	normalizeCmd.Flags().StringVar(&chain, "chain", providers.DefaultChain, "Chain the records were fetched from, for native asset symbols and the chain column")
+	normalizeCmd.Flags().StringVar(&timezone, "timezone", "UTC", "IANA time zone for exported timestamps (e.g. America/New_York)")
+	normalizeCmd.Flags().StringVar(&timeFormat, "time-format", output.TimeFormatRFC3339, "Timestamp format ("+strings.Join(output.TimeFormats, ", ")+"); rfc3339nano keeps sub-second precision")
+	normalizeCmd.Flags().StringSliceVar(&columns, "columns", nil, "Optional CSV columns to include ("+strings.Join(output.UnpricedColumns(), ", ")+")")
+	normalizeCmd.Flags().StringVarP(&address, "address", "a", "", "Wallet address the records were fetched for (required by --only-party)")
+	normalizeCmd.Flags().BoolVar(&strictNorm, "strict", false, "Fail before writing if any record cannot be normalized exactly, including tokens with invalid decimals")
+	addFilterFlags(normalizeCmd.Flags())
+	addNormalizerFlags(normalizeCmd.Flags())
+
+	normalizeCmd.MarkFlagRequired("input-dir")
+}
+
+func runNormalize(cmd *cobra.Command, args []string) error {
+	chain = strings.ToLower(chain)
+	if _, ok := providers.ChainID(chain); !ok {
+		return fmt.Errorf("unsupported chain %q (supported: %s)", chain, strings.Join(providers.SupportedChains(), ", "))
+	}
+	if onlyParty && !isValidEthereumAddress(address) {
+		return fmt.Errorf("--only-party needs the wallet's --address, got %q", address)
+	}
+	rows, err := parseRowFilters(address)
+	if err != nil {
+		return err
+	}
+	types, err := selectedTypes()
+	if err != nil {
+		return err
+	}
+	normalizer, err := newNormalizer()
+	if err != nil {
+		return err
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return fmt.Errorf("invalid timezone %q: %w", timezone, err)
+	}
+	timeLayout, err := output.LookupTimeFormat(timeFormat)
+	if err != nil {
+		return err
+	}
+	extraColumns, err := output.LookupUnpricedColumns(columns)
+	if err != nil {
+		return err
+	}
+	outputs, err := outputPaths(defaultOutputFile(outputFile, formats), formats)
+	if err != nil {
+		return err
+	}
+
+	dump, err := providers.LoadRawDump(inputDir)
+	if err != nil {
+		return err
+	}
+
+	fetcher := providers.NewTransactionFetcher(providers.NewRawDumpProvider(dump, chain), normalizer)
+	fetcher.SetTypes(types)
+	fetcher.SetWindowSize(math.MaxInt) // A dump is complete; never warn about truncation
+
+	result, err := fetcher.FetchAll(cmd.Context(), "", 1, 1)
+	if err != nil {
+		return fmt.Errorf("failed to normalize %s: %w", inputDir, err)
+	}
+	if stats := result.NormalizationStats; stats.ErrorCount > 0 {
+		if strictNorm {
+			return fmt.Errorf("%w: %d of %d records, first: %v", ErrStrictNormalization, stats.ErrorCount, stats.TotalProcessed, stats.Errors[0])
+		}
+		fmt.Fprintf(os.Stderr, "Skipped %d records that failed to normalize\n", stats.ErrorCount)
+	}
+	txs := result.Transactions
+	analysis.LabelWalletExecutions(txs)
+	analysis.CategorizeAll(txs)
+	analysis.LinkInternalParents(txs)
+	analysis.LinkApprovals(txs)
+	analysis.FlagUnlimitedApprovals(txs)
+	txs = rows.apply(txs)
+	sortRows(txs)
+
+	for i := range outputs {
+		outputs[i].file, err = openSink(cmd.Context(), outputs[i].path)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer outputs[i].file.Close()
+	}
+	if err := writeOutputs(outputs, txs, output.CSVConfig{Location: location, TimeLayout: timeLayout, Columns: extraColumns}, nil); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n✓ Normalized %d transactions from %s\n", len(txs), inputDir)
+	return nil
+}
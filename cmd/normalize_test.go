@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"conintracker-hiring/internal/testdata"
+)
+
+func TestNormalizeReproducesSavedFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("action") {
+		case "txlist":
+			w.Write([]byte(testdata.NormalTxResponse))
+		case "tokentx":
+			w.Write([]byte(testdata.ERC20TokenTxResponse))
+		default:
+			w.Write([]byte(testdata.EmptyResultResponse))
+		}
+	}))
+	defer server.Close()
+
+	useEtherscanServer(t, server.URL)
+	defer func() { saveRaw, inputDir, outputFile = "", "", "" }()
+
+	dir := t.TempDir()
+	dumpDir := filepath.Join(dir, "dump")
+	rootCmd.SetArgs([]string{
+		"fetch",
+		"--api-key", "test-key",
+		"--address", "0xa39b189482f984388a34460636fea9eb181ad1a6",
+		"--output", filepath.Join(dir, "fetched.csv"),
+		"--save-raw", dumpDir,
+	})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("fetch error = %v", err)
+	}
+
+	// The server is not needed to normalize again
+	server.Close()
+	saveRaw = ""
+	rootCmd.SetArgs([]string{
+		"normalize",
+		"--input-dir", dumpDir,
+		"--output", filepath.Join(dir, "normalized.csv"),
+	})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("normalize error = %v", err)
+	}
+
+	fetched, err := os.ReadFile(filepath.Join(dir, "fetched.csv"))
+	if err != nil {
+		t.Fatalf("failed to read fetch output: %v", err)
+	}
+	normalized, err := os.ReadFile(filepath.Join(dir, "normalized.csv"))
+	if err != nil {
+		t.Fatalf("failed to read normalize output: %v", err)
+	}
+	if bytes.Count(fetched, []byte("\n")) < 2 {
+		t.Fatalf("Expected fetched rows, got %q", fetched)
+	}
+	if !bytes.Equal(normalized, fetched) {
+		t.Errorf("Normalized output mismatch:\ngot  %q\nwant %q", normalized, fetched)
+	}
+}
+
+func TestNormalizeRequiresInputDir(t *testing.T) {
+	defer func() { inputDir = "" }()
+
+	rootCmd.SetArgs([]string{"normalize", "--input-dir", filepath.Join(t.TempDir(), "missing")})
+	if err := rootCmd.Execute(); err == nil {
+		t.Error("Expected error for a missing input directory, got none")
+	}
+}
+
+func TestNormalizeAppliesFetchFilters(t *testing.T) {
+	defer func() { inputDir, outputFile, minAmount, txTypes = "", "", "", nil }()
+
+	dir := t.TempDir()
+	// LoadRawDump also accepts a response as downloaded from the API
+	if err := os.WriteFile(filepath.Join(dir, "normal.json"), []byte(testdata.NormalTxResponse), 0644); err != nil {
+		t.Fatalf("failed to write raw dump: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "erc20.json"), []byte(testdata.ERC20TokenTxResponse), 0644); err != nil {
+		t.Fatalf("failed to write raw dump: %v", err)
+	}
+
+	outPath := filepath.Join(t.TempDir(), "filtered.csv")
+	rootCmd.SetArgs([]string{
+		"normalize",
+		"--input-dir", dir,
+		"--output", outPath,
+		"--types", "normal",
+		"--min-amount", "0.75",
+	})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("normalize error = %v", err)
+	}
+
+	data, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("failed to read normalize output: %v", err)
+	}
+	rows := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(rows) != 2 {
+		t.Fatalf("Expected a header and one row, got %d lines:\n%s", len(rows), data)
+	}
+	if !strings.Contains(rows[1], "0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef") {
+		t.Errorf("Expected the 1 ETH transfer to remain, got %q", rows[1])
+	}
+}
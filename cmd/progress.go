@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"conintracker-hiring/pkg/output"
+	"conintracker-hiring/pkg/providers"
+)
+
+// progressBarWidth is the number of cells in the live progress bar
+const progressBarWidth = 20
+
+// progressDisplay shows fetch and write progress. On a terminal it redraws one
+// line in place; otherwise it prints a plain line per update so logs stay
+// readable. A nil display shows nothing, which is what --quiet uses.
+type progressDisplay struct {
+	out  io.Writer
+	live bool // Redraw in place rather than print lines
+	open bool // A live line is drawn and not yet ended
+
+	fetch   providers.FetchProgress
+	counts  []string // "normal 12" per finished type, in completion order
+	writing string   // Format being written; empty while fetching
+	written output.WriteProgress
+}
+
+// newProgressDisplay renders to out, redrawing in place when live is set
+func newProgressDisplay(out io.Writer, live bool) *progressDisplay {
+	return &progressDisplay{out: out, live: live}
+}
+
+// newCommandProgress returns the display for a command: nil when quiet, live
+// when stderr is a terminal, and plain lines when it is piped or redirected
+func newCommandProgress(quiet bool) *progressDisplay {
+	if quiet {
+		return nil
+	}
+	return newProgressDisplay(os.Stderr, isTerminal(os.Stderr))
+}
+
+// isTerminal reports whether f is a character device such as a terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// Fetched records a finished transaction type
+func (d *progressDisplay) Fetched(p providers.FetchProgress) {
+	if d == nil {
+		return
+	}
+	d.fetch = p
+	if p.LastErr != nil {
+		d.counts = append(d.counts, p.LastType.Name()+" failed")
+	} else {
+		d.counts = append(d.counts, fmt.Sprintf("%s %d", p.LastType.Name(), p.LastCount))
+	}
+
+	if !d.live {
+		fmt.Fprintf(d.out, "Fetched %s (%d of %d types, %d transactions so far)\n", d.counts[len(d.counts)-1], p.TypesComplete, p.TypesTotal, p.Transactions)
+		return
+	}
+	d.draw(p.TypesComplete >= p.TypesTotal)
+}
+
+// Wrote records rows written to the output in format
+func (d *progressDisplay) Wrote(format string, p output.WriteProgress) {
+	if d == nil {
+		return
+	}
+	d.writing, d.written = strings.ToUpper(format), p
+
+	if !d.live {
+		fmt.Fprintf(d.out, "Wrote %s rows: %s\n", d.writing, p)
+		return
+	}
+	d.draw(p.Total > 0 && p.Written >= p.Total)
+}
+
+// Finish ends a live line left open by an interrupted fetch or a failed write
+func (d *progressDisplay) Finish() {
+	if d == nil || !d.open {
+		return
+	}
+	fmt.Fprintln(d.out)
+	d.open = false
+}
+
+// draw redraws the live line, ending it when the current phase is complete
+func (d *progressDisplay) draw(complete bool) {
+	fmt.Fprintf(d.out, "\r\x1b[K%s", d)
+	d.open = true
+	if complete {
+		d.Finish()
+	}
+}
+
+// String renders the current phase, e.g.
+// "Fetching [######--------------] 2/6 types, 14 transactions (normal 12, internal 2)"
+// or "Writing CSV [##########----------] 50% (7/14)"
+func (d *progressDisplay) String() string {
+	if d.writing != "" {
+		percent, _ := d.written.Percent()
+		return fmt.Sprintf("Writing %s %s %s", d.writing, progressBar(percent), d.written)
+	}
+
+	percent := 0
+	if d.fetch.TypesTotal > 0 {
+		percent = d.fetch.TypesComplete * 100 / d.fetch.TypesTotal
+	}
+	line := fmt.Sprintf("Fetching %s %d/%d types, %d transactions", progressBar(percent), d.fetch.TypesComplete, d.fetch.TypesTotal, d.fetch.Transactions)
+	if len(d.counts) > 0 {
+		line += " (" + strings.Join(d.counts, ", ") + ")"
+	}
+	return line
+}
+
+// progressBar draws percent (0-100) as a bar of progressBarWidth cells
+func progressBar(percent int) string {
+	filled := min(max(percent, 0), 100) * progressBarWidth / 100
+	return "[" + strings.Repeat("#", filled) + strings.Repeat("-", progressBarWidth-filled) + "]"
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"conintracker-hiring/pkg/output"
+	"conintracker-hiring/pkg/providers"
+)
+
+// driveProgress feeds a display a three-type fetch followed by a CSV write
+func driveProgress(d *progressDisplay) {
+	d.Fetched(providers.FetchProgress{TypesComplete: 1, TypesTotal: 3, Transactions: 12, LastType: providers.TxTypeNormal, LastCount: 12})
+	d.Fetched(providers.FetchProgress{TypesComplete: 2, TypesTotal: 3, Transactions: 12, LastType: providers.TxTypeInternal, LastErr: errors.New("timeout")})
+	d.Fetched(providers.FetchProgress{TypesComplete: 3, TypesTotal: 3, Transactions: 14, LastType: providers.TxTypeToken, LastCount: 2})
+	d.Wrote(output.FormatCSV, output.WriteProgress{Written: 7, Total: 14})
+	d.Wrote(output.FormatCSV, output.WriteProgress{Written: 14, Total: 14})
+}
+
+func TestProgressDisplayLive(t *testing.T) {
+	var buf bytes.Buffer
+	driveProgress(newProgressDisplay(&buf, true))
+
+	// Each phase is redrawn in place and ends its line once complete
+	var final []string
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+		frames := strings.Split(line, "\r\x1b[K")
+		final = append(final, frames[len(frames)-1])
+	}
+	want := []string{
+		"Fetching [####################] 3/3 types, 14 transactions (normal 12, internal failed, erc20 2)",
+		"Writing CSV [####################] 100% (14/14)",
+	}
+	if len(final) != len(want) {
+		t.Fatalf("Rendered lines mismatch: got %q, want %q", final, want)
+	}
+	for i := range want {
+		if final[i] != want[i] {
+			t.Errorf("Line %d mismatch: got %q, want %q", i, final[i], want[i])
+		}
+	}
+}
+
+func TestProgressDisplayPlain(t *testing.T) {
+	var buf bytes.Buffer
+	driveProgress(newProgressDisplay(&buf, false))
+
+	want := `Fetched normal 12 (1 of 3 types, 12 transactions so far)
+Fetched internal failed (2 of 3 types, 12 transactions so far)
+Fetched erc20 2 (3 of 3 types, 14 transactions so far)
+Wrote CSV rows: 50% (7/14)
+Wrote CSV rows: 100% (14/14)
+`
+	if buf.String() != want {
+		t.Errorf("Plain output mismatch:\ngot  %q\nwant %q", buf.String(), want)
+	}
+}
+
+func TestProgressDisplayQuietAndFinish(t *testing.T) {
+	// A nil display, as --quiet creates, ignores every update
+	quietDisplay := newCommandProgress(true)
+	driveProgress(quietDisplay)
+	quietDisplay.Finish()
+
+	// Finish ends a line left open by an interrupted fetch, once
+	var buf bytes.Buffer
+	d := newProgressDisplay(&buf, true)
+	d.Fetched(providers.FetchProgress{TypesComplete: 1, TypesTotal: 3, Transactions: 5, LastType: providers.TxTypeNormal, LastCount: 5})
+	d.Finish()
+	d.Finish()
+	if want := "\r\x1b[KFetching [######--------------] 1/3 types, 5 transactions (normal 5)\n"; buf.String() != want {
+		t.Errorf("Interrupted output mismatch: got %q, want %q", buf.String(), want)
+	}
+}
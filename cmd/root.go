@@ -1,12 +1,55 @@
 package cmd
 
 import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"conintracker-hiring/pkg/providers"
+
 	"github.com/spf13/cobra"
 )
 
+// Process exit codes
+const (
+	ExitCodeError          = 1
+	ExitCodeNoTransactions = 2   // fetch --fail-on-empty found nothing to export
+	ExitCodeInterrupted    = 130 // SIGINT/SIGTERM; 128 + SIGINT, as shells report it
+)
+
+// ErrNoTransactions is returned when --fail-on-empty is set and no transactions remain
+var ErrNoTransactions = errors.New("no transactions found")
+
+// ErrErrorRateExceeded is returned when --max-error-rate is set and too many records failed to normalize
+var ErrErrorRateExceeded = errors.New("normalization error rate exceeded")
+
+// ErrStrictNormalization is returned when --strict is set and a record failed to normalize
+var ErrStrictNormalization = errors.New("records failed to normalize in strict mode")
+
+// ErrInterrupted is returned when a signal cancels a fetch after the partial export was written
+var ErrInterrupted = errors.New("interrupted, partial export written")
+
+// DefaultTimeout bounds a command's network work unless --timeout says otherwise
+const DefaultTimeout = 5 * time.Minute
+
+// httpRequestTimeout bounds a single provider request
+const httpRequestTimeout = 30 * time.Second
+
 var (
-	version = "0.1.0"
-	apiKey  string
+	version            = "0.1.0"
+	apiKey             string
+	timeout            time.Duration
+	insecureSkipVerify bool
+
+	// newTransport builds the transport provider requests go through; tests wrap it to observe requests
+	newTransport = defaultTransport
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -19,11 +62,109 @@ var rootCmd = &cobra.Command{
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
+// SIGINT and SIGTERM cancel the command's context so it can stop and write what it has.
 func Execute() error {
-	return rootCmd.Execute()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return rootCmd.ExecuteContext(ctx)
+}
+
+// ExitCode maps an error returned by Execute to a process exit code
+func ExitCode(err error) int {
+	if errors.Is(err, ErrNoTransactions) {
+		return ExitCodeNoTransactions
+	}
+	if errors.Is(err, ErrInterrupted) {
+		return ExitCodeInterrupted
+	}
+	return ExitCodeError
+}
+
+// providerKeys maps each data provider to its display name and API key env var
+var providerKeys = map[string]struct{ name, env string }{
+	"etherscan": {"Etherscan", "ETHERSCAN_API_KEY"},
+	"moralis":   {"Moralis", "MORALIS_API_KEY"},
+}
+
+// resolveAPIKey returns the API key for a provider from --api-key or the provider's
+// env var (ETHERSCAN_API_KEY, MORALIS_API_KEY)
+func resolveAPIKey(providerName string) (string, error) {
+	info, ok := providerKeys[providerName]
+	if !ok {
+		return "", fmt.Errorf("unsupported provider %q (supported: etherscan, moralis)", providerName)
+	}
+
+	key := apiKey
+	if key == "" {
+		key = os.Getenv(info.env)
+	}
+	if key == "" {
+		return "", fmt.Errorf("%s API key is required (set via --api-key flag or %s env var)", info.name, info.env)
+	}
+	return key, nil
+}
+
+// commandContext derives the context for a command's network work from its context
+// and --timeout. A zero timeout means no deadline; negative values are rejected.
+func commandContext(cmd *cobra.Command) (context.Context, context.CancelFunc, error) {
+	if timeout < 0 {
+		return nil, nil, fmt.Errorf("invalid timeout %s: must be zero (no timeout) or positive", timeout)
+	}
+	if timeout == 0 {
+		ctx, cancel := context.WithCancel(cmd.Context())
+		return ctx, cancel, nil
+	}
+	ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+	return ctx, cancel, nil
+}
+
+// explainChainAccess adds advice to errors caused by an API key without access to
+// the requested chain; other errors are returned unchanged
+func explainChainAccess(err error) error {
+	var chainErr *providers.ErrChainNotSupported
+	if !errors.As(err, &chainErr) {
+		return err
+	}
+	return fmt.Errorf("%w\nYour API key's plan does not cover %s (chain ID %d); use a key with access to this chain or choose another --chain", err, chain, chainErr.ChainID)
+}
+
+// defaultTransport is http.DefaultTransport's configuration with proxies taken from
+// HTTP_PROXY, HTTPS_PROXY and NO_PROXY, and certificate checks disabled when
+// --insecure-skip-verify is set
+func defaultTransport() http.RoundTripper {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	if insecureSkipVerify {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+	return transport
+}
+
+// newHTTPClient returns the client provider requests are sent with
+func newHTTPClient() *http.Client {
+	if insecureSkipVerify {
+		fmt.Fprintln(os.Stderr, "Warning: TLS certificate verification is disabled (--insecure-skip-verify)")
+	}
+	return &http.Client{
+		Timeout:   httpRequestTimeout,
+		Transport: newTransport(),
+	}
+}
+
+// splitAPIKeys splits a comma-separated key pool, dropping blanks
+func splitAPIKeys(keys string) []string {
+	var pool []string
+	for _, key := range strings.Split(keys, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			pool = append(pool, key)
+		}
+	}
+	return pool
 }
 
 func init() {
 	// Global flags
-	rootCmd.PersistentFlags().StringVar(&apiKey, "api-key", "", "Etherscan API key (can also be set via ETHERSCAN_API_KEY env var)")
+	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", DefaultTimeout, "Overall time limit for network requests, e.g. 30m or 1h30m (0 for no timeout)")
+	rootCmd.PersistentFlags().StringVar(&apiKey, "api-key", "", "Provider API key, or a comma-separated Etherscan key pool (can also be set via ETHERSCAN_API_KEY or MORALIS_API_KEY)")
+	rootCmd.PersistentFlags().BoolVar(&insecureSkipVerify, "insecure-skip-verify", false, "Skip TLS certificate verification for provider requests (development only, e.g. behind an intercepting proxy)")
 }
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func TestCommandContextAppliesTimeout(t *testing.T) {
+	defer func() { timeout = DefaultTimeout }()
+
+	tests := []struct {
+		name         string
+		timeout      time.Duration
+		wantDeadline bool
+		wantErr      bool
+	}{
+		{"default", DefaultTimeout, true, false},
+		{"custom", 2 * time.Hour, true, false},
+		{"zero_means_none", 0, false, false},
+		{"negative", -time.Second, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timeout = tt.timeout
+			cmd := &cobra.Command{}
+			cmd.SetContext(context.Background())
+
+			start := time.Now()
+			ctx, cancel, err := commandContext(cmd)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Expected an error for a negative timeout")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("commandContext() error = %v", err)
+			}
+			defer cancel()
+
+			deadline, ok := ctx.Deadline()
+			if ok != tt.wantDeadline {
+				t.Fatalf("Deadline presence mismatch: got %v, want %v", ok, tt.wantDeadline)
+			}
+			if ok && deadline.Sub(start) < tt.timeout-time.Second {
+				t.Errorf("Deadline %v is sooner than the %v timeout", deadline.Sub(start), tt.timeout)
+			}
+		})
+	}
+}
+
+// recordingTransport counts requests before passing them to base
+type recordingTransport struct {
+	base     http.RoundTripper
+	mu       sync.Mutex
+	requests []string
+}
+
+func (rt *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.mu.Lock()
+	rt.requests = append(rt.requests, req.URL.Query().Get("action"))
+	rt.mu.Unlock()
+	return rt.base.RoundTrip(req)
+}
+
+func TestFetchUsesConfiguredTransport(t *testing.T) {
+	recorder := &recordingTransport{base: defaultTransport()}
+	newTransport = func() http.RoundTripper { return recorder }
+	defer func() { newTransport = defaultTransport }()
+
+	if err := runFetchAgainst(t); err != nil {
+		t.Fatalf("fetch error = %v", err)
+	}
+	if len(recorder.requests) == 0 {
+		t.Error("Expected provider requests to go through the configured transport")
+	}
+}
+
+func TestDefaultTransport(t *testing.T) {
+	defer func() { insecureSkipVerify = false }()
+
+	transport, ok := defaultTransport().(*http.Transport)
+	if !ok {
+		t.Fatalf("Expected *http.Transport, got %T", defaultTransport())
+	}
+	if transport.Proxy == nil {
+		t.Error("Expected proxies to be taken from the environment")
+	}
+	if transport.TLSClientConfig != nil && transport.TLSClientConfig.InsecureSkipVerify {
+		t.Error("Expected certificate verification by default")
+	}
+
+	insecureSkipVerify = true
+	transport = defaultTransport().(*http.Transport)
+	if transport.TLSClientConfig == nil || !transport.TLSClientConfig.InsecureSkipVerify {
+		t.Error("Expected --insecure-skip-verify to disable certificate verification")
+	}
+}
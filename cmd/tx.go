@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"conintracker-hiring/pkg/models"
+	"conintracker-hiring/pkg/providers"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// txHashPattern matches a 0x-prefixed 32-byte transaction hash
+var txHashPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{64}$`)
+
+// txCmd fetches and prints a single normalized transaction
+var txCmd = &cobra.Command{
+	Use:   "tx <hash>",
+	Short: "Fetch and print a single transaction by hash",
+	Long:  `Fetches one transaction through Etherscan's proxy endpoints and prints its normalized fields, for spot-checking exports.`,
+	Args:  cobra.ExactArgs(1),
+	RunE:  runTx,
+}
+
+func init() {
+	rootCmd.AddCommand(txCmd)
+
+	txCmd.Flags().StringVar(&chain, "chain", providers.DefaultChain, "Chain to query")
+}
+
+func runTx(cmd *cobra.Command, args []string) error {
+	hash := args[0]
+	if !txHashPattern.MatchString(hash) {
+		return fmt.Errorf("invalid transaction hash format: %s", hash)
+	}
+	if _, ok := providers.ChainID(chain); !ok {
+		return fmt.Errorf("unsupported chain %q", chain)
+	}
+
+	etherscanKey, err := resolveAPIKey("etherscan")
+	if err != nil {
+		return err
+	}
+
+	client := providers.NewEtherscanClient(providers.ClientConfig{
+		APIKeys:    splitAPIKeys(etherscanKey),
+		BaseURL:    etherscanBaseURL,
+		Chain:      chain,
+		HTTPClient: newHTTPClient(),
+		RateLimit:  etherscanRateLimit,
+		Clock:      etherscanClock,
+	})
+
+	ctx, cancel, err := commandContext(cmd)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	raw, err := client.FetchTransactionByHash(ctx, hash)
+	if errors.Is(err, providers.ErrTransactionNotFound) {
+		return fmt.Errorf("transaction %s not found on %s", hash, chain)
+	}
+	if err != nil {
+		return explainChainAccess(err)
+	}
+
+	normalizer := providers.NewEtherscanNormalizer()
+	normalizer.SetNativeSymbol(providers.ChainNativeSymbol(chain))
+	tx, err := normalizer.NormalizeNormalTx(*raw)
+	if err != nil {
+		return fmt.Errorf("failed to normalize transaction: %w", err)
+	}
+	tx.Chain = client.Chain()
+
+	printTransaction(cmd.OutOrStdout(), tx)
+	return nil
+}
+
+// printTransaction writes a normalized transaction as aligned "field: value" lines
+func printTransaction(w io.Writer, tx *models.Transaction) {
+	status := "success"
+	if tx.IsError {
+		status = "failed"
+	}
+
+	fields := []struct{ name, value string }{
+		{"Hash", tx.Hash},
+		{"Chain", tx.Chain},
+		{"Block", fmt.Sprint(tx.BlockNumber)},
+		{"Date & Time", tx.Timestamp.UTC().Format(time.RFC3339)},
+		{"From", tx.From},
+		{"To", tx.To},
+		{"Type", string(tx.Type)},
+		{"Amount", tx.Amount + " " + tx.AssetSymbol},
+		{"Gas Used", fmt.Sprint(tx.GasUsed)},
+		{"Gas Fee (ETH)", tx.GasFeeETH},
+		{"Method ID", tx.MethodID},
+		{"Status", status},
+	}
+	for _, f := range fields {
+		fmt.Fprintf(w, "%-14s %s\n", f.name+":", f.value)
+	}
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"conintracker-hiring/internal/testdata"
+)
+
+// runTxAgainst executes the tx command against a server serving canned proxy responses
+func runTxAgainst(t *testing.T, responses map[string]string, hash string) (string, error) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		response, ok := responses[r.URL.Query().Get("action")]
+		if !ok {
+			response = testdata.ProxyNullResponse
+		}
+		w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	useEtherscanServer(t, server.URL)
+
+	out := &bytes.Buffer{}
+	rootCmd.SetOut(out)
+	defer rootCmd.SetOut(nil)
+
+	rootCmd.SetArgs([]string{"tx", hash, "--api-key", "test-key", "--chain", "ethereum"})
+	err := rootCmd.Execute()
+	return out.String(), err
+}
+
+func TestTxPrintsNormalizedTransaction(t *testing.T) {
+	hash := "0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+	out, err := runTxAgainst(t, map[string]string{
+		"eth_getTransactionByHash":  testdata.ProxyTransactionResponse,
+		"eth_getTransactionReceipt": testdata.ProxyReceiptResponse,
+		"eth_getBlockByNumber":      testdata.ProxyBlockResponse,
+	}, hash)
+	if err != nil {
+		t.Fatalf("tx error = %v", err)
+	}
+
+	for _, want := range []string{
+		"Hash:          " + hash,
+		"Block:         20000000",
+		"Date & Time:   2023-11-14T22:13:20Z",
+		"Amount:        1 ETH\n",
+		"Gas Fee (ETH): 0.00105",
+		"Status:        success",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestTxNotFound(t *testing.T) {
+	hash := "0x" + strings.Repeat("0", 64)
+	_, err := runTxAgainst(t, nil, hash)
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("Expected not-found error, got %v", err)
+	}
+}
+
+func TestTxRejectsMalformedHash(t *testing.T) {
+	if _, err := runTxAgainst(t, nil, "0x1234"); err == nil {
+		t.Fatal("Expected error for malformed hash")
+	}
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+
+	"github.com/spf13/cobra"
+)
+
+// Build metadata, set at link time:
+//
+//	go build -ldflags "-X conintracker-hiring/cmd.commit=$(git rev-parse --short HEAD) -X conintracker-hiring/cmd.buildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+var (
+	commit    string
+	buildDate string
+
+	// readBuildInfo is swapped out in tests
+	readBuildInfo = debug.ReadBuildInfo
+)
+
+// BuildInfo describes the running binary
+type BuildInfo struct {
+	Version   string
+	Commit    string
+	BuildDate string
+	GoVersion string
+}
+
+// versionCmd prints version and build information
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print version and build information",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Fprint(cmd.OutOrStdout(), currentBuildInfo().String())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(versionCmd)
+	rootCmd.SetVersionTemplate(currentBuildInfo().String())
+}
+
+// currentBuildInfo combines link-time values with the module build info embedded
+// by the Go toolchain, falling back to "unknown" for anything unavailable
+func currentBuildInfo() BuildInfo {
+	info := BuildInfo{
+		Version:   version,
+		Commit:    commit,
+		BuildDate: buildDate,
+		GoVersion: runtime.Version(),
+	}
+
+	if bi, ok := readBuildInfo(); ok && bi != nil {
+		if bi.GoVersion != "" {
+			info.GoVersion = bi.GoVersion
+		}
+		for _, setting := range bi.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = setting.Value
+				}
+			case "vcs.time":
+				if info.BuildDate == "" {
+					info.BuildDate = setting.Value
+				}
+			}
+		}
+	}
+
+	if info.Commit == "" {
+		info.Commit = "unknown"
+	}
+	if info.BuildDate == "" {
+		info.BuildDate = "unknown"
+	}
+	return info
+}
+
+// String renders the build info for the version command and --version
+func (b BuildInfo) String() string {
+	return fmt.Sprintf("cointracker version %s\n  commit:     %s\n  built:      %s\n  go version: %s\n",
+		b.Version, b.Commit, b.BuildDate, b.GoVersion)
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"bytes"
+	"runtime/debug"
+	"strings"
+	"testing"
+)
+
+func TestVersionCommandWithoutBuildInfo(t *testing.T) {
+	previous := readBuildInfo
+	readBuildInfo = func() (*debug.BuildInfo, bool) { return nil, false }
+	defer func() { readBuildInfo = previous }()
+
+	buf := &bytes.Buffer{}
+	rootCmd.SetOut(buf)
+	defer rootCmd.SetOut(nil)
+
+	rootCmd.SetArgs([]string{"version"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("version command error = %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "cointracker version "+version) {
+		t.Errorf("Version string missing: %s", out)
+	}
+	if !strings.Contains(out, "commit:     unknown") {
+		t.Errorf("Expected unknown commit fallback: %s", out)
+	}
+	if !strings.Contains(out, "go version: go") {
+		t.Errorf("Go version missing: %s", out)
+	}
+}
+
+func TestCurrentBuildInfoPrefersLinkTimeValues(t *testing.T) {
+	previous := readBuildInfo
+	readBuildInfo = func() (*debug.BuildInfo, bool) {
+		return &debug.BuildInfo{
+			GoVersion: "go1.24.2",
+			Settings: []debug.BuildSetting{
+				{Key: "vcs.revision", Value: "fromvcs"},
+				{Key: "vcs.time", Value: "2024-01-01T00:00:00Z"},
+			},
+		}, true
+	}
+	defer func() { readBuildInfo = previous }()
+
+	commit = "abc1234"
+	defer func() { commit = "" }()
+
+	info := currentBuildInfo()
+	if info.Commit != "abc1234" {
+		t.Errorf("Commit mismatch: got %s, want abc1234", info.Commit)
+	}
+	if info.BuildDate != "2024-01-01T00:00:00Z" {
+		t.Errorf("BuildDate mismatch: got %s, want 2024-01-01T00:00:00Z", info.BuildDate)
+	}
+	if info.GoVersion != "go1.24.2" {
+		t.Errorf("GoVersion mismatch: got %s, want go1.24.2", info.GoVersion)
+	}
+}
@@ -0,0 +1,103 @@
+// Package keccak implements the legacy Keccak-256 hash used by Ethereum, which
+// differs from the standardized SHA3-256 only in its padding byte
+package keccak
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// rate is the Keccak-256 block size in bytes (1600-bit state minus 2x256-bit capacity)
+const rate = 136
+
+// roundConstants are the iota step constants for the 24 rounds of Keccak-f[1600]
+var roundConstants = [24]uint64{
+	0x0000000000000001, 0x0000000000008082, 0x800000000000808a, 0x8000000080008000,
+	0x000000000000808b, 0x0000000080000001, 0x8000000080008081, 0x8000000000008009,
+	0x000000000000008a, 0x0000000000000088, 0x0000000080008009, 0x000000008000000a,
+	0x000000008000808b, 0x800000000000008b, 0x8000000000008089, 0x8000000000008003,
+	0x8000000000008002, 0x8000000000000080, 0x000000000000800a, 0x800000008000000a,
+	0x8000000080008081, 0x8000000000008080, 0x0000000080000001, 0x8000000080008008,
+}
+
+// rotations are the rho step offsets, indexed x + 5*y
+var rotations = [25]int{
+	0, 1, 62, 28, 27,
+	36, 44, 6, 55, 20,
+	3, 10, 43, 25, 39,
+	41, 45, 15, 21, 8,
+	18, 2, 61, 56, 14,
+}
+
+// Domain separation bytes: legacy Keccak pads with 0x01, FIPS 202 SHA3 with 0x06
+const (
+	keccakPad = 0x01
+	sha3Pad   = 0x06
+)
+
+// Sum256 returns the Keccak-256 digest of data
+func Sum256(data []byte) [32]byte {
+	return sum256(data, keccakPad)
+}
+
+// sum256 absorbs data padded with pad ... 0x80 and squeezes a 256-bit digest
+func sum256(data []byte, pad byte) [32]byte {
+	var state [25]uint64
+
+	padded := make([]byte, len(data), len(data)+rate)
+	copy(padded, data)
+	padded = append(padded, pad)
+	for len(padded)%rate != 0 {
+		padded = append(padded, 0)
+	}
+	padded[len(padded)-1] |= 0x80
+
+	for offset := 0; offset < len(padded); offset += rate {
+		for i := 0; i < rate/8; i++ {
+			state[i] ^= binary.LittleEndian.Uint64(padded[offset+8*i:])
+		}
+		permute(&state)
+	}
+
+	var digest [32]byte
+	for i := 0; i < 4; i++ {
+		binary.LittleEndian.PutUint64(digest[8*i:], state[i])
+	}
+	return digest
+}
+
+// permute applies the Keccak-f[1600] permutation
+func permute(a *[25]uint64) {
+	var c [5]uint64
+	var b [25]uint64
+
+	for round := 0; round < 24; round++ {
+		// Theta
+		for x := 0; x < 5; x++ {
+			c[x] = a[x] ^ a[x+5] ^ a[x+10] ^ a[x+15] ^ a[x+20]
+		}
+		for x := 0; x < 5; x++ {
+			d := c[(x+4)%5] ^ bits.RotateLeft64(c[(x+1)%5], 1)
+			for y := 0; y < 25; y += 5 {
+				a[x+y] ^= d
+			}
+		}
+
+		// Rho and pi
+		for x := 0; x < 5; x++ {
+			for y := 0; y < 5; y++ {
+				b[y+5*((2*x+3*y)%5)] = bits.RotateLeft64(a[x+5*y], rotations[x+5*y])
+			}
+		}
+
+		// Chi
+		for y := 0; y < 25; y += 5 {
+			for x := 0; x < 5; x++ {
+				a[x+y] = b[x+y] ^ (^b[(x+1)%5+y] & b[(x+2)%5+y])
+			}
+		}
+
+		// Iota
+		a[0] ^= roundConstants[round]
+	}
+}
@@ -0,0 +1,41 @@
+package keccak
+
+import (
+	"crypto/sha3"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestSum256(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"empty", "", "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"},
+		{"abc", "abc", "4e03657aea45a94fc7d47ba826c8d667c0d1e6e33a64a036ec44f58fa12d6c45"},
+		{"transfer_selector", "transfer(address,uint256)", "a9059cbb2ab09eb219583f4a59a5d0623ade346d962bcd4e46b11da047c9049b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sum := Sum256([]byte(tt.input))
+			got := hex.EncodeToString(sum[:])
+			if got != tt.want {
+				t.Errorf("Sum256 mismatch: got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestPermutationMatchesSHA3 checks the sponge against the standard library across
+// block boundaries; SHA3-256 shares Keccak-256's permutation and rate
+func TestPermutationMatchesSHA3(t *testing.T) {
+	for _, n := range []int{0, 1, rate - 1, rate, rate + 1, 2 * rate, 1000} {
+		data := []byte(strings.Repeat("a", n))
+		if got, want := sum256(data, sha3Pad), sha3.Sum256(data); got != want {
+			t.Errorf("SHA3-256 mismatch for %d bytes: got %x, want %x", n, got, want)
+		}
+	}
+}
@@ -193,6 +193,30 @@ const ERC1155Response = `{
   ]
 }`
 
+// BeaconWithdrawalResponse is a sample Etherscan response for beacon chain withdrawals
+const BeaconWithdrawalResponse = `{
+  "status": "1",
+  "message": "OK",
+  "result": [
+    {
+      "withdrawalIndex": "10435271",
+      "validatorIndex": "482913",
+      "address": "0xa39b189482f984388a34460636fea9eb181ad1a6",
+      "amount": "18234567",
+      "blockNumber": "19999995",
+      "timestamp": "1699999950"
+    },
+    {
+      "withdrawalIndex": "10435272",
+      "validatorIndex": "482913",
+      "address": "0xa39b189482f984388a34460636fea9eb181ad1a6",
+      "amount": "32000000000",
+      "blockNumber": "19999996",
+      "timestamp": "1699999962"
+    }
+  ]
+}`
+
 // ErrorResponse is a sample error response from Etherscan
 const ErrorResponse = `{
   "status": "0",
@@ -200,6 +224,13 @@ const ErrorResponse = `{
   "result": "Invalid API Key"
 }`
 
+// ChainNotSupportedResponse is returned when the API key's plan does not cover the queried chain
+const ChainNotSupportedResponse = `{
+  "status": "0",
+  "message": "NOTOK",
+  "result": "Free API access is not supported for this chain. Please upgrade your api plan for full chain coverage. https://etherscan.io/apis"
+}`
+
 // RateLimitResponse is a sample rate-limit response
 const RateLimitResponse = `{
   "status": "0",
@@ -207,9 +238,81 @@ const RateLimitResponse = `{
   "result": "Max rate limit reached, please use API Key for higher rate limit"
 }`
 
+// NoTransactionsResponse is what Etherscan returns for an address with no activity
+const NoTransactionsResponse = `{
+  "status": "0",
+  "message": "No transactions found",
+  "result": []
+}`
+
 // EmptyResultResponse is a response with no transactions
 const EmptyResultResponse = `{
   "status": "1",
   "message": "OK",
   "result": []
 }`
+
+// ProxyTransactionResponse is a sample proxy eth_getTransactionByHash response
+const ProxyTransactionResponse = `{
+  "jsonrpc": "2.0",
+  "id": 1,
+  "result": {
+    "blockHash": "0x8b3b2b3c1a4e1b0e9f7d7b2d7b2e3c3f1d0a9e8c7b6a5f4e3d2c1b0a99887766",
+    "blockNumber": "0x1312d00",
+    "from": "0xa39b189482f984388a34460636fea9eb181ad1a6",
+    "gas": "0x5208",
+    "gasPrice": "0xba43b7400",
+    "hash": "0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef",
+    "input": "0x",
+    "nonce": "0x2a",
+    "to": "0xd620AADaBaA20d2af700853C4504028cba7C3333",
+    "transactionIndex": "0xf",
+    "value": "0xde0b6b3a7640000"
+  }
+}`
+
+// ProxyReceiptResponse is a sample proxy eth_getTransactionReceipt response
+const ProxyReceiptResponse = `{
+  "jsonrpc": "2.0",
+  "id": 1,
+  "result": {
+    "blockNumber": "0x1312d00",
+    "contractAddress": null,
+    "cumulativeGasUsed": "0x4c4b40",
+    "effectiveGasPrice": "0xba43b7400",
+    "gasUsed": "0x5208",
+    "status": "0x1",
+    "transactionHash": "0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+  }
+}`
+
+// ProxyBlockResponse is a sample proxy eth_getBlockByNumber response (header only)
+const ProxyBlockResponse = `{
+  "jsonrpc": "2.0",
+  "id": 1,
+  "result": {
+    "number": "0x1312d00",
+    "timestamp": "0x6553f100"
+  }
+}`
+
+// ProxyBlockNumberResponse is a sample proxy eth_blockNumber response (block 20,000,000)
+const ProxyBlockNumberResponse = `{
+  "jsonrpc": "2.0",
+  "id": 1,
+  "result": "0x1312d00"
+}`
+
+// ProxyNullResponse is what the proxy module returns for an unknown hash
+const ProxyNullResponse = `{
+  "jsonrpc": "2.0",
+  "id": 1,
+  "result": null
+}`
+
+// TokenBalanceResponse is a sample tokenbalance response: 1,234.5 USDC (6 decimals)
+const TokenBalanceResponse = `{
+  "status": "1",
+  "message": "OK",
+  "result": "1234500000"
+}`
@@ -0,0 +1,138 @@
+package testdata
+
+// MoralisWalletPage1Response is the first page of a Moralis wallet transaction
+// history (GET /{address}); its cursor points at MoralisWalletPage2Response
+const MoralisWalletPage1Response = `{
+  "page": 0,
+  "page_size": 1,
+  "cursor": "cursor-page-2",
+  "result": [
+    {
+      "hash": "0x1111111111111111111111111111111111111111111111111111111111111111",
+      "nonce": "5",
+      "transaction_index": "12",
+      "from_address": "0xa39b189482f984388a34460636fea9eb181ad1a6",
+      "to_address": "0xd620aadabaa20d2af700853c4504028cba7c3333",
+      "value": "1500000000000000000",
+      "gas": "21000",
+      "gas_price": "20000000000",
+      "input": "0x",
+      "receipt_gas_used": "21000",
+      "receipt_status": "1",
+      "receipt_contract_address": null,
+      "block_timestamp": "2023-11-14T22:13:20.000Z",
+      "block_number": "18570000",
+      "block_hash": "0xblock1",
+      "internal_transactions": [
+        {
+          "transaction_hash": "0x1111111111111111111111111111111111111111111111111111111111111111",
+          "block_number": "18570000",
+          "type": "CALL",
+          "from": "0xd620aadabaa20d2af700853c4504028cba7c3333",
+          "to": "0xa39b189482f984388a34460636fea9eb181ad1a6",
+          "value": "250000000000000000",
+          "gas": "2300",
+          "gas_used": "0",
+          "input": "0x"
+        }
+      ]
+    }
+  ]
+}`
+
+// MoralisWalletPage2Response is the last page of the wallet history (no cursor)
+const MoralisWalletPage2Response = `{
+  "page": 1,
+  "page_size": 1,
+  "cursor": null,
+  "result": [
+    {
+      "hash": "0x2222222222222222222222222222222222222222222222222222222222222222",
+      "nonce": "6",
+      "transaction_index": "3",
+      "from_address": "0xa39b189482f984388a34460636fea9eb181ad1a6",
+      "to_address": "0x1111111254fb6c44bac0bed2854e76f90643097d",
+      "value": "0",
+      "gas": "120000",
+      "gas_price": "30000000000",
+      "input": "0xa9059cbb",
+      "receipt_gas_used": "50000",
+      "receipt_status": "0",
+      "receipt_contract_address": null,
+      "block_timestamp": "2023-11-15T08:00:00.000Z",
+      "block_number": "18572900",
+      "block_hash": "0xblock2",
+      "internal_transactions": []
+    }
+  ]
+}`
+
+// MoralisERC20Response is a single page of ERC-20 transfers (GET /{address}/erc20/transfers)
+const MoralisERC20Response = `{
+  "page": 0,
+  "page_size": 100,
+  "cursor": null,
+  "result": [
+    {
+      "token_name": "USD Coin",
+      "token_symbol": "USDC",
+      "token_logo": null,
+      "token_decimals": "6",
+      "address": "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48",
+      "transaction_hash": "0x3333333333333333333333333333333333333333333333333333333333333333",
+      "transaction_index": "40",
+      "log_index": 120,
+      "block_timestamp": "2023-11-16T00:00:00.000Z",
+      "block_number": "18577000",
+      "block_hash": "0xblock3",
+      "from_address": "0xd620aadabaa20d2af700853c4504028cba7c3333",
+      "to_address": "0xa39b189482f984388a34460636fea9eb181ad1a6",
+      "value": "2500000000",
+      "possible_spam": false
+    }
+  ]
+}`
+
+// MoralisNFTResponse mixes ERC-721 and ERC-1155 transfers (GET /{address}/nft/transfers)
+const MoralisNFTResponse = `{
+  "page": 0,
+  "page_size": 100,
+  "cursor": null,
+  "result": [
+    {
+      "token_address": "0xbc4ca0eda7647a8ab7c2061c2e118a18a936f13d",
+      "token_id": "7537",
+      "token_name": "BoredApeYachtClub",
+      "token_symbol": "BAYC",
+      "contract_type": "ERC721",
+      "amount": "1",
+      "transaction_hash": "0x4444444444444444444444444444444444444444444444444444444444444444",
+      "transaction_index": "7",
+      "block_timestamp": "2023-11-17T00:00:00.000Z",
+      "block_number": "18584000",
+      "block_hash": "0xblock4",
+      "from_address": "0xd620aadabaa20d2af700853c4504028cba7c3333",
+      "to_address": "0xa39b189482f984388a34460636fea9eb181ad1a6"
+    },
+    {
+      "token_address": "0x76be3b62873462d2142405439777e971754e8e77",
+      "token_id": "10064",
+      "token_name": "parallel",
+      "token_symbol": "LL",
+      "contract_type": "ERC1155",
+      "amount": "3",
+      "transaction_hash": "0x5555555555555555555555555555555555555555555555555555555555555555",
+      "transaction_index": "9",
+      "block_timestamp": "2023-11-18T00:00:00.000Z",
+      "block_number": "18591000",
+      "block_hash": "0xblock5",
+      "from_address": "0xd620aadabaa20d2af700853c4504028cba7c3333",
+      "to_address": "0xa39b189482f984388a34460636fea9eb181ad1a6"
+    }
+  ]
+}`
+
+// MoralisErrorResponse is what Moralis returns with HTTP 401 for a bad API key
+const MoralisErrorResponse = `{
+  "message": "Invalid key"
+}`
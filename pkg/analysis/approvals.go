@@ -0,0 +1,101 @@
+package analysis
+
+import (
+	"conintracker-hiring/pkg/models"
+	"strings"
+)
+
+// approveCalldataLen is the length of approve(address,uint256) calldata as a hex
+// string: "0x", the 4-byte selector, and two 32-byte arguments
+const approveCalldataLen = 2 + 8 + 64 + 64
+
+// maxUint256Word is the ABI encoding of 2^256-1, the allowance wallets set for
+// "unlimited" approvals
+var maxUint256Word = strings.Repeat("f", 64)
+
+// IsUnlimitedApproval reports whether tx calls approve(address,uint256) with the
+// maximum uint256 amount, letting the spender move any amount of the token.
+// Calldata that is missing or too short to decode is not flagged.
+func IsUnlimitedApproval(tx *models.Transaction) bool {
+	if tx == nil || len(tx.Input) < approveCalldataLen {
+		return false
+	}
+	input := strings.ToLower(tx.Input)
+	if !strings.HasPrefix(input, SelectorApprove) {
+		return false
+	}
+	return input[approveCalldataLen-64:approveCalldataLen] == maxUint256Word
+}
+
+// FlagUnlimitedApprovals sets UnlimitedApproval on every transaction that grants
+// an unlimited ERC-20 allowance
+func FlagUnlimitedApprovals(txs []*models.Transaction) {
+	for _, tx := range txs {
+		if tx != nil {
+			tx.UnlimitedApproval = IsUnlimitedApproval(tx)
+		}
+	}
+}
+
+// ApprovalLinkBlocks is how many blocks after an approval a transfer can land and
+// still be linked to it by LinkApprovals
+const ApprovalLinkBlocks = 5
+
+// approvalSpender returns the lowercased spender address from approve(address,uint256)
+// calldata, or "" when tx is not a decodable approve call
+func approvalSpender(tx *models.Transaction) string {
+	if len(tx.Input) < approveCalldataLen {
+		return ""
+	}
+	input := strings.ToLower(tx.Input)
+	if !strings.HasPrefix(input, SelectorApprove) {
+		return ""
+	}
+	// The address is the low 20 bytes of the first 32-byte argument
+	return "0x" + input[len(SelectorApprove)+24:len(SelectorApprove)+64]
+}
+
+// LinkApprovals sets RelatedApprovalHash on each ERC-20 transfer that moves the
+// approver's tokens to the spender of an earlier approve() call on the same token
+// contract, at most ApprovalLinkBlocks blocks later. When several approvals
+// qualify, the most recent one is used. Failed approvals are ignored.
+func LinkApprovals(txs []*models.Transaction) {
+	// Approvals keyed by token contract, owner and spender
+	approvals := make(map[string][]*models.Transaction)
+	for _, tx := range txs {
+		if tx == nil || tx.Type != models.TypeEthTransfer || tx.IsError {
+			continue
+		}
+		spender := approvalSpender(tx)
+		if spender == "" {
+			continue
+		}
+		key := approvalKey(tx.To, tx.From, spender)
+		approvals[key] = append(approvals[key], tx)
+	}
+	if len(approvals) == 0 {
+		return
+	}
+
+	for _, tx := range txs {
+		if tx == nil || tx.Type != models.TypeERC20Transfer {
+			continue
+		}
+		var related *models.Transaction
+		for _, approval := range approvals[approvalKey(tx.AssetContractAddress, tx.From, tx.To)] {
+			if approval.BlockNumber > tx.BlockNumber || tx.BlockNumber-approval.BlockNumber > ApprovalLinkBlocks {
+				continue
+			}
+			if related == nil || approval.BlockNumber > related.BlockNumber {
+				related = approval
+			}
+		}
+		if related != nil {
+			tx.RelatedApprovalHash = related.Hash
+		}
+	}
+}
+
+func approvalKey(token, owner, spender string) string {
+	return strings.ToLower(token) + "|" + strings.ToLower(owner) + "|" + strings.ToLower(spender)
+}
@@ -0,0 +1,109 @@
+package analysis
+
+import (
+	"conintracker-hiring/pkg/models"
+	"strings"
+	"testing"
+)
+
+// approveCalldata encodes approve(spender, amount) with amount given as 64 hex digits
+func approveCalldata(amount string) string {
+	spender := strings.Repeat("0", 24) + "7a250d5630b4cf539739df2c5dacb4c659f2488d" // Uniswap V2 router
+	return SelectorApprove + spender + amount
+}
+
+func TestIsUnlimitedApproval(t *testing.T) {
+	maxAmount := strings.Repeat("f", 64)
+	limited := strings.Repeat("0", 56) + "3b9aca00" // 1,000,000,000
+
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{name: "max_allowance", input: approveCalldata(maxAmount), want: true},
+		{name: "max_allowance_uppercase", input: "0x095EA7B3" + strings.ToUpper(approveCalldata(maxAmount)[10:]), want: true},
+		{name: "limited_allowance", input: approveCalldata(limited), want: false},
+		{name: "revoke", input: approveCalldata(strings.Repeat("0", 64)), want: false},
+		{name: "truncated", input: approveCalldata(maxAmount)[:100], want: false},
+		{name: "other_selector", input: SelectorTransfer + approveCalldata(maxAmount)[10:], want: false},
+		{name: "no_input", input: "0x", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := &models.Transaction{Type: models.TypeEthTransfer, Input: tt.input}
+			if got := IsUnlimitedApproval(tx); got != tt.want {
+				t.Errorf("IsUnlimitedApproval() mismatch: got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlagUnlimitedApprovals(t *testing.T) {
+	unlimited := &models.Transaction{Input: approveCalldata(strings.Repeat("f", 64))}
+	limited := &models.Transaction{Input: approveCalldata(strings.Repeat("0", 63) + "1")}
+
+	FlagUnlimitedApprovals([]*models.Transaction{unlimited, nil, limited})
+
+	if !unlimited.UnlimitedApproval {
+		t.Error("Expected the max allowance approval to be flagged")
+	}
+	if limited.UnlimitedApproval {
+		t.Error("Expected the limited approval not to be flagged")
+	}
+}
+
+func TestLinkApprovals(t *testing.T) {
+	const (
+		owner  = "0x1111111111111111111111111111111111111111"
+		router = "0x7a250d5630b4cf539739df2c5dacb4c659f2488d" // spender in approveCalldata
+		token  = "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"
+		other  = "0x2222222222222222222222222222222222222222"
+	)
+	amount := strings.Repeat("f", 64)
+
+	approve := &models.Transaction{Hash: "0xapprove", Type: models.TypeEthTransfer, BlockNumber: 100, From: owner, To: token, Input: approveCalldata(amount)}
+	failed := &models.Transaction{Hash: "0xfailed", Type: models.TypeEthTransfer, BlockNumber: 101, From: owner, To: token, Input: approveCalldata(amount), IsError: true}
+	transfer := &models.Transaction{Hash: "0xswap", Type: models.TypeERC20Transfer, BlockNumber: 102, From: owner, To: strings.ToUpper(router), AssetContractAddress: token}
+	tooLate := &models.Transaction{Hash: "0xlate", Type: models.TypeERC20Transfer, BlockNumber: 100 + ApprovalLinkBlocks + 1, From: owner, To: router, AssetContractAddress: token}
+	before := &models.Transaction{Hash: "0xbefore", Type: models.TypeERC20Transfer, BlockNumber: 99, From: owner, To: router, AssetContractAddress: token}
+	otherToken := &models.Transaction{Hash: "0xother", Type: models.TypeERC20Transfer, BlockNumber: 102, From: owner, To: router, AssetContractAddress: other}
+	otherRecipient := &models.Transaction{Hash: "0xpay", Type: models.TypeERC20Transfer, BlockNumber: 102, From: owner, To: other, AssetContractAddress: token}
+
+	LinkApprovals([]*models.Transaction{before, approve, failed, nil, transfer, otherToken, otherRecipient, tooLate})
+
+	tests := []struct {
+		tx   *models.Transaction
+		want string
+	}{
+		{tx: transfer, want: "0xapprove"},
+		{tx: tooLate, want: ""},
+		{tx: before, want: ""},
+		{tx: otherToken, want: ""},
+		{tx: otherRecipient, want: ""},
+		{tx: approve, want: ""},
+	}
+	for _, tt := range tests {
+		if tt.tx.RelatedApprovalHash != tt.want {
+			t.Errorf("%s RelatedApprovalHash mismatch: got %q, want %q", tt.tx.Hash, tt.tx.RelatedApprovalHash, tt.want)
+		}
+	}
+}
+
+func TestLinkApprovalsPrefersLatest(t *testing.T) {
+	const (
+		owner  = "0x1111111111111111111111111111111111111111"
+		router = "0x7a250d5630b4cf539739df2c5dacb4c659f2488d"
+		token  = "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"
+	)
+	first := &models.Transaction{Hash: "0xfirst", Type: models.TypeEthTransfer, BlockNumber: 10, From: owner, To: token, Input: approveCalldata(strings.Repeat("0", 63) + "1")}
+	second := &models.Transaction{Hash: "0xsecond", Type: models.TypeEthTransfer, BlockNumber: 12, From: owner, To: token, Input: approveCalldata(strings.Repeat("0", 63) + "2")}
+	transfer := &models.Transaction{Hash: "0xswap", Type: models.TypeERC20Transfer, BlockNumber: 12, From: owner, To: router, AssetContractAddress: token}
+
+	LinkApprovals([]*models.Transaction{transfer, second, first})
+
+	if transfer.RelatedApprovalHash != "0xsecond" {
+		t.Errorf("RelatedApprovalHash mismatch: got %q, want %q", transfer.RelatedApprovalHash, "0xsecond")
+	}
+}
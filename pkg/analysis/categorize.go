@@ -0,0 +1,105 @@
+// Package analysis derives higher-level information from normalized transactions
+package analysis
+
+import (
+	"conintracker-hiring/pkg/models"
+	"strings"
+)
+
+// Transaction categories assigned by Categorize
+const (
+	CategoryApproval = "Approval"
+	CategorySwap     = "Swap"
+	CategoryTransfer = "Transfer"
+	CategoryMint     = "Mint"
+	CategoryBurn     = "Burn"
+	CategoryUnknown  = "Unknown"
+)
+
+// Well-known 4-byte function selectors
+const (
+	SelectorApprove           = "0x095ea7b3" // approve(address,uint256)
+	SelectorSetApprovalForAll = "0xa22cb465" // setApprovalForAll(address,bool)
+	SelectorTransfer          = "0xa9059cbb" // transfer(address,uint256)
+	SelectorTransferFrom      = "0x23b872dd" // transferFrom(address,address,uint256)
+	SelectorSafeTransferFrom  = "0x42842e0e" // safeTransferFrom(address,address,uint256)
+)
+
+// selectorCategories maps function selectors to categories
+var selectorCategories = map[string]string{
+	SelectorApprove:           CategoryApproval,
+	SelectorSetApprovalForAll: CategoryApproval,
+	SelectorTransfer:          CategoryTransfer,
+	SelectorTransferFrom:      CategoryTransfer,
+	SelectorSafeTransferFrom:  CategoryTransfer,
+	"0x38ed1739":              CategorySwap, // swapExactTokensForTokens
+	"0x8803dbee":              CategorySwap, // swapTokensForExactTokens
+	"0x7ff36ab5":              CategorySwap, // swapExactETHForTokens
+	"0x18cbafe5":              CategorySwap, // swapExactTokensForETH
+	"0xfb3bdb41":              CategorySwap, // swapETHForExactTokens
+	"0x4a25d94a":              CategorySwap, // swapTokensForExactETH
+	"0x414bf389":              CategorySwap, // exactInputSingle (Uniswap V3)
+	"0xc04b8d59":              CategorySwap, // exactInput (Uniswap V3)
+	"0x3593564c":              CategorySwap, // execute (Uniswap Universal Router)
+}
+
+// Categorize labels a transaction as Approval, Swap, Transfer, Mint, Burn, or Unknown.
+// Mint/burn subtypes win, then the called function (selector or name), then the
+// transfer pattern implied by the transaction type.
+func Categorize(tx *models.Transaction) string {
+	if tx == nil {
+		return CategoryUnknown
+	}
+
+	switch tx.Subtype {
+	case models.SubtypeMint:
+		return CategoryMint
+	case models.SubtypeBurn:
+		return CategoryBurn
+	}
+
+	if category, ok := selectorCategories[methodSelector(tx)]; ok {
+		return category
+	}
+
+	name := strings.ToLower(tx.FunctionName)
+	switch {
+	case strings.HasPrefix(name, "approve"), strings.HasPrefix(name, "setapprovalforall"):
+		return CategoryApproval
+	case strings.Contains(name, "swap"):
+		return CategorySwap
+	case strings.HasPrefix(name, "transfer"), strings.HasPrefix(name, "safetransfer"):
+		return CategoryTransfer
+	}
+
+	switch tx.Type {
+	case models.TypeERC20Transfer, models.TypeERC721Transfer, models.TypeERC1155Transfer,
+		models.TypeInternal, models.TypeBeaconWithdrawal:
+		return CategoryTransfer
+	case models.TypeEthTransfer:
+		// Plain ETH sends carry no calldata
+		if tx.Input == "" || tx.Input == "0x" {
+			return CategoryTransfer
+		}
+	}
+
+	return CategoryUnknown
+}
+
+// CategorizeAll sets Category on every transaction
+func CategorizeAll(txs []*models.Transaction) {
+	for _, tx := range txs {
+		if tx != nil {
+			tx.Category = Categorize(tx)
+		}
+	}
+}
+
+// methodSelector returns the lowercase 4-byte selector from MethodID or, failing that, Input
+func methodSelector(tx *models.Transaction) string {
+	selector := tx.MethodID
+	if selector == "" && len(tx.Input) >= 10 {
+		selector = tx.Input[:10]
+	}
+	return strings.ToLower(selector)
+}
@@ -0,0 +1,94 @@
+package analysis
+
+import (
+	"conintracker-hiring/pkg/models"
+	"testing"
+)
+
+func TestCategorize(t *testing.T) {
+	tests := []struct {
+		name string
+		tx   *models.Transaction
+		want string
+	}{
+		{
+			name: "approve_selector",
+			tx:   &models.Transaction{Type: models.TypeEthTransfer, MethodID: SelectorApprove, Input: "0x095ea7b3000000"},
+			want: CategoryApproval,
+		},
+		{
+			name: "approve_selector_from_input",
+			tx:   &models.Transaction{Type: models.TypeEthTransfer, Input: "0x095EA7B3000000"},
+			want: CategoryApproval,
+		},
+		{
+			name: "transfer_selector",
+			tx:   &models.Transaction{Type: models.TypeEthTransfer, MethodID: SelectorTransfer, Input: "0xa9059cbb000000"},
+			want: CategoryTransfer,
+		},
+		{
+			name: "transfer_from_selector",
+			tx:   &models.Transaction{Type: models.TypeEthTransfer, MethodID: SelectorTransferFrom},
+			want: CategoryTransfer,
+		},
+		{
+			name: "swap_by_function_name",
+			tx:   &models.Transaction{Type: models.TypeEthTransfer, MethodID: "0xdeadbeef", FunctionName: "swapExactTokensForETHSupportingFeeOnTransferTokens(uint256,uint256,address[],address,uint256)"},
+			want: CategorySwap,
+		},
+		{
+			name: "plain_eth_send",
+			tx:   &models.Transaction{Type: models.TypeEthTransfer, Input: "0x"},
+			want: CategoryTransfer,
+		},
+		{
+			name: "token_transfer",
+			tx:   &models.Transaction{Type: models.TypeERC20Transfer},
+			want: CategoryTransfer,
+		},
+		{
+			name: "mint",
+			tx:   &models.Transaction{Type: models.TypeERC721Transfer, Subtype: models.SubtypeMint},
+			want: CategoryMint,
+		},
+		{
+			name: "burn",
+			tx:   &models.Transaction{Type: models.TypeERC20Transfer, Subtype: models.SubtypeBurn},
+			want: CategoryBurn,
+		},
+		{
+			name: "unknown_contract_call",
+			tx:   &models.Transaction{Type: models.TypeEthTransfer, MethodID: "0xdeadbeef", Input: "0xdeadbeef00"},
+			want: CategoryUnknown,
+		},
+		{
+			name: "nil_transaction",
+			tx:   nil,
+			want: CategoryUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Categorize(tt.tx); got != tt.want {
+				t.Errorf("Categorize() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCategorizeAll(t *testing.T) {
+	txs := []*models.Transaction{
+		{Type: models.TypeEthTransfer, MethodID: SelectorApprove},
+		{Type: models.TypeERC20Transfer},
+	}
+
+	CategorizeAll(txs)
+
+	if txs[0].Category != CategoryApproval {
+		t.Errorf("Category mismatch: got %s, want %s", txs[0].Category, CategoryApproval)
+	}
+	if txs[1].Category != CategoryTransfer {
+		t.Errorf("Category mismatch: got %s, want %s", txs[1].Category, CategoryTransfer)
+	}
+}
@@ -0,0 +1,259 @@
+package analysis
+
+import (
+	"conintracker-hiring/pkg/models"
+	"conintracker-hiring/pkg/pricing"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Direction is which way a transaction moves its asset relative to an owner address
+type Direction int
+
+const (
+	DirectionNone Direction = iota // Owner is neither the sender nor the receiver
+	DirectionIn                    // Owner receives the asset
+	DirectionOut                   // Owner sends the asset
+	DirectionSelf                  // Owner sends the asset to itself
+)
+
+// String returns the direction's lowercase name
+func (d Direction) String() string {
+	switch d {
+	case DirectionIn:
+		return "in"
+	case DirectionOut:
+		return "out"
+	case DirectionSelf:
+		return "self"
+	default:
+		return "none"
+	}
+}
+
+// TransferDirection returns the direction of tx relative to owner, comparing
+// addresses case-insensitively
+func TransferDirection(tx *models.Transaction, owner string) Direction {
+	from := strings.EqualFold(tx.From, owner)
+	to := strings.EqualFold(tx.To, owner)
+	switch {
+	case from && to:
+		return DirectionSelf
+	case from:
+		return DirectionOut
+	case to:
+		return DirectionIn
+	default:
+		return DirectionNone
+	}
+}
+
+// LotMethod selects which open lots a disposal is matched against
+type LotMethod string
+
+const (
+	LotFIFO LotMethod = "fifo" // Oldest acquisitions are disposed of first
+	LotLIFO LotMethod = "lifo" // Newest acquisitions are disposed of first
+)
+
+// costBasisDecimals is the number of decimal places USD amounts are rounded to
+const costBasisDecimals = 2
+
+// maxQuantityDecimals bounds the decimal places of a lot quantity. Quantities are
+// split from exact token amounts, which never have more than 77 decimals.
+const maxQuantityDecimals = 77
+
+// RealizedLot is the part of a disposal matched against a single acquisition
+type RealizedLot struct {
+	Asset        string    // Symbol the asset is priced under
+	Contract     string    // Token contract address; empty for the native asset
+	Quantity     string    // Amount disposed of from the lot
+	Acquired     time.Time // Zero when the disposal exceeded the owner's holdings
+	AcquiredHash string
+	Disposed     time.Time
+	DisposedHash string
+	Proceeds     string // USD value of Quantity at disposal
+	CostBasis    string // USD value of Quantity at acquisition; empty when Acquired is zero
+	Gain         string // Proceeds minus CostBasis; empty when CostBasis is
+}
+
+// CostBasisReport is the realized gains of an owner's disposals
+type CostBasisReport struct {
+	Lots     []RealizedLot // Ordered by disposal time
+	Unpriced []string      // Assets left out because a transaction had no price
+}
+
+// openLot is the unsold remainder of an acquisition
+type openLot struct {
+	quantity *big.Rat
+	unitCost *big.Rat
+	acquired time.Time
+	hash     string
+}
+
+// assetHistory is one asset's transfers into and out of the owner's wallet
+type assetHistory struct {
+	symbol   string
+	contract string
+	txs      []*models.Transaction
+}
+
+// ComputeCostBasis matches owner's disposals against earlier acquisitions of the
+// same asset with method and values each match with prices at the time of both
+// transfers. Transfers in open lots at their price; transfers out close them.
+// Failed transactions, NFTs, self-transfers and unparseable amounts are skipped,
+// and gas fees are not added to the cost basis. An asset with any unpriced
+// transfer is left out of the lots and listed in Unpriced instead.
+func ComputeCostBasis(ctx context.Context, txs []*models.Transaction, owner string, prices pricing.PriceProvider, method LotMethod) (*CostBasisReport, error) {
+	if method != LotFIFO && method != LotLIFO {
+		return nil, fmt.Errorf("unknown lot method %q (expected %s or %s)", method, LotFIFO, LotLIFO)
+	}
+
+	sorted := make([]*models.Transaction, 0, len(txs))
+	for _, tx := range txs {
+		if tx != nil && !tx.IsError {
+			sorted = append(sorted, tx)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp.Before(sorted[j].Timestamp)
+	})
+
+	var order []string
+	histories := make(map[string]*assetHistory)
+	for _, tx := range sorted {
+		symbol := pricing.PriceSymbol(tx)
+		if symbol == "" {
+			continue
+		}
+		if dir := TransferDirection(tx, owner); dir != DirectionIn && dir != DirectionOut {
+			continue
+		}
+
+		key := strings.ToLower(tx.AssetContractAddress)
+		if key == "" {
+			key = symbol
+		}
+		history, ok := histories[key]
+		if !ok {
+			history = &assetHistory{symbol: symbol, contract: strings.ToLower(tx.AssetContractAddress)}
+			histories[key] = history
+			order = append(order, key)
+		}
+		history.txs = append(history.txs, tx)
+	}
+
+	report := &CostBasisReport{}
+	for _, key := range order {
+		history := histories[key]
+		lots, err := matchLots(ctx, history, owner, prices, method)
+		if errors.Is(err, pricing.ErrPriceNotFound) {
+			report.Unpriced = append(report.Unpriced, history.symbol)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		report.Lots = append(report.Lots, lots...)
+	}
+
+	sort.SliceStable(report.Lots, func(i, j int) bool {
+		return report.Lots[i].Disposed.Before(report.Lots[j].Disposed)
+	})
+	return report, nil
+}
+
+// matchLots replays one asset's transfers in order, returning a lot for every
+// match between a disposal and an acquisition
+func matchLots(ctx context.Context, history *assetHistory, owner string, prices pricing.PriceProvider, method LotMethod) ([]RealizedLot, error) {
+	var open []*openLot
+	var realized []RealizedLot
+
+	for _, tx := range history.txs {
+		amount, err := models.ParseAmount(tx)
+		if err != nil || amount.Sign() <= 0 {
+			continue
+		}
+
+		price, err := prices.AssetPriceAt(ctx, history.symbol, tx.Timestamp)
+		if err != nil {
+			if errors.Is(err, pricing.ErrPriceNotFound) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("failed to price %s at %s: %w", history.symbol, tx.Timestamp.Format(time.RFC3339), err)
+		}
+		rate := new(big.Rat)
+		if rate.SetFloat64(price) == nil {
+			return nil, pricing.ErrPriceNotFound // NaN or infinite price
+		}
+
+		if TransferDirection(tx, owner) == DirectionIn {
+			open = append(open, &openLot{quantity: amount, unitCost: rate, acquired: tx.Timestamp, hash: tx.Hash})
+			continue
+		}
+
+		remaining := amount
+		for remaining.Sign() > 0 && len(open) > 0 {
+			idx := 0
+			if method == LotLIFO {
+				idx = len(open) - 1
+			}
+			lot := open[idx]
+
+			quantity := new(big.Rat).Set(remaining)
+			if lot.quantity.Cmp(quantity) < 0 {
+				quantity.Set(lot.quantity)
+			}
+			proceeds := roundUSD(new(big.Rat).Mul(quantity, rate))
+			cost := roundUSD(new(big.Rat).Mul(quantity, lot.unitCost))
+
+			realized = append(realized, RealizedLot{
+				Asset:        history.symbol,
+				Contract:     history.contract,
+				Quantity:     formatQuantity(quantity),
+				Acquired:     lot.acquired,
+				AcquiredHash: lot.hash,
+				Disposed:     tx.Timestamp,
+				DisposedHash: tx.Hash,
+				Proceeds:     proceeds.FloatString(costBasisDecimals),
+				CostBasis:    cost.FloatString(costBasisDecimals),
+				Gain:         new(big.Rat).Sub(proceeds, cost).FloatString(costBasisDecimals),
+			})
+
+			lot.quantity = new(big.Rat).Sub(lot.quantity, quantity)
+			remaining = new(big.Rat).Sub(remaining, quantity)
+			if lot.quantity.Sign() == 0 {
+				open = append(open[:idx], open[idx+1:]...)
+			}
+		}
+
+		if remaining.Sign() > 0 {
+			realized = append(realized, RealizedLot{
+				Asset:        history.symbol,
+				Contract:     history.contract,
+				Quantity:     formatQuantity(remaining),
+				Disposed:     tx.Timestamp,
+				DisposedHash: tx.Hash,
+				Proceeds:     roundUSD(new(big.Rat).Mul(remaining, rate)).FloatString(costBasisDecimals),
+			})
+		}
+	}
+	return realized, nil
+}
+
+// roundUSD rounds r to whole cents so a lot's gain matches its printed amounts
+func roundUSD(r *big.Rat) *big.Rat {
+	rounded, _ := new(big.Rat).SetString(r.FloatString(costBasisDecimals))
+	return rounded
+}
+
+// formatQuantity renders r as a plain decimal without trailing zeros
+func formatQuantity(r *big.Rat) string {
+	s := r.FloatString(maxQuantityDecimals)
+	return strings.TrimSuffix(strings.TrimRight(s, "0"), ".")
+}
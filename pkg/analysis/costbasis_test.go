@@ -0,0 +1,173 @@
+package analysis
+
+import (
+	"conintracker-hiring/pkg/models"
+	"conintracker-hiring/pkg/pricing"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+const costBasisOwner = "0xOwner"
+
+// datedPrices returns a fixed price per symbol and day
+type datedPrices map[string]float64
+
+func (p datedPrices) AssetPriceAt(ctx context.Context, symbol string, t time.Time) (float64, error) {
+	price, ok := p[symbol+"@"+t.UTC().Format("2006-01-02")]
+	if !ok {
+		return 0, pricing.ErrPriceNotFound
+	}
+	return price, nil
+}
+
+func day(d int) time.Time {
+	return time.Date(2024, time.January, d, 12, 0, 0, 0, time.UTC)
+}
+
+// ethTransfer moves amount ETH to or from the owner on day d
+func ethTransfer(hash string, d int, amount string, in bool) *models.Transaction {
+	tx := &models.Transaction{Hash: hash, Type: models.TypeEthTransfer, Timestamp: day(d), Amount: amount, From: costBasisOwner, To: "0xmarket"}
+	if in {
+		tx.From, tx.To = "0xmarket", costBasisOwner
+	}
+	return tx
+}
+
+var buySellPrices = datedPrices{
+	"ETH@2024-01-01": 1000,
+	"ETH@2024-01-02": 2000,
+	"ETH@2024-01-03": 3000,
+}
+
+// buySellHistory buys 1 ETH at $1000, 1 ETH at $2000 and sells 1.5 ETH at $3000,
+// listed out of order to exercise sorting
+func buySellHistory() []*models.Transaction {
+	return []*models.Transaction{
+		ethTransfer("0xsell", 3, "1.5", false),
+		ethTransfer("0xbuy1", 1, "1", true),
+		ethTransfer("0xbuy2", 2, "1", true),
+	}
+}
+
+func TestComputeCostBasisFIFO(t *testing.T) {
+	report, err := ComputeCostBasis(context.Background(), buySellHistory(), "0xowner", buySellPrices, LotFIFO)
+	if err != nil {
+		t.Fatalf("ComputeCostBasis() error = %v", err)
+	}
+
+	want := []RealizedLot{
+		{Asset: "ETH", Quantity: "1", Acquired: day(1), AcquiredHash: "0xbuy1", Disposed: day(3), DisposedHash: "0xsell", Proceeds: "3000.00", CostBasis: "1000.00", Gain: "2000.00"},
+		{Asset: "ETH", Quantity: "0.5", Acquired: day(2), AcquiredHash: "0xbuy2", Disposed: day(3), DisposedHash: "0xsell", Proceeds: "1500.00", CostBasis: "1000.00", Gain: "500.00"},
+	}
+	if len(report.Lots) != len(want) {
+		t.Fatalf("Lot count mismatch: got %d, want %d: %+v", len(report.Lots), len(want), report.Lots)
+	}
+	for i := range want {
+		if report.Lots[i] != want[i] {
+			t.Errorf("Lot %d mismatch: got %+v, want %+v", i, report.Lots[i], want[i])
+		}
+	}
+}
+
+func TestComputeCostBasisLIFO(t *testing.T) {
+	report, err := ComputeCostBasis(context.Background(), buySellHistory(), costBasisOwner, buySellPrices, LotLIFO)
+	if err != nil {
+		t.Fatalf("ComputeCostBasis() error = %v", err)
+	}
+
+	tests := []struct {
+		acquiredHash, quantity, costBasis, gain string
+	}{
+		{"0xbuy2", "1", "2000.00", "1000.00"},
+		{"0xbuy1", "0.5", "500.00", "1000.00"},
+	}
+	if len(report.Lots) != len(tests) {
+		t.Fatalf("Lot count mismatch: got %d, want %d", len(report.Lots), len(tests))
+	}
+	for i, tt := range tests {
+		lot := report.Lots[i]
+		if lot.AcquiredHash != tt.acquiredHash || lot.Quantity != tt.quantity || lot.CostBasis != tt.costBasis || lot.Gain != tt.gain {
+			t.Errorf("Lot %d mismatch: got %s %s basis %s gain %s, want %s %s basis %s gain %s",
+				i, lot.AcquiredHash, lot.Quantity, lot.CostBasis, lot.Gain, tt.acquiredHash, tt.quantity, tt.costBasis, tt.gain)
+		}
+	}
+}
+
+func TestComputeCostBasisDisposalExceedsHoldings(t *testing.T) {
+	txs := []*models.Transaction{
+		ethTransfer("0xbuy1", 1, "1", true),
+		ethTransfer("0xsell", 3, "2", false),
+	}
+
+	report, err := ComputeCostBasis(context.Background(), txs, costBasisOwner, buySellPrices, LotFIFO)
+	if err != nil {
+		t.Fatalf("ComputeCostBasis() error = %v", err)
+	}
+	if len(report.Lots) != 2 {
+		t.Fatalf("Lot count mismatch: got %d, want 2", len(report.Lots))
+	}
+	unmatched := report.Lots[1]
+	if !unmatched.Acquired.IsZero() || unmatched.Quantity != "1" || unmatched.Proceeds != "3000.00" || unmatched.CostBasis != "" || unmatched.Gain != "" {
+		t.Errorf("Unmatched lot mismatch: got %+v", unmatched)
+	}
+}
+
+func TestComputeCostBasisSkipsAndUnpriced(t *testing.T) {
+	failed := ethTransfer("0xfailed", 2, "5", false)
+	failed.IsError = true
+	txs := append(buySellHistory(),
+		failed,
+		&models.Transaction{Hash: "0xself", Type: models.TypeEthTransfer, Timestamp: day(2), Amount: "1", From: costBasisOwner, To: costBasisOwner},
+		&models.Transaction{Hash: "0xnft", Type: models.TypeERC721Transfer, Timestamp: day(2), Amount: "1", From: costBasisOwner, To: "0xmarket"},
+		&models.Transaction{Hash: "0xtok", Type: models.TypeERC20Transfer, AssetSymbol: "FOO", AssetContractAddress: "0xF00", Timestamp: day(1), Amount: "10", From: "0xmarket", To: costBasisOwner},
+	)
+
+	report, err := ComputeCostBasis(context.Background(), txs, costBasisOwner, buySellPrices, LotFIFO)
+	if err != nil {
+		t.Fatalf("ComputeCostBasis() error = %v", err)
+	}
+	if len(report.Lots) != 2 {
+		t.Errorf("Lot count mismatch: got %d, want 2", len(report.Lots))
+	}
+	if len(report.Unpriced) != 1 || report.Unpriced[0] != "FOO" {
+		t.Errorf("Unpriced mismatch: got %v, want [FOO]", report.Unpriced)
+	}
+}
+
+func TestComputeCostBasisErrors(t *testing.T) {
+	if _, err := ComputeCostBasis(context.Background(), nil, costBasisOwner, buySellPrices, "hifo"); err == nil {
+		t.Error("Expected an error for an unknown lot method")
+	}
+
+	errPrices := errors.New("price service down")
+	_, err := ComputeCostBasis(context.Background(), buySellHistory(), costBasisOwner, failingPrices{errPrices}, LotFIFO)
+	if !errors.Is(err, errPrices) {
+		t.Errorf("Expected the price provider's error, got %v", err)
+	}
+}
+
+type failingPrices struct{ err error }
+
+func (p failingPrices) AssetPriceAt(ctx context.Context, symbol string, t time.Time) (float64, error) {
+	return 0, p.err
+}
+
+func TestTransferDirection(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     Direction
+	}{
+		{"0xOWNER", "0xother", DirectionOut},
+		{"0xother", "0xowner", DirectionIn},
+		{"0xowner", "0xOwner", DirectionSelf},
+		{"0xother", "0xmarket", DirectionNone},
+	}
+	for _, tt := range tests {
+		got := TransferDirection(&models.Transaction{From: tt.from, To: tt.to}, costBasisOwner)
+		if got != tt.want {
+			t.Errorf("TransferDirection(%s -> %s) mismatch: got %s, want %s", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
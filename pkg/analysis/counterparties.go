@@ -0,0 +1,26 @@
+package analysis
+
+import (
+	"conintracker-hiring/pkg/models"
+	"strings"
+)
+
+// UniqueCounterparties counts the distinct addresses other than owner that appear
+// as From or To in txs. Addresses compare case-insensitively; empty ones (withdrawal
+// senders, contract creation recipients) are not counted.
+func UniqueCounterparties(txs []*models.Transaction, owner string) int {
+	owner = strings.ToLower(owner)
+	seen := make(map[string]bool)
+	for _, tx := range txs {
+		if tx == nil {
+			continue
+		}
+		for _, addr := range []string{tx.From, tx.To} {
+			addr = strings.ToLower(addr)
+			if addr != "" && addr != owner {
+				seen[addr] = true
+			}
+		}
+	}
+	return len(seen)
+}
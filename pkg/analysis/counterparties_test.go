@@ -0,0 +1,43 @@
+package analysis
+
+import (
+	"conintracker-hiring/pkg/models"
+	"testing"
+)
+
+func TestUniqueCounterparties(t *testing.T) {
+	owner := "0xA39b189482f984388a34460636fea9eb181ad1a6"
+	alice := "0x1111111111111111111111111111111111111111"
+	bob := "0x2222222222222222222222222222222222222222"
+	router := "0xAbCdEf0000000000000000000000000000000003"
+
+	txs := []*models.Transaction{
+		{From: owner, To: alice},
+		{From: alice, To: owner}, // Same counterparty in the other direction
+		{From: "0xa39b189482f984388a34460636fea9eb181ad1a6", To: bob}, // Owner in a different case
+		{From: owner, To: router},
+		{From: owner, To: "0xabcdef0000000000000000000000000000000003"}, // Router in a different case
+		{From: "", To: owner},                                           // Withdrawal
+		{From: owner, To: ""},                                           // Contract creation
+		nil,
+	}
+
+	tests := []struct {
+		name  string
+		txs   []*models.Transaction
+		owner string
+		want  int
+	}{
+		{name: "overlapping_counterparties", txs: txs, owner: owner, want: 3},
+		{name: "owner_counted_when_not_owner", txs: txs, owner: alice, want: 3},
+		{name: "no_transactions", txs: nil, owner: owner, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := UniqueCounterparties(tt.txs, tt.owner); got != tt.want {
+				t.Errorf("UniqueCounterparties() mismatch: got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
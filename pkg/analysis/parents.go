@@ -0,0 +1,34 @@
+package analysis
+
+import (
+	"conintracker-hiring/pkg/models"
+	"strings"
+)
+
+// LinkInternalParents sets ParentHash and ParentFunction on each internal transfer
+// whose parent normal transaction (same hash) is among txs. The parent's function
+// name is preferred, falling back to its selector. Internal transfers without a
+// parent in txs are left unchanged.
+func LinkInternalParents(txs []*models.Transaction) {
+	parents := make(map[string]*models.Transaction)
+	for _, tx := range txs {
+		if tx != nil && tx.Type == models.TypeEthTransfer && tx.Hash != "" {
+			parents[strings.ToLower(tx.Hash)] = tx
+		}
+	}
+
+	for _, tx := range txs {
+		if tx == nil || tx.Type != models.TypeInternal {
+			continue
+		}
+		parent, ok := parents[strings.ToLower(tx.Hash)]
+		if !ok {
+			continue
+		}
+		tx.ParentHash = parent.Hash
+		tx.ParentFunction = parent.FunctionName
+		if tx.ParentFunction == "" {
+			tx.ParentFunction = methodSelector(parent)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package analysis
+
+import (
+	"conintracker-hiring/pkg/models"
+	"testing"
+)
+
+func TestLinkInternalParents(t *testing.T) {
+	parent := &models.Transaction{
+		Hash:         "0xAAA",
+		Type:         models.TypeEthTransfer,
+		Amount:       "1",
+		MethodID:     "0x7ff36ab5",
+		FunctionName: "swapExactETHForTokens(uint256,address[],address,uint256)",
+	}
+	selectorOnly := &models.Transaction{Hash: "0xbbb", Type: models.TypeEthTransfer, MethodID: "0x18CBAFE5"}
+	internal := &models.Transaction{Hash: "0xaaa", Type: models.TypeInternal, Amount: "0.5"}
+	internalBySelector := &models.Transaction{Hash: "0xbbb", Type: models.TypeInternal}
+	orphan := &models.Transaction{Hash: "0xccc", Type: models.TypeInternal}
+	token := &models.Transaction{Hash: "0xaaa", Type: models.TypeERC20Transfer}
+
+	LinkInternalParents([]*models.Transaction{internal, parent, selectorOnly, internalBySelector, orphan, token, nil})
+
+	tests := []struct {
+		name         string
+		tx           *models.Transaction
+		wantHash     string
+		wantFunction string
+	}{
+		{"function_name", internal, "0xAAA", parent.FunctionName},
+		{"selector_fallback", internalBySelector, "0xbbb", "0x18cbafe5"},
+		{"no_parent_in_export", orphan, "", ""},
+		{"token_transfer_not_linked", token, "", ""},
+		{"parent_not_linked", parent, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.tx.ParentHash != tt.wantHash {
+				t.Errorf("ParentHash mismatch: got %s, want %s", tt.tx.ParentHash, tt.wantHash)
+			}
+			if tt.tx.ParentFunction != tt.wantFunction {
+				t.Errorf("ParentFunction mismatch: got %s, want %s", tt.tx.ParentFunction, tt.wantFunction)
+			}
+		})
+	}
+}
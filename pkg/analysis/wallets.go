@@ -0,0 +1,47 @@
+package analysis
+
+import (
+	"conintracker-hiring/pkg/models"
+	"strings"
+)
+
+// SelectorExecTransaction is the Gnosis Safe execTransaction selector:
+// execTransaction(address,uint256,bytes,uint8,uint256,uint256,uint256,address,address,bytes)
+const SelectorExecTransaction = "0x6a761202"
+
+// walletSelectors maps the selectors of smart contract wallet entry points to the
+// subtype given to normal transactions calling them
+var walletSelectors = map[string]string{
+	SelectorExecTransaction: models.SubtypeSafeExecution,
+}
+
+// RegisterWalletSelector makes LabelWalletExecutions give subtype to normal
+// transactions calling selector (e.g. another multisig's execute function),
+// replacing any earlier subtype for it. It is not safe for concurrent use and is
+// meant to be called during initialization.
+func RegisterWalletSelector(selector, subtype string) {
+	walletSelectors[strings.ToLower(selector)] = subtype
+}
+
+// WalletSubtype returns the subtype for a normal transaction that calls a
+// registered wallet selector, or "" for any other transaction
+func WalletSubtype(tx *models.Transaction) string {
+	if tx == nil || tx.Type != models.TypeEthTransfer {
+		return ""
+	}
+	return walletSelectors[methodSelector(tx)]
+}
+
+// LabelWalletExecutions sets Subtype on normal transactions that call a registered
+// wallet selector, such as a Safe execTransaction. The base type is unchanged, and
+// transactions that already have a subtype are left alone.
+func LabelWalletExecutions(txs []*models.Transaction) {
+	for _, tx := range txs {
+		if tx == nil || tx.Subtype != "" {
+			continue
+		}
+		if subtype := WalletSubtype(tx); subtype != "" {
+			tx.Subtype = subtype
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package analysis
+
+import (
+	"conintracker-hiring/pkg/models"
+	"strings"
+	"testing"
+)
+
+// word left-pads hex to a 32-byte ABI word
+func word(hex string) string {
+	return strings.Repeat("0", 64-len(hex)) + hex
+}
+
+func TestLabelWalletExecutions(t *testing.T) {
+	// execTransaction(to, value, data, operation, safeTxGas, baseGas, gasPrice,
+	// gasToken, refundReceiver, signatures) sending 1 ETH with empty data
+	safeCalldata := SelectorExecTransaction +
+		word("d620aadabaa20d2af700853c4504028cba7c3333") + // to
+		word("de0b6b3a7640000") + // value: 1 ETH
+		word("140") + // data offset
+		word("0") + word("0") + word("0") + word("0") + // operation, safeTxGas, baseGas, gasPrice
+		word("0") + word("0") + // gasToken, refundReceiver
+		word("160") + // signatures offset
+		word("0") + // data length
+		word("0") // signatures length
+
+	tests := []struct {
+		name string
+		tx   *models.Transaction
+		want string
+	}{
+		{
+			name: "safe_exec_transaction",
+			tx:   &models.Transaction{Type: models.TypeEthTransfer, Input: safeCalldata},
+			want: models.SubtypeSafeExecution,
+		},
+		{
+			name: "method_id_uppercase",
+			tx:   &models.Transaction{Type: models.TypeEthTransfer, MethodID: "0x6A761202"},
+			want: models.SubtypeSafeExecution,
+		},
+		{
+			name: "plain_transfer",
+			tx:   &models.Transaction{Type: models.TypeEthTransfer, Input: "0x"},
+			want: "",
+		},
+		{
+			name: "token_transfer_keeps_base_labeling",
+			tx:   &models.Transaction{Type: models.TypeERC20Transfer, Input: safeCalldata},
+			want: "",
+		},
+		{
+			name: "existing_subtype_kept",
+			tx:   &models.Transaction{Type: models.TypeEthTransfer, Input: safeCalldata, Subtype: models.SubtypeMint},
+			want: models.SubtypeMint,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			LabelWalletExecutions([]*models.Transaction{tt.tx})
+			if tt.tx.Subtype != tt.want {
+				t.Errorf("Subtype mismatch: got %q, want %q", tt.tx.Subtype, tt.want)
+			}
+			if tt.want == models.SubtypeSafeExecution && tt.tx.Type != models.TypeEthTransfer {
+				t.Errorf("Type mismatch: got %s, want %s", tt.tx.Type, models.TypeEthTransfer)
+			}
+		})
+	}
+}
+
+func TestRegisterWalletSelector(t *testing.T) {
+	const selector = "0x12345678"
+	RegisterWalletSelector("0x12345678", "Custom Wallet")
+	defer delete(walletSelectors, selector)
+
+	tx := &models.Transaction{Type: models.TypeEthTransfer, Input: selector + word("1")}
+	LabelWalletExecutions([]*models.Transaction{tx})
+	if tx.Subtype != "Custom Wallet" {
+		t.Errorf("Subtype mismatch: got %q, want Custom Wallet", tx.Subtype)
+	}
+}
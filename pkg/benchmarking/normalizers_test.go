@@ -0,0 +1,56 @@
+package benchmarking
+
+import (
+	"conintracker-hiring/pkg/providers"
+	"testing"
+)
+
+// TestNormalizerPrecision checks that the normalizer keeps wei-exact amounts rather
+// than rounding through float64 to ~17 significant digits
+func TestNormalizerPrecision(t *testing.T) {
+	normalTx := providers.EtherscanNormalTx{
+		Hash:      "0x1",
+		TimeStamp: "1700000000",
+		From:      "0xfrom",
+		To:        "0xto",
+		Value:     "123456789012345678901", // 123.456789012345678901 ETH
+		GasUsed:   "21000",
+		GasPrice:  "20000000001",
+	}
+	tokenTx := providers.EtherscanTokenTx{
+		Hash:         "0x2",
+		TimeStamp:    "1700000000",
+		Value:        "1234567890123456789012345678",
+		TokenDecimal: "24",
+		TokenSymbol:  "BIG",
+		GasUsed:      "65000",
+		GasPrice:     "20000000000",
+	}
+
+	normal, err := providers.NewEtherscanNormalizer().NormalizeNormalTx(normalTx)
+	if err != nil {
+		t.Fatalf("NormalizeNormalTx() error = %v", err)
+	}
+	token, err := providers.NewEtherscanNormalizer().NormalizeERC20Tx(tokenTx)
+	if err != nil {
+		t.Fatalf("NormalizeERC20Tx() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{name: "eth_amount", got: normal.Amount, want: "123.456789012345678901"},
+		{name: "gas_fee", got: normal.GasFeeETH, want: "0.000420000000021"},
+		{name: "token_amount", got: token.Amount, want: "1234.567890123456789012345678"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("Precision mismatch: got %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+}
@@ -225,7 +225,7 @@ func BenchmarkRegressionParallel(b *testing.B) {
 	})
 }
 
-// mockFetcher wraps BenchmarkMockFetcher for testing
-func newMockFetcher(fixtures *providers.BenchmarkFixtures) *providers.BenchmarkMockFetcher {
-	return providers.NewBenchmarkMockFetcher(fixtures)
+// mockFetcher wraps NewConfigurableProvider for testing
+func newMockFetcher(fixtures *providers.BenchmarkFixtures) *providers.ConfigurableProvider {
+	return providers.NewConfigurableProvider(fixtures)
 }
@@ -0,0 +1,142 @@
+// Package cointracker is the library entry point for exporting wallet
+// transactions without going through the CLI or the filesystem
+package cointracker
+
+import (
+	"bytes"
+	"conintracker-hiring/pkg/analysis"
+	"conintracker-hiring/pkg/output"
+	"conintracker-hiring/pkg/pricing"
+	"conintracker-hiring/pkg/providers"
+	"context"
+	"fmt"
+	"time"
+)
+
+// Format is an export encoding
+type Format string
+
+// Supported export formats
+const (
+	FormatCSV Format = "csv"
+)
+
+// ExportRequest describes a single-address export. Only Address and either
+// Provider or APIKey are required; everything else has CLI-equivalent defaults.
+type ExportRequest struct {
+	Address string
+
+	// Provider supplies raw transactions. When nil, an Etherscan client is built from APIKey.
+	Provider providers.Provider
+	APIKey   string
+
+	// Normalizer defaults to providers.NewEtherscanNormalizer()
+	Normalizer providers.Normalizer
+
+	// StartPage and EndPage select the pages to fetch (both default to 1)
+	StartPage int
+	EndPage   int
+
+	// Format defaults to FormatCSV
+	Format Format
+
+	// Columns are optional output columns by name (see output.AvailableColumns)
+	Columns []string
+
+	// Prices, when set, fills the value-usd column from historical asset prices
+	Prices pricing.PriceProvider
+
+	// Location is the time zone timestamps are rendered in (defaults to UTC)
+	Location *time.Location
+
+	// OmitHeader skips the header row
+	OmitHeader bool
+}
+
+// Export fetches, normalizes, and encodes an address's transactions, returning the encoded bytes
+func Export(ctx context.Context, req ExportRequest) ([]byte, error) {
+	if req.Address == "" {
+		return nil, fmt.Errorf("address is required")
+	}
+
+	provider := req.Provider
+	if provider == nil {
+		if req.APIKey == "" {
+			return nil, fmt.Errorf("either a provider or an API key is required")
+		}
+		provider = providers.NewEtherscanClient(providers.ClientConfig{APIKey: req.APIKey})
+	}
+
+	normalizer := req.Normalizer
+	if normalizer == nil {
+		normalizer = providers.NewEtherscanNormalizer()
+	}
+
+	startPage, endPage := req.StartPage, req.EndPage
+	if startPage < 1 {
+		startPage = 1
+	}
+	if endPage < startPage {
+		endPage = startPage
+	}
+
+	format := req.Format
+	if format == "" {
+		format = FormatCSV
+	}
+	if format != FormatCSV {
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+
+	columns, err := output.LookupColumns(req.Columns)
+	if err != nil {
+		return nil, err
+	}
+
+	fetcher := providers.NewTransactionFetcher(provider, normalizer)
+	txs, err := fetcher.FetchAllTransactions(ctx, req.Address, startPage, endPage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch transactions: %w", err)
+	}
+	analysis.LabelWalletExecutions(txs)
+	analysis.CategorizeAll(txs)
+	analysis.LinkInternalParents(txs)
+	analysis.LinkApprovals(txs)
+	analysis.FlagUnlimitedApprovals(txs)
+
+	if req.Prices != nil {
+		if err := pricing.ApplyValueUSD(ctx, req.Prices, txs); err != nil {
+			return nil, fmt.Errorf("failed to value transactions: %w", err)
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	writer, err := output.NewCSVWriter(output.CSVConfig{
+		Writer:     nopCloser{buf},
+		OmitHeader: req.OmitHeader,
+		Location:   req.Location,
+		Columns:    columns,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CSV writer: %w", err)
+	}
+
+	if err := writer.WriteTransactions(txs); err != nil {
+		return nil, fmt.Errorf("failed to write transactions: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close CSV writer: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// nopCloser adapts a buffer to the io.WriteCloser the CSV writer expects
+type nopCloser struct {
+	*bytes.Buffer
+}
+
+// Close implements io.Closer
+func (nopCloser) Close() error {
+	return nil
+}
@@ -0,0 +1,102 @@
+package cointracker
+
+import (
+	"bytes"
+	"conintracker-hiring/pkg/providers"
+	"context"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExportCSV(t *testing.T) {
+	fixtures := providers.GetSmallFixture()
+
+	data, err := Export(context.Background(), ExportRequest{
+		Address:  "0x1234567890123456789012345678901234567890",
+		Provider: providers.NewConfigurableProvider(fixtures),
+		Columns:  []string{"category"},
+	})
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	want := len(fixtures.NormalTxs) * 5 // One of each fixture type per index
+	if len(lines) != want+1 {
+		t.Fatalf("Expected header and %d rows, got %d lines", want, len(lines))
+	}
+	if !strings.HasPrefix(lines[0], "Transaction Hash,") || !strings.HasSuffix(lines[0], ",Category") {
+		t.Errorf("Unexpected header: %s", lines[0])
+	}
+
+	firstHash := fixtures.NormalTxs[0].Hash
+	if !strings.Contains(string(data), firstHash+",") {
+		t.Errorf("Expected row for %s in output", firstHash)
+	}
+	if !strings.Contains(string(data), ",ETH,") {
+		t.Error("Expected an ETH row in output")
+	}
+}
+
+func TestExportValidatesRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		req  ExportRequest
+	}{
+		{name: "missing_address", req: ExportRequest{APIKey: "key"}},
+		{name: "missing_provider_and_key", req: ExportRequest{Address: "0xabc"}},
+		{name: "unsupported_format", req: ExportRequest{Address: "0xabc", APIKey: "key", Format: "xml"}},
+		{name: "unknown_column", req: ExportRequest{Address: "0xabc", APIKey: "key", Columns: []string{"nope"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Export(context.Background(), tt.req); err == nil {
+				t.Error("Expected error, got none")
+			}
+		})
+	}
+}
+
+// flatPrices prices every asset at the same USD rate
+type flatPrices float64
+
+func (p flatPrices) AssetPriceAt(ctx context.Context, symbol string, t time.Time) (float64, error) {
+	return float64(p), nil
+}
+
+func TestExportWithPrices(t *testing.T) {
+	fixtures := providers.NewBenchmarkFixtures(1)
+
+	data, err := Export(context.Background(), ExportRequest{
+		Address:  "0x1234567890123456789012345678901234567890",
+		Provider: providers.NewConfigurableProvider(fixtures),
+		Columns:  []string{"value-usd"},
+		Prices:   flatPrices(2000),
+	})
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+
+	// One fixture of each type: 1 ETH, 0.5 ETH internal, 1 USDC, then two NFTs
+	want := map[string]string{
+		"ETH":      "2000.00",
+		"Internal": "1000.00",
+		"ERC-20":   "2000.00",
+		"ERC-721":  "",
+		"ERC-1155": "",
+	}
+	for _, record := range records[1:] {
+		txType, value := record[4], record[len(record)-1]
+		if value != want[txType] {
+			t.Errorf("Value (USD) mismatch for %s: got %q, want %q", txType, value, want[txType])
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// Package filter narrows a set of normalized transactions before export
+package filter
+
+import (
+	"conintracker-hiring/pkg/analysis"
+	"conintracker-hiring/pkg/models"
+	"math/big"
+	"math/rand/v2"
+	"slices"
+	"strings"
+)
+
+// OnlyParty returns the transactions where owner is the sender or receiver,
+// dropping rows where it only appears incidentally (e.g. deep in an internal
+// trace). Addresses are compared case-insensitively; order is preserved.
+func OnlyParty(txs []*models.Transaction, owner string) []*models.Transaction {
+	kept := make([]*models.Transaction, 0, len(txs))
+	for _, tx := range txs {
+		if strings.EqualFold(tx.From, owner) || strings.EqualFold(tx.To, owner) {
+			kept = append(kept, tx)
+		}
+	}
+	return kept
+}
+
+// ApprovalsOnly returns the transactions categorized as approvals (see
+// analysis.Categorize, which must have run first); order is preserved
+func ApprovalsOnly(txs []*models.Transaction) []*models.Transaction {
+	kept := make([]*models.Transaction, 0, len(txs))
+	for _, tx := range txs {
+		if tx.Category == analysis.CategoryApproval {
+			kept = append(kept, tx)
+		}
+	}
+	return kept
+}
+
+// FilterByAmount returns the transactions whose Amount lies within [min, max].
+// Either bound may be nil to leave that side open. Amounts are compared as
+// per-row magnitudes in each row's own asset units (ETH, USDC, ...), not by value,
+// so a 500 USDC transfer passes a 100 minimum while a 0.5 ETH transfer does not.
+// Rows whose amount cannot be parsed are dropped; order is preserved.
+func FilterByAmount(txs []*models.Transaction, min, max *big.Rat) []*models.Transaction {
+	kept := make([]*models.Transaction, 0, len(txs))
+	for _, tx := range txs {
+		amount, err := models.ParseAmount(tx)
+		if err != nil {
+			continue
+		}
+		if min != nil && amount.Cmp(min) < 0 {
+			continue
+		}
+		if max != nil && amount.Cmp(max) > 0 {
+			continue
+		}
+		kept = append(kept, tx)
+	}
+	return kept
+}
+
+// Sample returns n transactions chosen uniformly at random, in their original order.
+// The same seed always picks the same rows from the same input. When n is not
+// smaller than len(txs), every transaction is returned.
+func Sample(txs []*models.Transaction, n int, seed uint64) []*models.Transaction {
+	if n < 0 {
+		n = 0
+	}
+	if n >= len(txs) {
+		return txs
+	}
+
+	rng := rand.New(rand.NewPCG(seed, seed))
+	picked := rng.Perm(len(txs))[:n]
+	slices.Sort(picked)
+
+	kept := make([]*models.Transaction, 0, n)
+	for _, i := range picked {
+		kept = append(kept, txs[i])
+	}
+	return kept
+}
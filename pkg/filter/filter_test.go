@@ -0,0 +1,142 @@
+package filter
+
+import (
+	"conintracker-hiring/pkg/analysis"
+	"conintracker-hiring/pkg/models"
+	"fmt"
+	"math/big"
+	"slices"
+	"testing"
+)
+
+func TestApprovalsOnly(t *testing.T) {
+	txs := []*models.Transaction{
+		{Hash: "0x1", Category: analysis.CategoryTransfer},
+		{Hash: "0x2", Category: analysis.CategoryApproval, UnlimitedApproval: true},
+		{Hash: "0x3", Category: analysis.CategorySwap},
+		{Hash: "0x4", Category: analysis.CategoryApproval},
+	}
+
+	kept := ApprovalsOnly(txs)
+
+	want := []string{"0x2", "0x4"}
+	if len(kept) != len(want) {
+		t.Fatalf("Expected %d transactions, got %d", len(want), len(kept))
+	}
+	for i, tx := range kept {
+		if tx.Hash != want[i] {
+			t.Errorf("Transaction %d mismatch: got %s, want %s", i, tx.Hash, want[i])
+		}
+	}
+}
+
+func TestOnlyParty(t *testing.T) {
+	owner := "0xa39b189482f984388a34460636fea9eb181ad1a6"
+
+	txs := []*models.Transaction{
+		{Hash: "0x1", Type: models.TypeEthTransfer, From: owner, To: "0xbbb"},
+		{Hash: "0x2", Type: models.TypeERC20Transfer, From: "0xccc", To: "0xA39B189482F984388A34460636FEA9EB181AD1A6"},
+		// Internal trace between two contracts the owner's transaction touched
+		{Hash: "0x3", Type: models.TypeInternal, From: "0xrouter", To: "0xpool"},
+		{Hash: "0x4", Type: models.TypeBeaconWithdrawal, To: owner},
+	}
+
+	kept := OnlyParty(txs, owner)
+
+	want := []string{"0x1", "0x2", "0x4"}
+	if len(kept) != len(want) {
+		t.Fatalf("Expected %d transactions, got %d", len(want), len(kept))
+	}
+	for i, tx := range kept {
+		if tx.Hash != want[i] {
+			t.Errorf("Transaction %d mismatch: got %s, want %s", i, tx.Hash, want[i])
+		}
+	}
+}
+
+func TestFilterByAmount(t *testing.T) {
+	txs := []*models.Transaction{
+		{Hash: "0x1", Amount: "0.5"},
+		{Hash: "0x2", Amount: "10"},
+		{Hash: "0x3", Amount: "100"},
+		{Hash: "0x4", Amount: "1000.000001"},
+		{Hash: "0x5", Amount: ""},
+	}
+
+	tests := []struct {
+		name string
+		min  *big.Rat
+		max  *big.Rat
+		want []string
+	}{
+		{
+			name: "lower_bound",
+			min:  big.NewRat(10, 1),
+			want: []string{"0x2", "0x3", "0x4"},
+		},
+		{
+			name: "upper_bound",
+			max:  big.NewRat(100, 1),
+			want: []string{"0x1", "0x2", "0x3"},
+		},
+		{
+			name: "inclusive_range",
+			min:  big.NewRat(10, 1),
+			max:  big.NewRat(100, 1),
+			want: []string{"0x2", "0x3"},
+		},
+		{
+			name: "no_bounds_drops_unparseable",
+			want: []string{"0x1", "0x2", "0x3", "0x4"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kept := FilterByAmount(txs, tt.min, tt.max)
+			if len(kept) != len(tt.want) {
+				t.Fatalf("Expected %d transactions, got %d", len(tt.want), len(kept))
+			}
+			for i, tx := range kept {
+				if tx.Hash != tt.want[i] {
+					t.Errorf("Transaction %d mismatch: got %s, want %s", i, tx.Hash, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSample(t *testing.T) {
+	var txs []*models.Transaction
+	for i := 0; i < 20; i++ {
+		txs = append(txs, &models.Transaction{Hash: fmt.Sprintf("0x%d", i), BlockNumber: uint64(i)})
+	}
+
+	first := Sample(txs, 5, 42)
+	if len(first) != 5 {
+		t.Fatalf("Expected 5 transactions, got %d", len(first))
+	}
+	for i := 1; i < len(first); i++ {
+		if first[i].BlockNumber <= first[i-1].BlockNumber {
+			t.Errorf("Sample out of order: block %d after %d", first[i].BlockNumber, first[i-1].BlockNumber)
+		}
+	}
+
+	// The same seed picks the same rows; another seed picks others
+	again := Sample(txs, 5, 42)
+	for i := range first {
+		if again[i] != first[i] {
+			t.Errorf("Transaction %d mismatch: got %s, want %s", i, again[i].Hash, first[i].Hash)
+		}
+	}
+	if other := Sample(txs, 5, 7); slices.Equal(other, first) {
+		t.Error("Expected a different sample for a different seed")
+	}
+
+	if all := Sample(txs, 50, 42); len(all) != len(txs) {
+		t.Errorf("Expected every transaction when n exceeds the input, got %d", len(all))
+	}
+	if none := Sample(txs, 0, 42); len(none) != 0 {
+		t.Errorf("Expected no transactions for n = 0, got %d", len(none))
+	}
+}
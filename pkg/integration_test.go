@@ -7,6 +7,7 @@ import (
 	"conintracker-hiring/pkg/providers"
 	"bytes"
 	"context"
+	"encoding/csv"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -121,7 +122,7 @@ func TestEndToEndFetchAndExport(t *testing.T) {
 	if !strings.Contains(csvContent, "0xfrom") && !strings.Contains(csvContent, "0xa39b189482f984388a34460636fea9eb181ad1a6") {
 		t.Error("From address not in CSV")
 	}
-	if !strings.Contains(csvContent, "0xto") && !strings.Contains(csvContent, "0xd620AADaBaA20d2af700853C4504028cba7C3333") {
+	if !strings.Contains(csvContent, "0xto") && !strings.Contains(csvContent, "0xd620aadabaa20d2af700853c4504028cba7c3333") {
 		t.Error("To address not in CSV")
 	}
 }
@@ -283,6 +284,69 @@ func TestCSVRoundTrip(t *testing.T) {
 	_ = normalizer // Use normalizer in test for completeness
 }
 
+// TestRawRecordsExportToCSV normalizes one raw record of each type and checks the
+// exact CSV rows, so type labels and amount formatting cannot drift
+func TestRawRecordsExportToCSV(t *testing.T) {
+	normalizer := providers.NewEtherscanNormalizer()
+	var txs []*models.Transaction
+	add := func(tx *models.Transaction, err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("normalize error: %v", err)
+		}
+		txs = append(txs, tx)
+	}
+	add(normalizer.NormalizeNormalTx(providers.EtherscanNormalTx{
+		Hash: "0xhash1", BlockNumber: "1", TimeStamp: "1609459200",
+		From: "0xFrom1", To: "0xTo1", Value: "123456789012345678901",
+		GasPrice: "1000000000", GasUsed: "21000",
+	}))
+	add(normalizer.NormalizeInternalTx(providers.EtherscanInternalTx{
+		Hash: "0xhash2", BlockNumber: "2", TimeStamp: "1609459210",
+		From: "0xfrom2", To: "0xto2", Value: "5000000000000000",
+	}))
+	add(normalizer.NormalizeERC20Tx(providers.EtherscanTokenTx{
+		Hash: "0xhash3", BlockNumber: "3", TimeStamp: "1609459220",
+		From: "0xfrom3", To: "0xto3", Value: "1500000",
+		TokenName: "USD Coin", TokenSymbol: "USDC", TokenDecimal: "6",
+		ContractAddress: "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48",
+		GasPrice:        "1200000000", GasUsed: "50000",
+	}))
+	add(normalizer.NormalizeERC721Tx(providers.EtherscanTokenTx{
+		Hash: "0xhash4", BlockNumber: "4", TimeStamp: "1609459230",
+		From: "0xfrom4", To: "0xto4", TokenID: "12345", TokenSymbol: "COOL",
+		ContractAddress: "0xcontractnft", GasPrice: "1300000000", GasUsed: "55000",
+	}))
+	add(normalizer.NormalizeERC1155Tx(providers.EtherscanTokenTx{
+		Hash: "0xhash5", BlockNumber: "5", TimeStamp: "1609459240",
+		From: "0xfrom5", To: "0xto5", TokenID: "777", TokenValue: "3", TokenSymbol: "ITM",
+		ContractAddress: "0xcontract1155", GasPrice: "1400000000", GasUsed: "60000",
+	}))
+
+	buf := &bytes.Buffer{}
+	csvWriter, err := output.NewCSVWriter(output.CSVConfig{Writer: &closeableBuffer{buf}})
+	if err != nil {
+		t.Fatalf("NewCSVWriter() error = %v", err)
+	}
+	if err := csvWriter.WriteTransactions(txs); err != nil {
+		t.Fatalf("WriteTransactions() error = %v", err)
+	}
+	if err := csvWriter.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	want := `Transaction Hash,Date & Time,From Address,To Address,Transaction Type,Asset Contract Address,Asset Symbol / Name,Token ID,Value / Amount,Gas Fee (ETH)
+0xhash1,2021-01-01T00:00:00Z,0xfrom1,0xto1,ETH,,ETH,,123.456789012345678901,0.000021
+0xhash2,2021-01-01T00:00:10Z,0xfrom2,0xto2,Internal,,ETH,,0.005,
+0xhash3,2021-01-01T00:00:20Z,0xfrom3,0xto3,ERC-20,0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48,USDC,,1.5,0.00006
+0xhash4,2021-01-01T00:00:30Z,0xfrom4,0xto4,ERC-721,0xcontractnft,COOL,12345,1,0.0000715
+0xhash5,2021-01-01T00:00:40Z,0xfrom5,0xto5,ERC-1155,0xcontract1155,ITM,777,3,0.000084
+`
+	if got := buf.String(); got != want {
+		t.Errorf("CSV mismatch:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
 // closeableBuffer wraps bytes.Buffer to implement io.WriteCloser
 type closeableBuffer struct {
 	*bytes.Buffer
@@ -291,3 +355,162 @@ type closeableBuffer struct {
 func (cb *closeableBuffer) Close() error {
 	return nil
 }
+
+// TestConfirmationsFlowToCSV checks the raw confirmations count reaches the optional column
+func TestConfirmationsFlowToCSV(t *testing.T) {
+	normalizer := providers.NewEtherscanNormalizer()
+
+	normal, err := normalizer.NormalizeNormalTx(providers.EtherscanNormalTx{
+		Hash: "0xaaa", TimeStamp: "1700000000", Value: "0", Confirmations: "12345",
+	})
+	if err != nil {
+		t.Fatalf("NormalizeNormalTx() error = %v", err)
+	}
+	token, err := normalizer.NormalizeERC20Tx(providers.EtherscanTokenTx{
+		Hash: "0xbbb", TimeStamp: "1700000001", Value: "1", TokenDecimal: "0", Confirmations: "678",
+	})
+	if err != nil {
+		t.Fatalf("NormalizeERC20Tx() error = %v", err)
+	}
+	internal, err := normalizer.NormalizeInternalTx(providers.EtherscanInternalTx{
+		Hash: "0xccc", TimeStamp: "1700000002", Value: "0",
+	})
+	if err != nil {
+		t.Fatalf("NormalizeInternalTx() error = %v", err)
+	}
+
+	columns, err := output.LookupColumns([]string{"confirmations"})
+	if err != nil {
+		t.Fatalf("LookupColumns() error = %v", err)
+	}
+	buf := &bytes.Buffer{}
+	writer, err := output.NewCSVWriter(output.CSVConfig{Writer: &closeableBuffer{buf}, Columns: columns})
+	if err != nil {
+		t.Fatalf("NewCSVWriter() error = %v", err)
+	}
+	if err := writer.WriteTransactions([]*models.Transaction{normal, token, internal}); err != nil {
+		t.Fatalf("WriteTransactions() error = %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	records, err := csv.NewReader(buf).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse CSV: %v", err)
+	}
+	last := len(records[0]) - 1
+	if records[0][last] != "Confirmations" {
+		t.Fatalf("Header mismatch: got %s, want Confirmations", records[0][last])
+	}
+
+	// Internal transfers carry no count, so their cell stays empty
+	for i, want := range []string{"12345", "678", ""} {
+		if got := records[i+1][last]; got != want {
+			t.Errorf("Row %d confirmations mismatch: got %q, want %q", i+1, got, want)
+		}
+	}
+}
+
+// TestNonceFlowsToCSV checks a normal transaction's nonce reaches the metadata columns,
+// including nonce 0, while other types leave the cell empty
+func TestNonceFlowsToCSV(t *testing.T) {
+	normalizer := providers.NewEtherscanNormalizer()
+
+	first, err := normalizer.NormalizeNormalTx(providers.EtherscanNormalTx{
+		Hash: "0xaaa", TimeStamp: "1700000000", Value: "0", Nonce: "0",
+	})
+	if err != nil {
+		t.Fatalf("NormalizeNormalTx() error = %v", err)
+	}
+	later, err := normalizer.NormalizeNormalTx(providers.EtherscanNormalTx{
+		Hash: "0xbbb", TimeStamp: "1700000001", Value: "0", Nonce: "57",
+	})
+	if err != nil {
+		t.Fatalf("NormalizeNormalTx() error = %v", err)
+	}
+	token, err := normalizer.NormalizeERC20Tx(providers.EtherscanTokenTx{
+		Hash: "0xccc", TimeStamp: "1700000002", Value: "1", TokenDecimal: "0", Nonce: "9",
+	})
+	if err != nil {
+		t.Fatalf("NormalizeERC20Tx() error = %v", err)
+	}
+
+	columns, err := output.LookupColumns(output.MetadataColumns)
+	if err != nil {
+		t.Fatalf("LookupColumns() error = %v", err)
+	}
+	buf := &bytes.Buffer{}
+	writer, err := output.NewCSVWriter(output.CSVConfig{Writer: &closeableBuffer{buf}, Columns: columns})
+	if err != nil {
+		t.Fatalf("NewCSVWriter() error = %v", err)
+	}
+	if err := writer.WriteTransactions([]*models.Transaction{first, later, token}); err != nil {
+		t.Fatalf("WriteTransactions() error = %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	records, err := csv.NewReader(buf).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse CSV: %v", err)
+	}
+	last := len(records[0]) - 1
+	if records[0][last] != "Nonce" {
+		t.Fatalf("Header mismatch: got %s, want Nonce", records[0][last])
+	}
+	for i, want := range []string{"0", "57", ""} {
+		if got := records[i+1][last]; got != want {
+			t.Errorf("Row %d nonce mismatch: got %q, want %q", i+1, got, want)
+		}
+	}
+}
+
+// TestRevertedInternalErrorFlowsToCSV checks an internal transfer's error code reaches the Error column
+func TestRevertedInternalErrorFlowsToCSV(t *testing.T) {
+	normalizer := providers.NewEtherscanNormalizer()
+
+	reverted, err := normalizer.NormalizeInternalTx(providers.EtherscanInternalTx{
+		Hash: "0xaaa", TimeStamp: "1700000000", Value: "1000000000000000000", IsError: "1", ErrCode: "Reverted",
+	})
+	if err != nil {
+		t.Fatalf("NormalizeInternalTx() error = %v", err)
+	}
+	ok, err := normalizer.NormalizeInternalTx(providers.EtherscanInternalTx{
+		Hash: "0xbbb", TimeStamp: "1700000001", Value: "1000000000000000000", IsError: "0",
+	})
+	if err != nil {
+		t.Fatalf("NormalizeInternalTx() error = %v", err)
+	}
+
+	columns, err := output.LookupColumns([]string{"error"})
+	if err != nil {
+		t.Fatalf("LookupColumns() error = %v", err)
+	}
+	buf := &bytes.Buffer{}
+	writer, err := output.NewCSVWriter(output.CSVConfig{Writer: &closeableBuffer{buf}, Columns: columns})
+	if err != nil {
+		t.Fatalf("NewCSVWriter() error = %v", err)
+	}
+	if err := writer.WriteTransactions([]*models.Transaction{reverted, ok}); err != nil {
+		t.Fatalf("WriteTransactions() error = %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	records, err := csv.NewReader(buf).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse CSV: %v", err)
+	}
+	last := len(records[0]) - 1
+	if records[0][last] != "Error" {
+		t.Fatalf("Header mismatch: got %s, want Error", records[0][last])
+	}
+	for i, want := range []string{"Reverted", ""} {
+		if got := records[i+1][last]; got != want {
+			t.Errorf("Row %d error mismatch: got %q, want %q", i+1, got, want)
+		}
+	}
+}
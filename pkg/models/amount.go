@@ -0,0 +1,35 @@
+package models
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ParseAmount parses a transaction's Amount into an exact rational, the one parser
+// sorting, filtering, valuation, and output should share. Amounts are plain decimal
+// strings already scaled to the asset's units: "1.5" ETH, "1000.25" USDC. NFT
+// transfers (ERC-721 and ERC-1155) count whole tokens, so a fractional amount is
+// rejected for them. Empty amounts, fractions such as "1/3", and exponents are
+// malformed.
+func ParseAmount(tx *Transaction) (*big.Rat, error) {
+	if tx == nil {
+		return nil, fmt.Errorf("malformed amount: no transaction")
+	}
+	s := strings.TrimSpace(tx.Amount)
+	if s == "" || strings.ContainsAny(s, "/eE") {
+		return nil, fmt.Errorf("malformed amount %q for transaction %s", tx.Amount, tx.Hash)
+	}
+	amount, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("malformed amount %q for transaction %s", tx.Amount, tx.Hash)
+	}
+
+	switch tx.Type {
+	case TypeERC721Transfer, TypeERC1155Transfer:
+		if !amount.IsInt() {
+			return nil, fmt.Errorf("fractional %s amount %q for transaction %s", tx.Type, tx.Amount, tx.Hash)
+		}
+	}
+	return amount, nil
+}
@@ -0,0 +1,37 @@
+package models
+
+import "testing"
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		name    string
+		tx      *Transaction
+		want    string
+		wantErr bool
+	}{
+		{name: "eth_decimal", tx: &Transaction{Type: TypeEthTransfer, Amount: "1.5"}, want: "3/2"},
+		{name: "eth_wei", tx: &Transaction{Type: TypeEthTransfer, Amount: "0.000000000000000001"}, want: "1/1000000000000000000"},
+		{name: "token_decimal", tx: &Transaction{Type: TypeERC20Transfer, Amount: "1000.25"}, want: "4001/4"},
+		{name: "negative", tx: &Transaction{Type: TypeERC20Transfer, Amount: "-2"}, want: "-2/1"},
+		{name: "nft_integer", tx: &Transaction{Type: TypeERC721Transfer, Amount: "1"}, want: "1/1"},
+		{name: "erc1155_integer", tx: &Transaction{Type: TypeERC1155Transfer, Amount: "25"}, want: "25/1"},
+		{name: "nft_fractional", tx: &Transaction{Type: TypeERC721Transfer, Amount: "0.5"}, wantErr: true},
+		{name: "malformed", tx: &Transaction{Type: TypeEthTransfer, Amount: "1.5 ETH"}, wantErr: true},
+		{name: "empty", tx: &Transaction{Type: TypeEthTransfer}, wantErr: true},
+		{name: "fraction_syntax", tx: &Transaction{Type: TypeEthTransfer, Amount: "1/3"}, wantErr: true},
+		{name: "exponent", tx: &Transaction{Type: TypeEthTransfer, Amount: "1e18"}, wantErr: true},
+		{name: "nil", tx: nil, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAmount(tt.tx)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseAmount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got.String() != tt.want {
+				t.Errorf("Amount mismatch: got %s, want %s", got.String(), tt.want)
+			}
+		})
+	}
+}
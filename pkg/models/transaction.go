@@ -1,6 +1,10 @@
 package models
 
 import (
+	"conintracker-hiring/internal/keccak"
+	"encoding/hex"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -14,27 +18,47 @@ const (
 	TypeERC1155Transfer TransactionType = "ERC-1155"
 	TypeInternal       TransactionType = "Internal"
 	TypeContractCreate TransactionType = "Contract Creation"
+	TypeBeaconWithdrawal TransactionType = "Beacon Withdrawal"
 )
 
+// ZeroAddress is the null address tokens are minted from and burned to
+const ZeroAddress = "0x0000000000000000000000000000000000000000"
+
+// Transfer subtypes describing token supply changes
+const (
+	SubtypeMint = "Mint"
+	SubtypeBurn = "Burn"
+)
+
+// SubtypeSafeExecution labels normal transactions that execute a Gnosis Safe
+// multisig transaction
+const SubtypeSafeExecution = "Safe Execution"
+
 // Transaction represents a normalized transaction record
 type Transaction struct {
 	// Core transaction info
+	Chain     string `csv:"Chain"` // Optional column: chain name, e.g. "ethereum", "polygon"
 	Hash      string `csv:"Transaction Hash"`
 	Timestamp time.Time `csv:"Date & Time"`
 	From      string `csv:"From Address"`
 	To        string `csv:"To Address"`
 	
 	// Transaction categorization
-	Type TransactionType `csv:"Transaction Type"`
+	Type    TransactionType `csv:"Transaction Type"`
+	Subtype string          `csv:"Subtype"` // Optional column: Mint, Burn, Safe Execution
+	Category string         `csv:"Category"` // Optional column: Approval, Swap, Transfer, Mint, Burn, Unknown
 	
 	// Asset info
 	AssetContractAddress string `csv:"Asset Contract Address"`
 	AssetSymbol          string `csv:"Asset Symbol / Name"`
+	AssetName            string `csv:"Asset Name"` // Optional column: token name, e.g. "USD Coin"
 	TokenID              string `csv:"Token ID"` // For NFTs (ERC-721, ERC-1155)
 	
 	// Values
 	Amount  string `csv:"Value / Amount"` // Quantity transferred
+	RawAmount string `csv:"-"` // Amount in the asset's base unit as the provider reported it (wei, token units, Gwei for withdrawals); empty for NFTs
 	GasFeeETH string `csv:"Gas Fee (ETH)"` // Total gas cost in ETH
+	ValueUSD  string `csv:"Value (USD)"` // Optional column: Amount at the asset's historical USD price
 	
 	// Additional metadata (not in CSV but useful for processing)
 	BlockNumber     uint64 `csv:"-"`
@@ -42,11 +66,121 @@ type Transaction struct {
 	GasPrice        string `csv:"-"` // in Wei
 	TransactionFee  string `csv:"-"` // in Wei
 	Nonce           uint64 `csv:"-"`
+	Confirmations   uint64 `csv:"-"` // Blocks mined on top as of the fetch; 0 when the provider doesn't report it
 	IsError         bool   `csv:"-"`
+	ErrorReason     string `csv:"-"` // Why a failed transaction failed: the provider's error code, or "Failed" when none is given
 	Input           string `csv:"-"`
 	MethodID        string `csv:"-"`
 	FunctionName    string `csv:"-"`
-	Decimals        int    `csv:"-"` // For token transfers
+	UnlimitedApproval bool `csv:"-"` // approve() of the max uint256 allowance, letting the spender move any amount
+	RelatedApprovalHash string `csv:"-"` // ERC-20 transfers: hash of the approve() that let the recipient move these tokens
+	Decimals        int    `csv:"-"` // Decimal places of Amount's asset: the token's for ERC-20, 18 for native transfers, 0 for NFTs
+	UnknownDecimals bool   `csv:"-"` // Token reported missing or invalid decimals; Amount is the raw integer
+	ParentHash      string `csv:"-"` // Internal transfers: hash of the normal tx that spawned it, when in the same export
+	ParentFunction  string `csv:"-"` // Internal transfers: the parent's function name, or its selector
+	WithdrawalIndex string `csv:"-"` // Beacon withdrawals: the consensus layer's index, which stands in for the missing hash
+	TraceID         string `csv:"-"` // Internal transfers: the call's position in the trace, telling apart identical calls in one hash
+
+	// Extra holds free-form annotations added by enrichers
+	Extra map[string]string `csv:"-"`
+}
+
+// Clone returns an independent copy of the transaction, including its Extra map
+func (t *Transaction) Clone() *Transaction {
+	if t == nil {
+		return nil
+	}
+
+	clone := *t
+	if t.Extra != nil {
+		clone.Extra = make(map[string]string, len(t.Extra))
+		for k, v := range t.Extra {
+			clone.Extra[k] = v
+		}
+	}
+	return &clone
+}
+
+// Key returns the canonical identity of the transfer: the lowercased hash, type,
+// token ID and contract, followed by the sender, receiver and amount, which tell
+// apart the several transfers one hash can carry (e.g. both legs of a swap).
+// Beacon withdrawals have no hash and use their withdrawal index instead, and
+// internal transfers end with their trace ID when known, so identical calls within
+// one hash stay distinct. The amount is RawAmount when known, so --decimals and
+// --compact-amounts don't change the key. Dedupe, append and resume all compare
+// transactions by Key.
+func (t *Transaction) Key() string {
+	id := strings.ToLower(t.Hash)
+	if id == "" && t.WithdrawalIndex != "" {
+		id = "withdrawal:" + t.WithdrawalIndex
+	}
+	amount := t.Amount
+	if t.RawAmount != "" {
+		amount = "raw:" + t.RawAmount
+	}
+	parts := []string{
+		id,
+		string(t.Type),
+		t.TokenID,
+		strings.ToLower(t.AssetContractAddress),
+		strings.ToLower(t.From),
+		strings.ToLower(t.To),
+		amount,
+	}
+	if t.TraceID != "" {
+		parts = append(parts, "trace:"+t.TraceID)
+	}
+	return strings.Join(parts, "|")
+}
+
+// MaskAddress shortens an address to its first four and last four hex digits,
+// e.g. 0x1234…abcd. Values too short to mask are returned unchanged.
+func MaskAddress(addr string) string {
+	if len(addr) <= 10 {
+		return addr
+	}
+	return addr[:6] + "…" + addr[len(addr)-4:]
+}
+
+// ChecksumAddress returns the EIP-55 mixed-case checksum form of a 0x-prefixed
+// 20-byte hex address. Anything else is returned unchanged.
+func ChecksumAddress(addr string) string {
+	if len(addr) != 42 || !strings.HasPrefix(addr, "0x") && !strings.HasPrefix(addr, "0X") {
+		return addr
+	}
+	lower := strings.ToLower(addr[2:])
+	if _, err := hex.DecodeString(lower); err != nil {
+		return addr
+	}
+
+	hash := keccak.Sum256([]byte(lower))
+	out := []byte("0x" + lower)
+	for i := 0; i < 40; i++ {
+		// Uppercase a letter when the matching nibble of the hash is 8 or more
+		nibble := hash[i/2] >> 4
+		if i%2 == 1 {
+			nibble = hash[i/2] & 0x0f
+		}
+		if c := out[i+2]; c >= 'a' && c <= 'f' && nibble >= 8 {
+			out[i+2] = c - 'a' + 'A'
+		}
+	}
+	return string(out)
+}
+
+// RedactAddresses masks From, To, and AssetContractAddress with MaskAddress.
+// An address equal to keep (case-insensitive) stays visible; pass "" to mask all.
+func (t *Transaction) RedactAddresses(keep string) {
+	mask := func(addr string) string {
+		if keep != "" && strings.EqualFold(addr, keep) {
+			return addr
+		}
+		return MaskAddress(addr)
+	}
+
+	t.From = mask(t.From)
+	t.To = mask(t.To)
+	t.AssetContractAddress = mask(t.AssetContractAddress)
 }
 
 // TransactionList is a sortable slice of transactions
@@ -69,3 +203,19 @@ func (tl TransactionList) Less(i, j int) bool {
 func (tl TransactionList) Swap(i, j int) {
 	tl[i], tl[j] = tl[j], tl[i]
 }
+
+// SortByTimestamp orders txs by wall-clock time alone, ignoring block numbers, which
+// makes it the right order for exports merging several chains. Equal timestamps
+// are ordered by hash; the sort is stable, so rows sharing both keep their order.
+func SortByTimestamp(txs []*Transaction, descending bool) {
+	sort.SliceStable(txs, func(i, j int) bool {
+		a, b := txs[i], txs[j]
+		if descending {
+			a, b = b, a
+		}
+		if !a.Timestamp.Equal(b.Timestamp) {
+			return a.Timestamp.Before(b.Timestamp)
+		}
+		return strings.ToLower(a.Hash) < strings.ToLower(b.Hash)
+	})
+}
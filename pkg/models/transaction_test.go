@@ -0,0 +1,225 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTransactionClone(t *testing.T) {
+	original := &Transaction{
+		Hash:      "0xabc",
+		Timestamp: time.Unix(1700000000, 0).UTC(),
+		From:      "0x1111111111111111111111111111111111111111",
+		To:        "0x2222222222222222222222222222222222222222",
+		Type:      TypeERC20Transfer,
+		Amount:    "1.5",
+		Extra:     map[string]string{"label": "exchange"},
+	}
+
+	clone := original.Clone()
+	if clone == original {
+		t.Fatal("Clone returned the same pointer")
+	}
+
+	clone.Amount = "2"
+	clone.Type = TypeEthTransfer
+	clone.Extra["label"] = "bridge"
+	clone.Extra["note"] = "added"
+
+	if original.Amount != "1.5" {
+		t.Errorf("Amount mismatch: got %s, want 1.5", original.Amount)
+	}
+	if original.Type != TypeERC20Transfer {
+		t.Errorf("Type mismatch: got %s, want %s", original.Type, TypeERC20Transfer)
+	}
+	if original.Extra["label"] != "exchange" {
+		t.Errorf("Extra label mismatch: got %s, want exchange", original.Extra["label"])
+	}
+	if _, ok := original.Extra["note"]; ok {
+		t.Error("Clone mutation leaked a new key into the original Extra map")
+	}
+}
+
+func TestTransactionKey(t *testing.T) {
+	const hash = "0xAbC0000000000000000000000000000000000000000000000000000000000001"
+	wallet := "0x1111111111111111111111111111111111111111"
+	pool := "0x2222222222222222222222222222222222222222"
+
+	// The same transfer as reported with checksummed and lowercased addresses
+	checksummed := &Transaction{
+		Hash:                 hash,
+		Type:                 TypeERC20Transfer,
+		From:                 ChecksumAddress(wallet),
+		To:                   pool,
+		AssetContractAddress: ChecksumAddress("0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"),
+		Amount:               "100",
+		Timestamp:            time.Unix(1700000000, 0),
+	}
+	lowered := checksummed.Clone()
+	lowered.Hash = strings.ToLower(hash)
+	lowered.From = wallet
+	lowered.AssetContractAddress = "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"
+	lowered.Category = "Swap" // Enrichment does not change identity
+
+	if checksummed.Key() != lowered.Key() {
+		t.Errorf("Key mismatch for the same transfer: got %s and %s", checksummed.Key(), lowered.Key())
+	}
+
+	// The two legs of a swap share the hash but move different tokens
+	sold := &Transaction{Hash: hash, Type: TypeERC20Transfer, From: wallet, To: pool, AssetContractAddress: "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", Amount: "100"}
+	bought := &Transaction{Hash: hash, Type: TypeERC20Transfer, From: pool, To: wallet, AssetContractAddress: "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2", Amount: "0.05"}
+
+	if sold.Key() == bought.Key() {
+		t.Errorf("Expected distinct keys for the two swap legs, both got %s", sold.Key())
+	}
+
+	// Formatting options change Amount but not the raw amount the key is built from
+	precise := &Transaction{Hash: hash, Type: TypeEthTransfer, From: wallet, To: pool, Amount: "1.23456789", RawAmount: "1234567890000000000"}
+	rounded := precise.Clone()
+	rounded.Amount = "1.23"
+	if precise.Key() != rounded.Key() {
+		t.Errorf("Key mismatch across amount formatting: got %s and %s", precise.Key(), rounded.Key())
+	}
+
+	// Equal withdrawals to one address are told apart by their withdrawal index
+	first := &Transaction{Type: TypeBeaconWithdrawal, To: wallet, Amount: "0.0123", WithdrawalIndex: "1001"}
+	second := &Transaction{Type: TypeBeaconWithdrawal, To: wallet, Amount: "0.0123", WithdrawalIndex: "1002"}
+	if first.Key() == second.Key() {
+		t.Errorf("Expected distinct keys for two withdrawals, both got %s", first.Key())
+	}
+
+	// Identical internal calls in one transaction are told apart by their trace ID
+	call := &Transaction{Hash: hash, Type: TypeInternal, From: pool, To: wallet, Amount: "1", RawAmount: "1000000000000000000", TraceID: "0_1"}
+	repeat := call.Clone()
+	repeat.TraceID = "0_2"
+	if call.Key() == repeat.Key() {
+		t.Errorf("Expected distinct keys for two internal calls, both got %s", call.Key())
+	}
+}
+
+func TestMaskAddress(t *testing.T) {
+	tests := []struct {
+		addr string
+		want string
+	}{
+		{addr: "0x1234567890abcdef1234567890abcdef1234abcd", want: "0x1234…abcd"},
+		{addr: "", want: ""},
+		{addr: "0x1234", want: "0x1234"},
+	}
+
+	for _, tt := range tests {
+		if got := MaskAddress(tt.addr); got != tt.want {
+			t.Errorf("MaskAddress(%q) = %q, want %q", tt.addr, got, tt.want)
+		}
+	}
+}
+
+func TestTransactionRedactAddresses(t *testing.T) {
+	owner := "0xA39B189482F984388A34460636FEA9EB181AD1A6"
+	hash := "0x5555555555555555555555555555555555555555555555555555555555555555"
+
+	newTx := func() *Transaction {
+		return &Transaction{
+			Hash:                 hash,
+			From:                 "0xa39b189482f984388a34460636fea9eb181ad1a6",
+			To:                   "0xd620aadabaa20d2af700853c4504028cba7c3333",
+			AssetContractAddress: "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48",
+		}
+	}
+
+	tx := newTx()
+	tx.RedactAddresses(owner)
+	if tx.From != "0xa39b189482f984388a34460636fea9eb181ad1a6" {
+		t.Errorf("Owner address should stay visible, got %s", tx.From)
+	}
+	if tx.To != "0xd620…3333" {
+		t.Errorf("To mismatch: got %s, want 0xd620…3333", tx.To)
+	}
+	if tx.AssetContractAddress != "0xa0b8…eb48" {
+		t.Errorf("AssetContractAddress mismatch: got %s, want 0xa0b8…eb48", tx.AssetContractAddress)
+	}
+	if tx.Hash != hash {
+		t.Errorf("Hash should be untouched, got %s", tx.Hash)
+	}
+
+	tx = newTx()
+	tx.RedactAddresses("")
+	if tx.From != "0xa39b…d1a6" {
+		t.Errorf("From mismatch: got %s, want 0xa39b…d1a6", tx.From)
+	}
+	if tx.Hash != hash {
+		t.Errorf("Hash should be untouched, got %s", tx.Hash)
+	}
+}
+
+func TestTransactionCloneNil(t *testing.T) {
+	var tx *Transaction
+	if tx.Clone() != nil {
+		t.Error("Expected nil clone of nil transaction")
+	}
+
+	clone := (&Transaction{Hash: "0xabc"}).Clone()
+	if clone.Extra != nil {
+		t.Error("Expected nil Extra to stay nil")
+	}
+}
+
+func TestChecksumAddress(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		// EIP-55 reference vectors
+		{"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"},
+		{"0xFB6916095CA1DF60BB79CE92CE3EA74C37C5D359", "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359"},
+		{"0xdbf03b407c01e7cd3cbea99509d93f8dddc8c6fb", "0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB"},
+		{"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb", "0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb"},
+		{"", ""},
+		{"0x1234", "0x1234"},
+		{"0xzz20a0cf47c7b9be7a2e6ba89f429762e7b9adbb", "0xzz20a0cf47c7b9be7a2e6ba89f429762e7b9adbb"},
+	}
+
+	for _, tt := range tests {
+		if got := ChecksumAddress(tt.input); got != tt.want {
+			t.Errorf("ChecksumAddress(%q) mismatch: got %s, want %s", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestSortByTimestamp(t *testing.T) {
+	at := func(seconds int64) time.Time { return time.Unix(1700000000+seconds, 0) }
+
+	// Polygon block numbers dwarf Ethereum's, so block order would list every
+	// Ethereum row first
+	newTxs := func() []*Transaction {
+		return []*Transaction{
+			{Hash: "0xe1", Chain: "ethereum", BlockNumber: 18000000, Timestamp: at(30)},
+			{Hash: "0xp1", Chain: "polygon", BlockNumber: 50000000, Timestamp: at(10)},
+			{Hash: "0xe2", Chain: "ethereum", BlockNumber: 18000001, Timestamp: at(40)},
+			{Hash: "0xp3", Chain: "polygon", BlockNumber: 50000002, Timestamp: at(20)},
+			{Hash: "0xP2", Chain: "polygon", BlockNumber: 50000001, Timestamp: at(20)},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		descending bool
+		want       []string
+	}{
+		{"ascending", false, []string{"0xp1", "0xP2", "0xp3", "0xe1", "0xe2"}},
+		{"descending", true, []string{"0xe2", "0xe1", "0xp3", "0xP2", "0xp1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txs := newTxs()
+			SortByTimestamp(txs, tt.descending)
+			for i, hash := range tt.want {
+				if txs[i].Hash != hash {
+					t.Errorf("Position %d mismatch: got %s, want %s", i, txs[i].Hash, hash)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package output
+
+import (
+	"conintracker-hiring/pkg/models"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// AppendFile is an existing CSV export opened for appending.
+// It remembers the keys recorded by earlier runs in its seen store (see SeenPath),
+// so re-runs don't write them twice. A file without a seen store, such as a plain
+// export, is matched against its rows instead. Those carry amounts and addresses
+// as written, so a run with different rounding, zero padding or redaction than the
+// one that wrote the file does not recognize its rows and appends them again.
+type AppendFile struct {
+	*os.File
+
+	// HasContent reports whether the file held any non-blank content before
+	// opening; callers should omit the header when it does (see NeedsHeader)
+	HasContent bool
+
+	existing map[string]struct{}
+	rows     map[string]int // Without a seen store: the file's rows by rowKey, with counts
+	seenPath string
+	added    []string // Keys to record in the seen store at the next SaveSeen
+}
+
+// OpenAppendFile opens path for appending, creating it if needed, and indexes its existing rows
+func OpenAppendFile(path string) (*AppendFile, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open output file for append: %w", err)
+	}
+
+	// Reads start at offset 0; O_APPEND only affects writes
+	state, err := DetectHeader(file)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to read existing output file: %w", err)
+	}
+
+	af := &AppendFile{
+		File:       file,
+		HasContent: state != HeaderEmpty,
+		existing:   make(map[string]struct{}),
+		seenPath:   SeenPath(path),
+	}
+
+	hasSeen, err := loadSeen(af.seenPath, af.existing)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	// The seen store holds the raw-amount keys of every row written; the rows
+	// themselves only carry formatted amounts, so they are read only without one
+	if af.HasContent && !hasSeen {
+		af.rows = make(map[string]int)
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			file.Close()
+			return nil, fmt.Errorf("failed to rewind output file: %w", err)
+		}
+		if err := af.indexRows(file); err != nil {
+			file.Close()
+			return nil, err
+		}
+	}
+
+	return af, nil
+}
+
+// indexRows counts the rowKey of every data row in a previously written export
+func (af *AppendFile) indexRows(r io.Reader) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read existing output file: %w", err)
+		}
+
+		// Skip the header and any row that doesn't match the export layout
+		if len(record) < 9 || strings.TrimPrefix(record[0], utf8BOM) == standardHeaders[0] {
+			continue
+		}
+
+		row := &models.Transaction{
+			Hash:                 record[0],
+			From:                 record[2],
+			To:                   record[3],
+			Type:                 models.TransactionType(record[4]),
+			AssetContractAddress: record[5],
+			TokenID:              record[7],
+			Amount:               strings.ReplaceAll(record[8], ",", ""), // Rows written with --human group thousands
+		}
+		af.rows[row.Key()]++
+	}
+}
+
+// NeedsHeader reports whether rows appended to this file should be preceded by a header
+func (af *AppendFile) NeedsHeader() bool {
+	return !af.HasContent
+}
+
+// FilterNew returns the transactions that are not already present in the file.
+// With a seen store they are matched by Key alone. Without one, each row read back
+// from the file matches one transaction with the same rowKey, so distinct transfers
+// whose amounts format alike are not merged; matched keys go into the new seen store.
+func (af *AppendFile) FilterNew(txs []*models.Transaction) []*models.Transaction {
+	var fresh []*models.Transaction
+	for _, tx := range txs {
+		key := tx.Key()
+		if _, ok := af.existing[key]; ok {
+			continue
+		}
+		af.existing[key] = struct{}{}
+		af.added = append(af.added, key)
+		if row := rowKey(tx); af.rows[row] > 0 {
+			af.rows[row]--
+			continue
+		}
+		fresh = append(fresh, tx)
+	}
+	return fresh
+}
+
+// rowKey is tx's Key as a row read back from an export gives it: keyed by the
+// formatted Amount, by the empty hash for withdrawals, and without a trace ID
+func rowKey(tx *models.Transaction) string {
+	row := *tx
+	row.RawAmount = ""
+	row.WithdrawalIndex = ""
+	row.TraceID = ""
+	return row.Key()
+}
+
+// SaveSeen records the keys of the transactions FilterNew let through, and of those
+// it matched to the file's rows, in the seen store, so later runs skip them without
+// reading the rows back. Call it once the new rows are written.
+func (af *AppendFile) SaveSeen() error {
+	if err := appendSeen(af.seenPath, af.added); err != nil {
+		return err
+	}
+	af.added = nil
+	return nil
+}
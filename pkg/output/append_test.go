@@ -0,0 +1,310 @@
+package output
+
+import (
+	"conintracker-hiring/pkg/models"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func appendTestTx(hash string, block uint64) *models.Transaction {
+	return &models.Transaction{
+		Hash:        hash,
+		Timestamp:   time.Unix(1700000000+int64(block), 0),
+		From:        "0xfrom",
+		To:          "0xto",
+		Type:        models.TypeEthTransfer,
+		Amount:      "1",
+		GasFeeETH:   "0.001",
+		BlockNumber: block,
+	}
+}
+
+func writeAppendBatch(t *testing.T, path string, txs []*models.Transaction) int {
+	t.Helper()
+
+	af, err := OpenAppendFile(path)
+	if err != nil {
+		t.Fatalf("OpenAppendFile() error = %v", err)
+	}
+
+	fresh := af.FilterNew(txs)
+	writer, err := NewCSVWriter(CSVConfig{Writer: af, OmitHeader: !af.NeedsHeader()})
+	if err != nil {
+		t.Fatalf("NewCSVWriter() error = %v", err)
+	}
+	if err := writer.WriteTransactions(fresh); err != nil {
+		t.Fatalf("WriteTransactions() error = %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := af.SaveSeen(); err != nil {
+		t.Fatalf("SaveSeen() error = %v", err)
+	}
+
+	return len(fresh)
+}
+
+func TestAppendSkipsHeaderAndDuplicates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "transactions.csv")
+
+	first := []*models.Transaction{appendTestTx("0xaaa", 1), appendTestTx("0xbbb", 2)}
+	if n := writeAppendBatch(t, path, first); n != 2 {
+		t.Fatalf("Expected 2 rows in first batch, wrote %d", n)
+	}
+
+	// The second batch overlaps the first at the block boundary, with different hash casing
+	second := []*models.Transaction{appendTestTx("0xBBB", 2), appendTestTx("0xccc", 3)}
+	if n := writeAppendBatch(t, path, second); n != 1 {
+		t.Fatalf("Expected 1 new row in second batch, wrote %d", n)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	content := string(data)
+
+	if count := strings.Count(content, "Transaction Hash"); count != 1 {
+		t.Errorf("Expected exactly 1 header, got %d", count)
+	}
+
+	lines := strings.Split(strings.TrimSpace(content), "\n")
+	if len(lines) != 4 {
+		t.Errorf("Expected header + 3 rows, got %d lines:\n%s", len(lines), content)
+	}
+	if strings.Count(strings.ToLower(content), "0xbbb") != 1 {
+		t.Errorf("Duplicate row written across append boundary:\n%s", content)
+	}
+}
+
+func TestAppendSeenStoreSkipsRowsFromPriorRuns(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "transactions.csv")
+
+	first := []*models.Transaction{appendTestTx("0xaaa", 1), appendTestTx("0xbbb", 2)}
+	if n := writeAppendBatch(t, path, first); n != 2 {
+		t.Fatalf("Expected 2 rows in first batch, wrote %d", n)
+	}
+
+	// A spreadsheet re-save reformats the amounts, so the rows no longer match their keys
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	resaved := strings.ReplaceAll(string(data), ",1,0.001", ",1.00,0.001")
+	if err := os.WriteFile(path, []byte(resaved), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	second := []*models.Transaction{appendTestTx("0xbbb", 2), appendTestTx("0xccc", 3)}
+	if n := writeAppendBatch(t, path, second); n != 1 {
+		t.Fatalf("Expected 1 new row in second batch, wrote %d", n)
+	}
+
+	data, err = os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if count := strings.Count(string(data), "0xbbb"); count != 1 {
+		t.Errorf("Expected 0xbbb written once total, found %d:\n%s", count, data)
+	}
+
+	seen, err := os.ReadFile(SeenPath(path))
+	if err != nil {
+		t.Fatalf("ReadFile(seen) error = %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(string(seen)), "\n"); len(lines) != 3 {
+		t.Errorf("Expected 3 keys in seen store, got %d:\n%s", len(lines), seen)
+	}
+}
+
+func TestAppendKeyIgnoresAmountFormatting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "transactions.csv")
+
+	tx := appendTestTx("0xaaa", 1)
+	tx.RawAmount = "1234567890000000000"
+	tx.Amount = "1.23456789"
+	if n := writeAppendBatch(t, path, []*models.Transaction{tx}); n != 1 {
+		t.Fatalf("Expected 1 row in first batch, wrote %d", n)
+	}
+
+	// The same transfer re-fetched with --decimals 2
+	rounded := tx.Clone()
+	rounded.Amount = "1.23"
+	if n := writeAppendBatch(t, path, []*models.Transaction{rounded}); n != 0 {
+		t.Errorf("Expected the reformatted transfer to be skipped, wrote %d", n)
+	}
+
+	// A file written before raw amounts were keyed still matches on its rows
+	legacy := filepath.Join(t.TempDir(), "legacy.csv")
+	unkeyed := tx.Clone()
+	unkeyed.RawAmount = ""
+	writeAppendBatch(t, legacy, []*models.Transaction{unkeyed})
+	if err := os.Remove(SeenPath(legacy)); err != nil {
+		t.Fatalf("Remove(seen) error = %v", err)
+	}
+	if n := writeAppendBatch(t, legacy, []*models.Transaction{tx}); n != 0 {
+		t.Errorf("Expected the row already in the legacy file to be skipped, wrote %d", n)
+	}
+}
+
+func TestAppendKeepsTransfersThatFormatAlike(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "transactions.csv")
+
+	// Two transfers in one transaction that both round to 1.23 with --decimals 2
+	a := appendTestTx("0xaaa", 1)
+	a.RawAmount, a.Amount = "1230000000000000000", "1.23"
+	b := a.Clone()
+	b.RawAmount = "1231000000000000000"
+
+	// A plain export holding only the first, so there is no seen store yet
+	writeAppendBatch(t, path, []*models.Transaction{a})
+	if err := os.Remove(SeenPath(path)); err != nil {
+		t.Fatalf("Remove(seen) error = %v", err)
+	}
+
+	if n := writeAppendBatch(t, path, []*models.Transaction{a, b}); n != 1 {
+		t.Errorf("Expected only the second transfer to be appended, wrote %d", n)
+	}
+	if n := writeAppendBatch(t, path, []*models.Transaction{a, b}); n != 0 {
+		t.Errorf("Expected both transfers to be skipped once recorded, wrote %d", n)
+	}
+}
+
+func TestAppendDedupesMixedCaseHashes(t *testing.T) {
+	lower := appendTestTx("0xabcdef", 1)
+	upper := lower.Clone()
+	upper.Hash = "0xABCDEF"
+	if lower.Key() != upper.Key() {
+		t.Fatalf("Key mismatch across hash case: got %s and %s", lower.Key(), upper.Key())
+	}
+
+	af, err := OpenAppendFile(filepath.Join(t.TempDir(), "transactions.csv"))
+	if err != nil {
+		t.Fatalf("OpenAppendFile() error = %v", err)
+	}
+	defer af.Close()
+
+	if fresh := af.FilterNew([]*models.Transaction{lower, upper}); len(fresh) != 1 {
+		t.Errorf("Expected the two casings to collapse to 1 row, got %d", len(fresh))
+	}
+}
+
+func TestAppendKeepsIdenticalInternalCalls(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "transactions.csv")
+
+	// One transaction making the same 1 ETH call twice
+	first := appendTestTx("0xaaa", 1)
+	first.Type = models.TypeInternal
+	first.RawAmount = "1000000000000000000"
+	first.TraceID = "0_1"
+	second := first.Clone()
+	second.TraceID = "0_2"
+
+	if n := writeAppendBatch(t, path, []*models.Transaction{first, second}); n != 2 {
+		t.Fatalf("Expected both internal calls to be written, wrote %d", n)
+	}
+	if n := writeAppendBatch(t, path, []*models.Transaction{first, second}); n != 0 {
+		t.Errorf("Expected both internal calls to be skipped on re-run, wrote %d", n)
+	}
+}
+
+func TestAppendSkipsWithdrawals(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "transactions.csv")
+
+	withdrawal := appendTestTx("", 1)
+	withdrawal.Type = models.TypeBeaconWithdrawal
+	withdrawal.WithdrawalIndex = "42"
+	withdrawal.RawAmount = "1000000000"
+
+	if n := writeAppendBatch(t, path, []*models.Transaction{withdrawal}); n != 1 {
+		t.Fatalf("Expected 1 row in first batch, wrote %d", n)
+	}
+	if n := writeAppendBatch(t, path, []*models.Transaction{withdrawal}); n != 0 {
+		t.Errorf("Expected the withdrawal to be skipped, wrote %d", n)
+	}
+
+	// Rows carry no withdrawal index, but still match without a seen store
+	if err := os.Remove(SeenPath(path)); err != nil {
+		t.Fatalf("Remove(seen) error = %v", err)
+	}
+	if n := writeAppendBatch(t, path, []*models.Transaction{withdrawal}); n != 0 {
+		t.Errorf("Expected the withdrawal row to be matched, wrote %d", n)
+	}
+}
+
+func TestOpenAppendFileCreatesMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "new.csv")
+
+	af, err := OpenAppendFile(path)
+	if err != nil {
+		t.Fatalf("OpenAppendFile() error = %v", err)
+	}
+	defer af.Close()
+
+	if af.HasContent {
+		t.Error("Expected new file to have no content")
+	}
+}
+
+func TestAppendMatchesHumanReadableRows(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "transactions.csv")
+	tx := appendTestTx("0xaaa", 1)
+	tx.Amount = "1234.5"
+
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	writer, err := NewCSVWriter(CSVConfig{Writer: file, HumanReadable: true})
+	if err != nil {
+		t.Fatalf("NewCSVWriter() error = %v", err)
+	}
+	if err := writer.WriteTransaction(tx); err != nil {
+		t.Fatalf("WriteTransaction() error = %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	if n := writeAppendBatch(t, path, []*models.Transaction{tx}); n != 0 {
+		t.Errorf("Expected grouped row to be recognized as existing, wrote %d", n)
+	}
+}
+
+func TestAppendWritesSingleHeader(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing string
+		wantRows int
+	}{
+		// A previous export whose header a spreadsheet re-saved with a BOM
+		{name: "bom_headered_file", existing: "\ufeffTransaction Hash,Date & Time,From Address,To Address,Transaction Type,Asset Contract Address,Asset Symbol / Name,Token ID,Value / Amount,Gas Fee (ETH)\n", wantRows: 1},
+		// Non-empty on disk but no rows: still needs a header
+		{name: "blank_file", existing: "\n\n", wantRows: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "transactions.csv")
+			if err := os.WriteFile(path, []byte(tt.existing), 0644); err != nil {
+				t.Fatalf("WriteFile() error = %v", err)
+			}
+
+			if n := writeAppendBatch(t, path, []*models.Transaction{appendTestTx("0xaaa", 1)}); n != tt.wantRows {
+				t.Fatalf("Expected %d new rows, wrote %d", tt.wantRows, n)
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("ReadFile() error = %v", err)
+			}
+			if count := strings.Count(string(data), "Transaction Hash"); count != 1 {
+				t.Errorf("Expected exactly 1 header, got %d:\n%s", count, data)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package output
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// ChecksumSuffix is appended to an output path to name its checksum file
+const ChecksumSuffix = ".sha256"
+
+// FileSHA256 returns the hex-encoded SHA-256 digest of the file at path
+func FileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// WriteChecksumFile writes the SHA-256 digest of the file at path to
+// path+ChecksumSuffix and returns it. The line has the "<digest>  <name>" form
+// sha256sum prints, so `sha256sum -c` verifies the file from its directory.
+func WriteChecksumFile(path string) (string, error) {
+	digest, err := FileSHA256(path)
+	if err != nil {
+		return "", err
+	}
+	line := fmt.Sprintf("%s  %s\n", digest, filepath.Base(path))
+	if err := os.WriteFile(path+ChecksumSuffix, []byte(line), 0644); err != nil {
+		return "", fmt.Errorf("failed to write checksum file: %w", err)
+	}
+	return digest, nil
+}
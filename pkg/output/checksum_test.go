@@ -0,0 +1,36 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteChecksumFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "transactions.csv")
+	if err := os.WriteFile(path, []byte("abc"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	digest, err := WriteChecksumFile(path)
+	if err != nil {
+		t.Fatalf("WriteChecksumFile() error = %v", err)
+	}
+	// SHA-256("abc") from FIPS 180-2
+	want := "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"
+	if digest != want {
+		t.Errorf("Digest mismatch: got %s, want %s", digest, want)
+	}
+
+	data, err := os.ReadFile(path + ChecksumSuffix)
+	if err != nil {
+		t.Fatalf("failed to read checksum file: %v", err)
+	}
+	if got := string(data); got != want+"  transactions.csv\n" {
+		t.Errorf("Checksum file mismatch: got %q", got)
+	}
+
+	if _, err := WriteChecksumFile(filepath.Join(t.TempDir(), "missing.csv")); err == nil {
+		t.Error("Expected error for a missing file, got none")
+	}
+}
@@ -0,0 +1,258 @@
+package output
+
+import (
+	"conintracker-hiring/pkg/models"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Column is an optional CSV column appended after the standard columns
+type Column struct {
+	Name   string // Identifier used to select the column, e.g. on the CLI
+	Header string
+	Value  func(tx *models.Transaction) string
+	Amount bool // Grouped with thousands separators in human-readable output
+	Priced bool // Filled only when a price provider values the export (see pricing.ApplyValueUSD)
+}
+
+// optionalColumns lists every column that can be enabled, in output order
+var optionalColumns = []Column{
+	{
+		Name:   "chain",
+		Header: "Chain",
+		Value:  func(tx *models.Transaction) string { return tx.Chain },
+	},
+	{
+		Name:   "subtype",
+		Header: "Subtype",
+		Value:  func(tx *models.Transaction) string { return tx.Subtype },
+	},
+	{
+		Name:   "asset-name",
+		Header: "Asset Name",
+		Value:  func(tx *models.Transaction) string { return tx.AssetName },
+	},
+	{
+		Name:   "category",
+		Header: "Category",
+		Value:  func(tx *models.Transaction) string { return tx.Category },
+	},
+	{
+		Name:   "unlimited-approval",
+		Header: "Unlimited Approval",
+		Value:  formatUnlimitedApproval,
+	},
+	{
+		Name:   "related-approval",
+		Header: "Related Approval Hash",
+		Value:  func(tx *models.Transaction) string { return tx.RelatedApprovalHash },
+	},
+	{
+		Name:   "amount-whole",
+		Header: "Amount Whole",
+		Value:  func(tx *models.Transaction) string { whole, _ := splitAmount(tx); return whole },
+		Amount: true,
+	},
+	{
+		Name:   "amount-fraction",
+		Header: "Amount Fraction",
+		Value:  func(tx *models.Transaction) string { _, fraction := splitAmount(tx); return fraction },
+	},
+	{
+		Name:   "value-usd",
+		Header: "Value (USD)",
+		Value:  func(tx *models.Transaction) string { return tx.ValueUSD },
+		Amount: true,
+		Priced: true,
+	},
+	{
+		Name:   "parent-function",
+		Header: "Parent Function",
+		Value:  func(tx *models.Transaction) string { return tx.ParentFunction },
+	},
+	{
+		Name:   "error",
+		Header: "Error",
+		Value:  func(tx *models.Transaction) string { return tx.ErrorReason },
+	},
+	{
+		Name:   "block-number",
+		Header: "Block Number",
+		Value:  func(tx *models.Transaction) string { return strconv.FormatUint(tx.BlockNumber, 10) },
+	},
+	{
+		Name:   "gas-used",
+		Header: "Gas Used",
+		Value:  func(tx *models.Transaction) string { return strconv.FormatUint(tx.GasUsed, 10) },
+	},
+	{
+		Name:   "gas-price",
+		Header: "Gas Price (Gwei)",
+		Value:  func(tx *models.Transaction) string { return weiToGwei(tx.GasPrice) },
+	},
+	{
+		Name:   "nonce",
+		Header: "Nonce",
+		Value:  formatNonce,
+	},
+	{
+		Name:   "confirmations",
+		Header: "Confirmations",
+		Value:  func(tx *models.Transaction) string { return formatConfirmations(tx.Confirmations) },
+	},
+}
+
+// SplitAmountColumns are the whole and fractional amount columns enabled together by --split-amount
+var SplitAmountColumns = []string{"amount-whole", "amount-fraction"}
+
+// MetadataColumns are the on-chain metadata columns enabled together by --include-metadata
+var MetadataColumns = []string{"block-number", "gas-used", "gas-price", "nonce"}
+
+// formatNonce renders the sender's nonce for normal transactions; other types
+// don't carry one, so their cell stays empty rather than showing 0
+func formatNonce(tx *models.Transaction) string {
+	if tx.Type != models.TypeEthTransfer {
+		return ""
+	}
+	return strconv.FormatUint(tx.Nonce, 10)
+}
+
+// formatUnlimitedApproval marks approvals of an unlimited allowance, leaving other rows empty
+func formatUnlimitedApproval(tx *models.Transaction) string {
+	if !tx.UnlimitedApproval {
+		return ""
+	}
+	return "true"
+}
+
+// formatConfirmations renders a confirmation count, leaving the cell empty when the
+// provider did not report one (internal transfers, withdrawals)
+func formatConfirmations(n uint64) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.FormatUint(n, 10)
+}
+
+// splitAmount splits a transaction's amount into its whole and fractional digits,
+// exactly: "1234.567" is "1234" and "567", and a whole amount such as "1000.0" has
+// fraction "0". The sign stays on the whole part ("-0.5" is "-0" and "5"). Amounts
+// models.ParseAmount rejects yield empty cells.
+func splitAmount(tx *models.Transaction) (whole, fraction string) {
+	r, err := models.ParseAmount(tx)
+	if err != nil {
+		return "", ""
+	}
+
+	// A decimal amount needs no more fractional digits than it was written with
+	_, written, _ := strings.Cut(strings.TrimSpace(tx.Amount), ".")
+	whole, fraction, _ = strings.Cut(r.FloatString(max(len(written), 1)), ".")
+	if fraction = strings.TrimRight(fraction, "0"); fraction == "" {
+		fraction = "0"
+	}
+	return whole, fraction
+}
+
+// weiPerGwei is 10^9
+var weiPerGwei = big.NewRat(1_000_000_000, 1)
+
+// weiToGwei converts a wei amount to an exact gwei string without trailing zeros.
+// Empty or malformed input yields an empty cell.
+func weiToGwei(wei string) string {
+	r, ok := new(big.Rat).SetString(wei)
+	if !ok {
+		return ""
+	}
+	gwei := r.Quo(r, weiPerGwei).FloatString(9)
+	gwei = strings.TrimRight(gwei, "0")
+	return strings.TrimSuffix(gwei, ".")
+}
+
+// standardHeaders are always written, in this order
+var standardHeaders = []string{
+	"Transaction Hash",
+	"Date & Time",
+	"From Address",
+	"To Address",
+	"Transaction Type",
+	"Asset Contract Address",
+	"Asset Symbol / Name",
+	"Token ID",
+	"Value / Amount",
+	"Gas Fee (ETH)",
+}
+
+// AvailableColumns returns the names of all optional columns
+func AvailableColumns() []string {
+	return columnNames(func(Column) bool { return true })
+}
+
+// UnpricedColumns returns the names of the optional columns that need no price
+// provider, for callers such as the CLI that have none
+func UnpricedColumns() []string {
+	return columnNames(func(col Column) bool { return !col.Priced })
+}
+
+// LookupColumns resolves optional column names. Columns are returned in their
+// canonical order regardless of the order requested, and duplicates are ignored.
+func LookupColumns(names []string) ([]Column, error) {
+	return lookupColumns(names, func(Column) bool { return true })
+}
+
+// LookupUnpricedColumns is LookupColumns restricted to UnpricedColumns
+func LookupUnpricedColumns(names []string) ([]Column, error) {
+	return lookupColumns(names, func(col Column) bool { return !col.Priced })
+}
+
+// columnNames returns the names of the optional columns allowed accepts
+func columnNames(allowed func(Column) bool) []string {
+	names := make([]string, 0, len(optionalColumns))
+	for _, col := range optionalColumns {
+		if allowed(col) {
+			names = append(names, col.Name)
+		}
+	}
+	return names
+}
+
+// lookupColumns resolves names among the optional columns allowed accepts
+func lookupColumns(names []string, allowed func(Column) bool) ([]Column, error) {
+	requested := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		found := false
+		for _, col := range optionalColumns {
+			if col.Name == name && allowed(col) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown column %q (available: %s)", name, strings.Join(columnNames(allowed), ", "))
+		}
+		requested[name] = true
+	}
+
+	var columns []Column
+	for _, col := range optionalColumns {
+		if requested[col.Name] {
+			columns = append(columns, col)
+		}
+	}
+	return columns, nil
+}
+
+// headerWithColumns returns the standard header followed by the optional column headers
+func headerWithColumns(columns []Column) []string {
+	header := make([]string, 0, len(standardHeaders)+len(columns))
+	header = append(header, standardHeaders...)
+	for _, col := range columns {
+		header = append(header, col.Header)
+	}
+	return header
+}
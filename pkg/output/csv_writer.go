@@ -8,36 +8,77 @@ import (
 	"time"
 )
 
-// CSVWriter writes transactions to a CSV file
+// CSVWriter writes transactions to a CSV file. It is not safe for concurrent use.
 type CSVWriter struct {
-	writer *csv.Writer
-	file   io.WriteCloser
+	writer     *csv.Writer
+	file       io.WriteCloser
+	location   *time.Location
+	timeLayout string
+	layout     []Column
+	columns    []Column
+	human      bool
+	sanitize   bool
+	record     []string // Scratch row reused across writes; csv.Writer copies it out
 }
 
 // CSVConfig holds configuration for CSV writing
 type CSVConfig struct {
 	Writer io.WriteCloser
+
+	// OmitHeader skips the header row so outputs can be concatenated (header is written by default)
+	OmitHeader bool
+
+	// Location is the time zone timestamps are rendered in (defaults to UTC)
+	Location *time.Location
+
+	// TimeLayout is the layout timestamps are rendered with (defaults to time.RFC3339;
+	// see LookupTimeFormat)
+	TimeLayout string
+
+	// Layout replaces the standard columns when set (see EtherscanLayout)
+	Layout []Column
+
+	// Columns are optional columns appended after the standard ones (see LookupColumns)
+	Columns []Column
+
+	// HumanReadable groups amounts with thousands separators (1,234.56). Such
+	// fields are quoted, so the file stays valid CSV but is meant for people.
+	HumanReadable bool
+
+	// Sanitize escapes cells that a spreadsheet would run as a formula (see SanitizeCell)
+	Sanitize bool
 }
 
 // NewCSVWriter creates a new CSV writer
 func NewCSVWriter(config CSVConfig) (*CSVWriter, error) {
+	location := config.Location
+	if location == nil {
+		location = time.UTC
+	}
+	timeLayout := config.TimeLayout
+	if timeLayout == "" {
+		timeLayout = time.RFC3339
+	}
+
 	cw := &CSVWriter{
-		writer: csv.NewWriter(config.Writer),
-		file:   config.Writer,
+		writer:     csv.NewWriter(config.Writer),
+		file:       config.Writer,
+		location:   location,
+		timeLayout: timeLayout,
+		layout:     config.Layout,
+		columns:    config.Columns,
+		human:      config.HumanReadable,
+		sanitize:   config.Sanitize,
 	}
 
 	// Write header
-	headers := []string{
-		"Transaction Hash",
-		"Date & Time",
-		"From Address",
-		"To Address",
-		"Transaction Type",
-		"Asset Contract Address",
-		"Asset Symbol / Name",
-		"Token ID",
-		"Value / Amount",
-		"Gas Fee (ETH)",
+	headers := headerWithColumns(cw.columns)
+	if cw.layout != nil {
+		headers = append(layoutHeaders(cw.layout), layoutHeaders(cw.columns)...)
+	}
+
+	if config.OmitHeader {
+		return cw, nil
 	}
 
 	if err := cw.writer.Write(headers); err != nil {
@@ -49,10 +90,19 @@ func NewCSVWriter(config CSVConfig) (*CSVWriter, error) {
 
 // WriteTransaction writes a single transaction to CSV
 func (cw *CSVWriter) WriteTransaction(tx *models.Transaction) error {
-	// Format timestamp as RFC3339 (ISO 8601)
-	timestamp := tx.Timestamp.Format(time.RFC3339)
+	if cw.layout != nil {
+		return cw.writeRecord(tx, cw.record[:0], cw.layout)
+	}
+
+	// Format timestamp as RFC3339 (ISO 8601) unless another layout was chosen
+	timestamp := tx.Timestamp.In(cw.location).Format(cw.timeLayout)
+
+	amount, gasFee := tx.Amount, tx.GasFeeETH
+	if cw.human {
+		amount, gasFee = GroupThousands(amount), GroupThousands(gasFee)
+	}
 
-	record := []string{
+	record := append(cw.record[:0],
 		tx.Hash,
 		timestamp,
 		tx.From,
@@ -61,9 +111,27 @@ func (cw *CSVWriter) WriteTransaction(tx *models.Transaction) error {
 		tx.AssetContractAddress,
 		tx.AssetSymbol,
 		tx.TokenID,
-		tx.Amount,
-		tx.GasFeeETH,
+		amount,
+		gasFee,
+	)
+	return cw.writeRecord(tx, record, nil)
+}
+
+// writeRecord appends the layout and optional column values for tx to record and writes it
+func (cw *CSVWriter) writeRecord(tx *models.Transaction, record []string, layout []Column) error {
+	for _, columns := range [][]Column{layout, cw.columns} {
+		for _, col := range columns {
+			value := col.Value(tx)
+			if cw.human && col.Amount {
+				value = GroupThousands(value)
+			}
+			record = append(record, value)
+		}
+	}
+	if cw.sanitize {
+		sanitizeRecord(record)
 	}
+	cw.record = record // Keep the grown backing array for the next row
 
 	if err := cw.writer.Write(record); err != nil {
 		return fmt.Errorf("failed to write CSV record: %w", err)
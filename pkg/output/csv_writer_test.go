@@ -3,6 +3,8 @@ package output
 import (
 	"conintracker-hiring/pkg/models"
 	"bytes"
+	"encoding/csv"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -257,3 +259,469 @@ func TestEmptyTransactions(t *testing.T) {
 		t.Errorf("Expected only header line, got %d lines", len(lines))
 	}
 }
+
+func TestCSVWriterOmitHeader(t *testing.T) {
+	buf := &WriteCloserBuffer{Buffer: &bytes.Buffer{}}
+	writer, err := NewCSVWriter(CSVConfig{Writer: buf, OmitHeader: true})
+	if err != nil {
+		t.Fatalf("NewCSVWriter() error = %v", err)
+	}
+
+	tx := &models.Transaction{
+		Hash:      "0x1234",
+		Timestamp: time.Unix(1700000000, 0),
+		From:      "0xfrom",
+		To:        "0xto",
+		Type:      models.TypeEthTransfer,
+		Amount:    "1.5",
+		GasFeeETH: "0.001",
+	}
+
+	if err := writer.WriteTransaction(tx); err != nil {
+		t.Fatalf("WriteTransaction() error = %v", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("Expected 1 data line, got %d", len(lines))
+	}
+	if !strings.HasPrefix(lines[0], "0x1234,") {
+		t.Errorf("Expected first line to be data, got %s", lines[0])
+	}
+}
+
+func TestCSVWriterLocation(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("LoadLocation() error = %v", err)
+	}
+
+	buf := &WriteCloserBuffer{Buffer: &bytes.Buffer{}}
+	writer, err := NewCSVWriter(CSVConfig{Writer: buf, Location: newYork})
+	if err != nil {
+		t.Fatalf("NewCSVWriter() error = %v", err)
+	}
+
+	tx := &models.Transaction{
+		Hash:      "0x1234",
+		Timestamp: time.Date(2023, 11, 15, 10, 30, 45, 0, time.UTC),
+		Type:      models.TypeEthTransfer,
+	}
+
+	if err := writer.WriteTransaction(tx); err != nil {
+		t.Fatalf("WriteTransaction() error = %v", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	// November is outside DST, so New York is UTC-5
+	if !strings.Contains(buf.String(), "2023-11-15T05:30:45-05:00") {
+		t.Errorf("Timestamp not rendered in America/New_York. Content: %s", buf.String())
+	}
+}
+
+func TestWritersTimeLayout(t *testing.T) {
+	tx := &models.Transaction{
+		Hash:      "0x1234",
+		Timestamp: time.Date(2023, 11, 15, 10, 30, 45, 123456789, time.UTC),
+		Type:      models.TypeEthTransfer,
+	}
+
+	tests := []struct {
+		name   string
+		layout string
+		want   string
+	}{
+		{name: "default", layout: "", want: "2023-11-15T10:30:45Z"},
+		{name: "rfc3339", layout: time.RFC3339, want: "2023-11-15T10:30:45Z"},
+		{name: "rfc3339nano", layout: time.RFC3339Nano, want: "2023-11-15T10:30:45.123456789Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			csvBuf := &WriteCloserBuffer{Buffer: &bytes.Buffer{}}
+			csvWriter, err := NewCSVWriter(CSVConfig{Writer: csvBuf, OmitHeader: true, TimeLayout: tt.layout})
+			if err != nil {
+				t.Fatalf("NewCSVWriter() error = %v", err)
+			}
+			jsonBuf := &WriteCloserBuffer{Buffer: &bytes.Buffer{}}
+			jsonWriter, err := NewJSONWriter(JSONConfig{Writer: jsonBuf, TimeLayout: tt.layout})
+			if err != nil {
+				t.Fatalf("NewJSONWriter() error = %v", err)
+			}
+
+			for _, w := range []Exporter{csvWriter, jsonWriter} {
+				if err := w.WriteTransaction(tx); err != nil {
+					t.Fatalf("WriteTransaction() error = %v", err)
+				}
+				if err := w.Close(); err != nil {
+					t.Fatalf("Close() error = %v", err)
+				}
+			}
+
+			if got := strings.Split(csvBuf.String(), ",")[1]; got != tt.want {
+				t.Errorf("CSV timestamp mismatch: got %s, want %s", got, tt.want)
+			}
+			if !strings.Contains(jsonBuf.String(), `"timestamp":"`+tt.want+`"`) {
+				t.Errorf("JSON timestamp mismatch: want %s in %s", tt.want, jsonBuf.String())
+			}
+		})
+	}
+}
+
+func TestCSVWriterDefaultsToUTC(t *testing.T) {
+	buf := &WriteCloserBuffer{Buffer: &bytes.Buffer{}}
+	writer, err := NewCSVWriter(CSVConfig{Writer: buf})
+	if err != nil {
+		t.Fatalf("NewCSVWriter() error = %v", err)
+	}
+
+	tx := &models.Transaction{
+		Hash:      "0x1234",
+		Timestamp: time.Unix(1700000000, 0),
+		Type:      models.TypeEthTransfer,
+	}
+
+	if err := writer.WriteTransaction(tx); err != nil {
+		t.Fatalf("WriteTransaction() error = %v", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "2023-11-14T22:13:20Z") {
+		t.Errorf("Timestamp not rendered in UTC. Content: %s", buf.String())
+	}
+}
+
+func TestCSVWriterOptionalColumns(t *testing.T) {
+	columns, err := LookupColumns([]string{"subtype"})
+	if err != nil {
+		t.Fatalf("LookupColumns() error = %v", err)
+	}
+
+	buf := &WriteCloserBuffer{Buffer: &bytes.Buffer{}}
+	writer, err := NewCSVWriter(CSVConfig{Writer: buf, Columns: columns})
+	if err != nil {
+		t.Fatalf("NewCSVWriter() error = %v", err)
+	}
+
+	tx := &models.Transaction{
+		Hash:      "0x1234",
+		Timestamp: time.Unix(1700000000, 0),
+		From:      models.ZeroAddress,
+		To:        "0xto",
+		Type:      models.TypeERC20Transfer,
+		Subtype:   models.SubtypeMint,
+	}
+
+	if err := writer.WriteTransaction(tx); err != nil {
+		t.Fatalf("WriteTransaction() error = %v", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if !strings.HasSuffix(lines[0], ",Subtype") {
+		t.Errorf("Subtype header missing: %s", lines[0])
+	}
+	if !strings.HasSuffix(lines[1], ",Mint") {
+		t.Errorf("Subtype value missing: %s", lines[1])
+	}
+}
+
+func TestCSVWriterMetadataColumns(t *testing.T) {
+	columns, err := LookupColumns(MetadataColumns)
+	if err != nil {
+		t.Fatalf("LookupColumns() error = %v", err)
+	}
+
+	buf := &WriteCloserBuffer{Buffer: &bytes.Buffer{}}
+	writer, err := NewCSVWriter(CSVConfig{Writer: buf, Columns: columns})
+	if err != nil {
+		t.Fatalf("NewCSVWriter() error = %v", err)
+	}
+
+	tx := &models.Transaction{
+		Hash:        "0x1234",
+		Timestamp:   time.Unix(1700000000, 0),
+		From:        "0xfrom",
+		To:          "0xto",
+		Type:        models.TypeEthTransfer,
+		BlockNumber: 19999999,
+		GasUsed:     21000,
+		GasPrice:    "50000000000",
+		Nonce:       42,
+	}
+
+	if err := writer.WriteTransaction(tx); err != nil {
+		t.Fatalf("WriteTransaction() error = %v", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if !strings.HasSuffix(lines[0], ",Block Number,Gas Used,Gas Price (Gwei),Nonce") {
+		t.Errorf("Metadata headers missing: %s", lines[0])
+	}
+	if !strings.HasSuffix(lines[1], ",19999999,21000,50,42") {
+		t.Errorf("Metadata values mismatch: %s", lines[1])
+	}
+}
+
+func TestCSVWriterAssetNameColumn(t *testing.T) {
+	columns, err := LookupColumns([]string{"asset-name"})
+	if err != nil {
+		t.Fatalf("LookupColumns() error = %v", err)
+	}
+
+	buf := &WriteCloserBuffer{Buffer: &bytes.Buffer{}}
+	writer, err := NewCSVWriter(CSVConfig{Writer: buf, Columns: columns})
+	if err != nil {
+		t.Fatalf("NewCSVWriter() error = %v", err)
+	}
+
+	tx := &models.Transaction{
+		Hash:        "0x1234",
+		Timestamp:   time.Unix(1700000000, 0),
+		Type:        models.TypeERC20Transfer,
+		AssetSymbol: "USDC",
+		AssetName:   "USD Coin",
+	}
+
+	if err := writer.WriteTransaction(tx); err != nil {
+		t.Fatalf("WriteTransaction() error = %v", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if !strings.HasSuffix(lines[0], ",Asset Name") {
+		t.Errorf("Asset Name header missing: %s", lines[0])
+	}
+	if !strings.Contains(lines[1], ",USDC,") {
+		t.Errorf("Asset symbol missing: %s", lines[1])
+	}
+	if !strings.HasSuffix(lines[1], ",USD Coin") {
+		t.Errorf("Asset name missing: %s", lines[1])
+	}
+}
+
+func TestLookupColumnsRejectsUnknown(t *testing.T) {
+	if _, err := LookupColumns([]string{"nope"}); err == nil {
+		t.Error("Expected error for unknown column, got none")
+	}
+}
+
+func TestLookupUnpricedColumnsHidesPricedColumns(t *testing.T) {
+	if _, err := LookupUnpricedColumns([]string{"value-usd"}); err == nil {
+		t.Error("Expected value-usd to be rejected without a price provider")
+	}
+	if slices.Contains(UnpricedColumns(), "value-usd") {
+		t.Errorf("Expected value-usd to be hidden, got %v", UnpricedColumns())
+	}
+
+	columns, err := LookupUnpricedColumns([]string{"chain"})
+	if err != nil || len(columns) != 1 {
+		t.Errorf("Expected the chain column, got %v (error %v)", columns, err)
+	}
+}
+
+func TestCSVWriterHumanReadable(t *testing.T) {
+	columns, err := LookupColumns([]string{"value-usd"})
+	if err != nil {
+		t.Fatalf("LookupColumns() error = %v", err)
+	}
+
+	buf := &WriteCloserBuffer{Buffer: &bytes.Buffer{}}
+	writer, err := NewCSVWriter(CSVConfig{Writer: buf, Columns: columns, HumanReadable: true})
+	if err != nil {
+		t.Fatalf("NewCSVWriter() error = %v", err)
+	}
+
+	tx := &models.Transaction{
+		Hash:      "0x1234",
+		Timestamp: time.Unix(1700000000, 0),
+		Type:      models.TypeEthTransfer,
+		Amount:    "1234567.89",
+		GasFeeETH: "0.00042",
+		ValueUSD:  "2469135780.00",
+	}
+	if err := writer.WriteTransaction(tx); err != nil {
+		t.Fatalf("WriteTransaction() error = %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	if !strings.Contains(buf.String(), `,"1,234,567.89",0.00042,"2,469,135,780.00"`) {
+		t.Errorf("Expected grouped, quoted amounts, got: %s", buf.String())
+	}
+
+	records, err := csv.NewReader(strings.NewReader(buf.String())).ReadAll()
+	if err != nil {
+		t.Fatalf("human-readable output is not valid CSV: %v", err)
+	}
+	if got := records[1][8]; got != "1,234,567.89" {
+		t.Errorf("Amount mismatch: got %s, want 1,234,567.89", got)
+	}
+}
+
+func TestCSVWriterSanitize(t *testing.T) {
+	columns, err := LookupColumns([]string{"asset-name"})
+	if err != nil {
+		t.Fatalf("LookupColumns() error = %v", err)
+	}
+
+	tx := &models.Transaction{
+		Hash:        "0x1234",
+		Timestamp:   time.Unix(1700000000, 0),
+		Type:        models.TypeERC20Transfer,
+		AssetSymbol: "=cmd",
+		AssetName:   "@SUM(A1:A9)",
+		Amount:      "-1.5",
+		GasFeeETH:   "0",
+	}
+
+	tests := []struct {
+		name       string
+		sanitize   bool
+		wantSymbol string
+		wantName   string
+	}{
+		{"on", true, "'=cmd", "'@SUM(A1:A9)"},
+		{"off", false, "=cmd", "@SUM(A1:A9)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &WriteCloserBuffer{Buffer: &bytes.Buffer{}}
+			writer, err := NewCSVWriter(CSVConfig{Writer: buf, Columns: columns, Sanitize: tt.sanitize})
+			if err != nil {
+				t.Fatalf("NewCSVWriter() error = %v", err)
+			}
+			if err := writer.WriteTransaction(tx); err != nil {
+				t.Fatalf("WriteTransaction() error = %v", err)
+			}
+			if err := writer.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+
+			records, err := csv.NewReader(strings.NewReader(buf.String())).ReadAll()
+			if err != nil {
+				t.Fatalf("failed to parse CSV: %v", err)
+			}
+			if got := records[1][6]; got != tt.wantSymbol {
+				t.Errorf("Symbol mismatch: got %s, want %s", got, tt.wantSymbol)
+			}
+			if got := records[1][10]; got != tt.wantName {
+				t.Errorf("Asset name mismatch: got %s, want %s", got, tt.wantName)
+			}
+			if got := records[1][8]; got != "-1.5" {
+				t.Errorf("Amount mismatch: got %s, want -1.5", got)
+			}
+		})
+	}
+}
+
+func TestWeiToGwei(t *testing.T) {
+	tests := []struct {
+		wei  string
+		want string
+	}{
+		{"50000000000", "50"},
+		{"1500000000", "1.5"},
+		{"1", "0.000000001"},
+		{"0", "0"},
+		{"", ""},
+		{"not-a-number", ""},
+	}
+
+	for _, tt := range tests {
+		if got := weiToGwei(tt.wei); got != tt.want {
+			t.Errorf("weiToGwei(%q) mismatch: got %q, want %q", tt.wei, got, tt.want)
+		}
+	}
+}
+
+func TestSplitAmount(t *testing.T) {
+	tests := []struct {
+		amount   string
+		whole    string
+		fraction string
+	}{
+		{"1234.567", "1234", "567"},
+		{"1000.0", "1000", "0"},
+		{"1000", "1000", "0"},
+		{"0.000000000000000001", "0", "000000000000000001"},
+		{"-1.5", "-1", "5"},
+		{"-0.25", "-0", "25"},
+		{"1e-3", "", ""},
+		{"1/3", "", ""},
+		{"", "", ""},
+		{"not-a-number", "", ""},
+	}
+
+	for _, tt := range tests {
+		whole, fraction := splitAmount(&models.Transaction{Type: models.TypeERC20Transfer, Amount: tt.amount})
+		if whole != tt.whole || fraction != tt.fraction {
+			t.Errorf("splitAmount(%q) mismatch: got %q %q, want %q %q", tt.amount, whole, fraction, tt.whole, tt.fraction)
+		}
+	}
+}
+
+// discardCloser is a WriteCloser that drops everything written to it
+type discardCloser struct{}
+
+func (discardCloser) Write(p []byte) (int, error) { return len(p), nil }
+func (discardCloser) Close() error                { return nil }
+
+// BenchmarkCSVWriter100k measures per-record allocations writing 100k rows; run with -benchmem
+func BenchmarkCSVWriter100k(b *testing.B) {
+	txs := make([]*models.Transaction, 100000)
+	for i := range txs {
+		txs[i] = &models.Transaction{
+			Hash:        "0xabc123def4567890abc123def4567890abc123def4567890abc123def4567890",
+			Timestamp:   time.Unix(1700000000+int64(i), 0),
+			From:        "0x1111111111111111111111111111111111111111",
+			To:          "0x2222222222222222222222222222222222222222",
+			Type:        models.TypeEthTransfer,
+			AssetSymbol: "ETH",
+			Amount:      "1.5",
+			GasFeeETH:   "0.00042",
+		}
+	}
+
+	columns, err := LookupColumns([]string{"chain", "category"})
+	if err != nil {
+		b.Fatalf("LookupColumns() error = %v", err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		writer, err := NewCSVWriter(CSVConfig{Writer: discardCloser{}, Columns: columns})
+		if err != nil {
+			b.Fatalf("NewCSVWriter() error = %v", err)
+		}
+		if err := writer.WriteTransactions(txs); err != nil {
+			b.Fatalf("WriteTransactions() error = %v", err)
+		}
+		if err := writer.Close(); err != nil {
+			b.Fatalf("Close() error = %v", err)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package output
+
+import "strings"
+
+// GroupThousands inserts comma separators into the integer part of a decimal
+// string, e.g. "1234567.89" becomes "1,234,567.89". Values that are not plain
+// decimals (empty, already grouped, scientific notation) are returned unchanged.
+func GroupThousands(value string) string {
+	sign := ""
+	digits := value
+	if strings.HasPrefix(digits, "-") || strings.HasPrefix(digits, "+") {
+		sign, digits = digits[:1], digits[1:]
+	}
+
+	intPart, fraction := digits, ""
+	if dot := strings.IndexByte(digits, '.'); dot >= 0 {
+		intPart, fraction = digits[:dot], digits[dot:]
+	}
+	if intPart == "" || !isDigits(intPart) || (len(fraction) > 1 && !isDigits(fraction[1:])) {
+		return value
+	}
+	if len(intPart) <= 3 {
+		return value
+	}
+
+	var b strings.Builder
+	b.Grow(len(value) + len(intPart)/3)
+	b.WriteString(sign)
+	lead := len(intPart) % 3
+	if lead == 0 {
+		lead = 3
+	}
+	b.WriteString(intPart[:lead])
+	for i := lead; i < len(intPart); i += 3 {
+		b.WriteByte(',')
+		b.WriteString(intPart[i : i+3])
+	}
+	b.WriteString(fraction)
+	return b.String()
+}
+
+// isDigits reports whether s consists only of ASCII digits
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,30 @@
+package output
+
+import "testing"
+
+func TestGroupThousands(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"1234567.89", "1,234,567.89"},
+		{"1234", "1,234"},
+		{"123", "123"},
+		{"123456", "123,456"},
+		{"0.000021", "0.000021"},
+		{"-9876543.21", "-9,876,543.21"},
+		{"1000000000000000000000", "1,000,000,000,000,000,000,000"},
+		{"", ""},
+		{"1,234", "1,234"},
+		{"1e18", "1e18"},
+		{"abc", "abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := GroupThousands(tt.input); got != tt.want {
+				t.Errorf("GroupThousands(%q) mismatch: got %s, want %s", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package output
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// utf8BOM is the byte order mark spreadsheet tools may prepend when re-saving a CSV
+const utf8BOM = "\ufeff"
+
+// HeaderState describes how an existing export begins
+type HeaderState int
+
+const (
+	HeaderEmpty   HeaderState = iota // No content (or only blank lines): a header should be written
+	HeaderPresent                    // First line is an export header
+	HeaderMissing                    // First line is data, e.g. from a --no-header run
+)
+
+// DetectHeader inspects the first non-blank line of r to decide whether it
+// starts with an export header
+func DetectHeader(r io.Reader) (HeaderState, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), utf8BOM))
+		if line == "" {
+			continue
+		}
+		if isHeaderLine(line) {
+			return HeaderPresent, nil
+		}
+		return HeaderMissing, nil
+	}
+	return HeaderEmpty, scanner.Err()
+}
+
+// NeedsHeader reports whether writing to path should start with a header: only when
+// the file does not exist yet or holds nothing. A file that already has rows never
+// gets a second header, whether or not it started with one.
+func NeedsHeader(path string) (bool, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	state, err := DetectHeader(file)
+	if err != nil {
+		return false, err
+	}
+	return state == HeaderEmpty, nil
+}
+
+// needsHeaderFor reports whether output to w should start with a header. A writer
+// backed by a regular file, such as *os.File or *AppendFile, defers to NeedsHeader
+// on that file; any other writer starts out empty and needs one.
+func needsHeaderFor(w io.Writer) (bool, error) {
+	file, ok := w.(interface {
+		Name() string
+		Stat() (fs.FileInfo, error)
+	})
+	if !ok {
+		return true, nil
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return false, err
+	}
+	if !info.Mode().IsRegular() {
+		return true, nil
+	}
+	return NeedsHeader(file.Name())
+}
+
+// isHeaderLine reports whether a CSV line is an export header row
+func isHeaderLine(line string) bool {
+	first := line
+	if comma := strings.IndexByte(line, ','); comma >= 0 {
+		first = line[:comma]
+	}
+	return strings.Trim(first, `"`) == standardHeaders[0]
+}
@@ -0,0 +1,71 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDetectHeader(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    HeaderState
+	}{
+		{name: "empty", content: "", want: HeaderEmpty},
+		{name: "blank_lines", content: "\n  \n\r\n", want: HeaderEmpty},
+		{name: "header", content: "Transaction Hash,Date & Time\n0x1,2024\n", want: HeaderPresent},
+		{name: "quoted_header", content: "\"Transaction Hash\",\"Date & Time\"\n", want: HeaderPresent},
+		{name: "bom_header", content: "\ufeffTransaction Hash,Date & Time\n", want: HeaderPresent},
+		{name: "header_after_blank", content: "\nTransaction Hash,Date & Time\n", want: HeaderPresent},
+		{name: "data_only", content: "0x1,2024-01-01T00:00:00Z\n", want: HeaderMissing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectHeader(strings.NewReader(tt.content))
+			if err != nil {
+				t.Fatalf("DetectHeader() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("DetectHeader mismatch: got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNeedsHeader(t *testing.T) {
+	dir := t.TempDir()
+
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+		return path
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{name: "missing", path: filepath.Join(dir, "missing.csv"), want: true},
+		{name: "blank", path: write("blank.csv", "\n\n"), want: true},
+		{name: "headered", path: write("headered.csv", "Transaction Hash,Date & Time\n"), want: false},
+		{name: "headerless", path: write("headerless.csv", "0x1,2024\n"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NeedsHeader(tt.path)
+			if err != nil {
+				t.Fatalf("NeedsHeader() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("NeedsHeader mismatch: got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package output
+
+import (
+	"bufio"
+	"conintracker-hiring/pkg/models"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Output formats selectable for an export
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+)
+
+// Formats lists the supported output formats
+var Formats = []string{FormatCSV, FormatJSON}
+
+// JSONRecord is one transaction in a JSON export. Its fields mirror the standard
+// CSV columns; optional columns are keyed by column name under Columns.
+type JSONRecord struct {
+	Hash                 string            `json:"hash"`
+	Timestamp            string            `json:"timestamp"`
+	From                 string            `json:"from"`
+	To                   string            `json:"to"`
+	Type                 string            `json:"type"`
+	AssetContractAddress string            `json:"asset_contract_address"`
+	AssetSymbol          string            `json:"asset_symbol"`
+	TokenID              string            `json:"token_id"`
+	Amount               string            `json:"amount"`
+	GasFeeETH            string            `json:"gas_fee_eth"`
+	Columns              map[string]string `json:"columns,omitempty"`
+}
+
+// JSONConfig holds configuration for JSON writing
+type JSONConfig struct {
+	Writer io.WriteCloser
+
+	// Location is the time zone timestamps are rendered in (defaults to UTC)
+	Location *time.Location
+
+	// TimeLayout is the layout timestamps are rendered with (defaults to time.RFC3339)
+	TimeLayout string
+
+	// Columns are optional columns included under each record's Columns
+	Columns []Column
+}
+
+// JSONWriter writes transactions as a JSON array, one record per line
+type JSONWriter struct {
+	buf        *bufio.Writer
+	file       io.WriteCloser
+	location   *time.Location
+	timeLayout string
+	columns    []Column
+	count      int
+}
+
+// NewJSONWriter creates a new JSON writer
+func NewJSONWriter(config JSONConfig) (*JSONWriter, error) {
+	location := config.Location
+	if location == nil {
+		location = time.UTC
+	}
+	timeLayout := config.TimeLayout
+	if timeLayout == "" {
+		timeLayout = time.RFC3339
+	}
+
+	jw := &JSONWriter{
+		buf:        bufio.NewWriter(config.Writer),
+		file:       config.Writer,
+		location:   location,
+		timeLayout: timeLayout,
+		columns:    config.Columns,
+	}
+	if _, err := jw.buf.WriteString("["); err != nil {
+		return nil, fmt.Errorf("failed to write JSON array: %w", err)
+	}
+	return jw, nil
+}
+
+// WriteTransaction writes a single transaction as a JSON record
+func (jw *JSONWriter) WriteTransaction(tx *models.Transaction) error {
+	record := JSONRecord{
+		Hash:                 tx.Hash,
+		Timestamp:            tx.Timestamp.In(jw.location).Format(jw.timeLayout),
+		From:                 tx.From,
+		To:                   tx.To,
+		Type:                 string(tx.Type),
+		AssetContractAddress: tx.AssetContractAddress,
+		AssetSymbol:          tx.AssetSymbol,
+		TokenID:              tx.TokenID,
+		Amount:               tx.Amount,
+		GasFeeETH:            tx.GasFeeETH,
+	}
+	if len(jw.columns) > 0 {
+		record.Columns = make(map[string]string, len(jw.columns))
+		for _, col := range jw.columns {
+			record.Columns[col.Name] = col.Value(tx)
+		}
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode JSON record: %w", err)
+	}
+
+	separator := ",\n"
+	if jw.count == 0 {
+		separator = "\n"
+	}
+	jw.count++
+	if _, err := jw.buf.WriteString(separator); err != nil {
+		return fmt.Errorf("failed to write JSON record: %w", err)
+	}
+	if _, err := jw.buf.Write(data); err != nil {
+		return fmt.Errorf("failed to write JSON record: %w", err)
+	}
+	return nil
+}
+
+// WriteTransactions writes multiple transactions as JSON records
+func (jw *JSONWriter) WriteTransactions(txs []*models.Transaction) error {
+	for _, tx := range txs {
+		if err := jw.WriteTransaction(tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close terminates the array, flushes the writer and closes the file
+func (jw *JSONWriter) Close() error {
+	if _, err := jw.buf.WriteString("\n]\n"); err != nil {
+		return fmt.Errorf("JSON writer error: %w", err)
+	}
+	if err := jw.buf.Flush(); err != nil {
+		return fmt.Errorf("JSON writer error: %w", err)
+	}
+	return jw.file.Close()
+}
@@ -0,0 +1,66 @@
+package output
+
+import (
+	"bytes"
+	"conintracker-hiring/pkg/models"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestJSONWriter(t *testing.T) {
+	columns, err := LookupColumns([]string{"chain"})
+	if err != nil {
+		t.Fatalf("LookupColumns() error = %v", err)
+	}
+
+	buf := &WriteCloserBuffer{Buffer: &bytes.Buffer{}}
+	writer, err := NewJSONWriter(JSONConfig{Writer: buf, Columns: columns})
+	if err != nil {
+		t.Fatalf("NewJSONWriter() error = %v", err)
+	}
+
+	txs := []*models.Transaction{
+		{Hash: "0x1", Timestamp: time.Unix(1700000000, 0), Type: models.TypeEthTransfer, AssetSymbol: "ETH", Amount: "1.5", GasFeeETH: "0.00042", Chain: "ethereum"},
+		{Hash: "0x2", Timestamp: time.Unix(1700000060, 0), Type: models.TypeERC20Transfer, AssetSymbol: "USDC", Amount: "2500", GasFeeETH: "0", Chain: "ethereum"},
+	}
+	if err := writer.WriteTransactions(txs); err != nil {
+		t.Fatalf("WriteTransactions() error = %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	var records []JSONRecord
+	if err := json.Unmarshal(buf.Bytes(), &records); err != nil {
+		t.Fatalf("output is not a valid JSON array: %v\n%s", err, buf.String())
+	}
+	if len(records) != len(txs) {
+		t.Fatalf("Expected %d records, got %d", len(txs), len(records))
+	}
+	if records[1].AssetSymbol != "USDC" || records[1].Amount != "2500" {
+		t.Errorf("Record mismatch: got %+v", records[1])
+	}
+	if records[0].Timestamp != "2023-11-14T22:13:20Z" {
+		t.Errorf("Timestamp mismatch: got %s, want 2023-11-14T22:13:20Z", records[0].Timestamp)
+	}
+	if records[0].Columns["chain"] != "ethereum" {
+		t.Errorf("Chain column mismatch: got %s, want ethereum", records[0].Columns["chain"])
+	}
+}
+
+func TestJSONWriterEmpty(t *testing.T) {
+	buf := &WriteCloserBuffer{Buffer: &bytes.Buffer{}}
+	writer, err := NewJSONWriter(JSONConfig{Writer: buf})
+	if err != nil {
+		t.Fatalf("NewJSONWriter() error = %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	var records []JSONRecord
+	if err := json.Unmarshal(buf.Bytes(), &records); err != nil || len(records) != 0 {
+		t.Errorf("Expected an empty JSON array, got %q (err %v)", buf.String(), err)
+	}
+}
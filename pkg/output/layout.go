@@ -0,0 +1,82 @@
+package output
+
+import (
+	"conintracker-hiring/pkg/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Column layouts accepted by --layout
+const (
+	LayoutDefault   = "default"   // Standard columns followed by any optional ones
+	LayoutEtherscan = "etherscan" // Etherscan's "Download CSV Export" columns, see EtherscanLayout
+)
+
+// Layouts lists the supported column layouts
+var Layouts = []string{LayoutDefault, LayoutEtherscan}
+
+// EtherscanLayout returns the columns of Etherscan's own CSV export, so files can
+// replace one downloaded from Etherscan. Value is split into Value_IN(ETH) and
+// Value_OUT(ETH) by whether owner received or sent it. Like Etherscan, both are 0
+// for token transfers, which move no native value. CurrentValue is left empty
+// because only historical prices are known.
+func EtherscanLayout(owner string) []Column {
+	return []Column{
+		{Name: "txhash", Header: "Txhash", Value: func(tx *models.Transaction) string { return tx.Hash }},
+		{Name: "blockno", Header: "Blockno", Value: func(tx *models.Transaction) string { return strconv.FormatUint(tx.BlockNumber, 10) }},
+		{Name: "unix-timestamp", Header: "UnixTimestamp", Value: func(tx *models.Transaction) string { return strconv.FormatInt(tx.Timestamp.Unix(), 10) }},
+		{Name: "datetime", Header: "DateTime (UTC)", Value: func(tx *models.Transaction) string { return tx.Timestamp.UTC().Format(time.DateTime) }},
+		{Name: "from", Header: "From", Value: func(tx *models.Transaction) string { return tx.From }},
+		{Name: "to", Header: "To", Value: func(tx *models.Transaction) string { return tx.To }},
+		{Name: "contract-address", Header: "ContractAddress", Value: func(tx *models.Transaction) string { return tx.AssetContractAddress }},
+		{Name: "value-in", Header: "Value_IN(ETH)", Amount: true, Value: func(tx *models.Transaction) string { return nativeValue(tx, owner, false) }},
+		{Name: "value-out", Header: "Value_OUT(ETH)", Amount: true, Value: func(tx *models.Transaction) string { return nativeValue(tx, owner, true) }},
+		{Name: "current-value", Header: "CurrentValue", Value: func(tx *models.Transaction) string { return "" }},
+		{Name: "txn-fee", Header: "TxnFee(ETH)", Amount: true, Value: func(tx *models.Transaction) string { return tx.GasFeeETH }},
+		{Name: "status", Header: "Status", Value: etherscanStatus},
+		{Name: "method", Header: "Method", Value: etherscanMethod},
+	}
+}
+
+// nativeValue returns tx's native amount for the IN (outgoing false) or OUT column,
+// and "0" when it belongs in the other one. Sends from owner are OUT, receipts IN.
+func nativeValue(tx *models.Transaction, owner string, outgoing bool) string {
+	switch tx.Type {
+	case models.TypeEthTransfer, models.TypeInternal, models.TypeBeaconWithdrawal, models.TypeContractCreate:
+	default:
+		return "0"
+	}
+
+	sent := strings.EqualFold(tx.From, owner)
+	received := !sent && strings.EqualFold(tx.To, owner)
+	if (outgoing && sent) || (!outgoing && received) {
+		return tx.Amount
+	}
+	return "0"
+}
+
+// etherscanStatus is empty for successful transactions and "Error(0)" for reverted ones
+func etherscanStatus(tx *models.Transaction) string {
+	if tx.IsError {
+		return "Error(0)"
+	}
+	return ""
+}
+
+// etherscanMethod is the called function's name without its parameters, or the selector when unnamed
+func etherscanMethod(tx *models.Transaction) string {
+	if name, _, _ := strings.Cut(tx.FunctionName, "("); name != "" {
+		return name
+	}
+	return tx.MethodID
+}
+
+// layoutHeaders returns the headers of columns, in order
+func layoutHeaders(columns []Column) []string {
+	headers := make([]string, 0, len(columns))
+	for _, col := range columns {
+		headers = append(headers, col.Header)
+	}
+	return headers
+}
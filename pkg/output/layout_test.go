@@ -0,0 +1,74 @@
+package output
+
+import (
+	"bytes"
+	"conintracker-hiring/pkg/models"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCSVWriterEtherscanLayout(t *testing.T) {
+	owner := "0xA39b189482f984388a34460636fea9eb181ad1a6"
+	other := "0x1111111111111111111111111111111111111111"
+
+	buf := &WriteCloserBuffer{Buffer: &bytes.Buffer{}}
+	writer, err := NewCSVWriter(CSVConfig{Writer: buf, Layout: EtherscanLayout(owner)})
+	if err != nil {
+		t.Fatalf("NewCSVWriter() error = %v", err)
+	}
+
+	txs := []*models.Transaction{
+		{Hash: "0xin", Timestamp: time.Unix(1700000000, 0), BlockNumber: 18573000, From: other, To: strings.ToLower(owner), Type: models.TypeEthTransfer, Amount: "1.5", GasFeeETH: "0.00042"},
+		{Hash: "0xout", Timestamp: time.Unix(1700000060, 0), BlockNumber: 18573005, From: strings.ToLower(owner), To: other, Type: models.TypeEthTransfer, Amount: "0.25", GasFeeETH: "0.00042", IsError: true, FunctionName: "deposit()"},
+		{Hash: "0xtoken", Timestamp: time.Unix(1700000120, 0), From: other, To: owner, Type: models.TypeERC20Transfer, AssetContractAddress: "0xusdc", Amount: "100", MethodID: "0xa9059cbb"},
+	}
+	if err := writer.WriteTransactions(txs); err != nil {
+		t.Fatalf("WriteTransactions() error = %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	records, err := csv.NewReader(buf).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse CSV: %v", err)
+	}
+
+	wantHeader := "Txhash,Blockno,UnixTimestamp,DateTime (UTC),From,To,ContractAddress,Value_IN(ETH),Value_OUT(ETH),CurrentValue,TxnFee(ETH),Status,Method"
+	if got := strings.Join(records[0], ","); got != wantHeader {
+		t.Fatalf("Header mismatch:\ngot  %s\nwant %s", got, wantHeader)
+	}
+
+	tests := []struct {
+		name    string
+		row     []string
+		wantIn  string
+		wantOut string
+	}{
+		{name: "incoming", row: records[1], wantIn: "1.5", wantOut: "0"},
+		{name: "outgoing", row: records[2], wantIn: "0", wantOut: "0.25"},
+		{name: "token_moves_no_eth", row: records[3], wantIn: "0", wantOut: "0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.row[7] != tt.wantIn {
+				t.Errorf("Value_IN mismatch: got %s, want %s", tt.row[7], tt.wantIn)
+			}
+			if tt.row[8] != tt.wantOut {
+				t.Errorf("Value_OUT mismatch: got %s, want %s", tt.row[8], tt.wantOut)
+			}
+		})
+	}
+
+	if got := strings.Join(records[1][:4], ","); got != "0xin,18573000,1700000000,2023-11-14 22:13:20" {
+		t.Errorf("Incoming row prefix mismatch: got %s", got)
+	}
+	if records[2][11] != "Error(0)" || records[2][12] != "deposit" {
+		t.Errorf("Outgoing status/method mismatch: got %s, %s", records[2][11], records[2][12])
+	}
+	if records[3][6] != "0xusdc" || records[3][12] != "0xa9059cbb" {
+		t.Errorf("Token contract/method mismatch: got %s, %s", records[3][6], records[3][12])
+	}
+}
@@ -0,0 +1,65 @@
+package output
+
+import (
+	"conintracker-hiring/pkg/analysis"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"time"
+)
+
+// lotHeaders are the columns of a cost-basis lot report
+var lotHeaders = []string{
+	"Asset",
+	"Contract",
+	"Quantity",
+	"Acquired",
+	"Acquired Hash",
+	"Disposed",
+	"Disposed Hash",
+	"Proceeds (USD)",
+	"Cost Basis (USD)",
+	"Gain (USD)",
+}
+
+// WriteLotsCSV writes a cost-basis report's lots to w, one row per lot, with dates
+// in timeLayout (RFC3339 when empty). Disposals with no matching acquisition get
+// empty acquisition and cost columns.
+func WriteLotsCSV(w io.Writer, lots []analysis.RealizedLot, timeLayout string) error {
+	if timeLayout == "" {
+		timeLayout = time.RFC3339
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(lotHeaders); err != nil {
+		return fmt.Errorf("failed to write lot headers: %w", err)
+	}
+
+	for _, lot := range lots {
+		acquired := ""
+		if !lot.Acquired.IsZero() {
+			acquired = lot.Acquired.UTC().Format(timeLayout)
+		}
+		row := []string{
+			lot.Asset,
+			lot.Contract,
+			lot.Quantity,
+			acquired,
+			lot.AcquiredHash,
+			lot.Disposed.UTC().Format(timeLayout),
+			lot.DisposedHash,
+			lot.Proceeds,
+			lot.CostBasis,
+			lot.Gain,
+		}
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write lot: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to flush lots: %w", err)
+	}
+	return nil
+}
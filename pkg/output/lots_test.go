@@ -0,0 +1,32 @@
+package output
+
+import (
+	"bytes"
+	"conintracker-hiring/pkg/analysis"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteLotsCSV(t *testing.T) {
+	acquired := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	disposed := time.Date(2024, time.January, 3, 12, 0, 0, 0, time.UTC)
+	lots := []analysis.RealizedLot{
+		{Asset: "ETH", Quantity: "1", Acquired: acquired, AcquiredHash: "0xbuy", Disposed: disposed, DisposedHash: "0xsell", Proceeds: "3000.00", CostBasis: "1000.00", Gain: "2000.00"},
+		{Asset: "ETH", Quantity: "0.5", Disposed: disposed, DisposedHash: "0xsell", Proceeds: "1500.00"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteLotsCSV(&buf, lots, ""); err != nil {
+		t.Fatalf("WriteLotsCSV() error = %v", err)
+	}
+
+	want := strings.Join([]string{
+		"Asset,Contract,Quantity,Acquired,Acquired Hash,Disposed,Disposed Hash,Proceeds (USD),Cost Basis (USD),Gain (USD)",
+		"ETH,,1,2024-01-01T12:00:00Z,0xbuy,2024-01-03T12:00:00Z,0xsell,3000.00,1000.00,2000.00",
+		"ETH,,0.5,,,2024-01-03T12:00:00Z,0xsell,1500.00,,",
+	}, "\n") + "\n"
+	if buf.String() != want {
+		t.Errorf("CSV mismatch:\ngot:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
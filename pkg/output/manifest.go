@@ -0,0 +1,69 @@
+package output
+
+import (
+	"conintracker-hiring/pkg/models"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Manifest describes an export for auditing; it is written as a JSON sidecar
+type Manifest struct {
+	Addresses   []string          `json:"addresses"`
+	Chain       string            `json:"chain"`
+	Provider    string            `json:"provider"`
+	Output      string            `json:"output"`
+	StartBlock  uint64            `json:"start_block,omitempty"`
+	EndBlock    uint64            `json:"end_block,omitempty"`
+	StartTime   *time.Time        `json:"start_time,omitempty"`
+	EndTime     *time.Time        `json:"end_time,omitempty"`
+	Filters     map[string]string `json:"filters,omitempty"` // Options applied to the export, by flag name
+	Counts      map[string]int    `json:"counts"`            // Exported rows per transaction type
+	Total       int               `json:"total"`
+	ToolVersion string            `json:"tool_version"`
+	ExportedAt  time.Time         `json:"exported_at"`
+}
+
+// NewManifest builds a manifest for the exported transactions, filling in the
+// block and date range and the per-type counts. Callers set the descriptive fields.
+func NewManifest(txs []*models.Transaction) *Manifest {
+	m := &Manifest{
+		Counts:     make(map[string]int),
+		Total:      len(txs),
+		ExportedAt: time.Now().UTC(),
+	}
+
+	for _, tx := range txs {
+		m.Counts[string(tx.Type)]++
+
+		if m.StartBlock == 0 || tx.BlockNumber < m.StartBlock {
+			m.StartBlock = tx.BlockNumber
+		}
+		if tx.BlockNumber > m.EndBlock {
+			m.EndBlock = tx.BlockNumber
+		}
+
+		ts := tx.Timestamp.UTC()
+		if m.StartTime == nil || ts.Before(*m.StartTime) {
+			m.StartTime = &ts
+		}
+		if m.EndTime == nil || ts.After(*m.EndTime) {
+			m.EndTime = &ts
+		}
+	}
+
+	return m
+}
+
+// WriteFile writes the manifest as indented JSON
+func (m *Manifest) WriteFile(path string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
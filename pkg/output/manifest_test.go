@@ -0,0 +1,65 @@
+package output
+
+import (
+	"conintracker-hiring/pkg/models"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestNewManifest(t *testing.T) {
+	txs := []*models.Transaction{
+		{Hash: "0x1", Type: models.TypeEthTransfer, BlockNumber: 200, Timestamp: time.Unix(1700000200, 0)},
+		{Hash: "0x2", Type: models.TypeERC20Transfer, BlockNumber: 100, Timestamp: time.Unix(1700000100, 0)},
+		{Hash: "0x3", Type: models.TypeERC20Transfer, BlockNumber: 300, Timestamp: time.Unix(1700000300, 0)},
+	}
+
+	m := NewManifest(txs)
+
+	if m.Total != 3 {
+		t.Errorf("Total mismatch: got %d, want 3", m.Total)
+	}
+	if m.Counts["ERC-20"] != 2 || m.Counts["ETH"] != 1 {
+		t.Errorf("Counts mismatch: got %v", m.Counts)
+	}
+	if m.StartBlock != 100 || m.EndBlock != 300 {
+		t.Errorf("Block range mismatch: got %d-%d, want 100-300", m.StartBlock, m.EndBlock)
+	}
+	if !m.StartTime.Equal(time.Unix(1700000100, 0)) || !m.EndTime.Equal(time.Unix(1700000300, 0)) {
+		t.Errorf("Date range mismatch: got %v-%v", m.StartTime, m.EndTime)
+	}
+}
+
+func TestManifestWriteFile(t *testing.T) {
+	m := NewManifest([]*models.Transaction{
+		{Hash: "0x1", Type: models.TypeEthTransfer, BlockNumber: 42, Timestamp: time.Unix(1700000000, 0)},
+	})
+	m.Addresses = []string{"0xa39b189482f984388a34460636fea9eb181ad1a6"}
+	m.Chain = "ethereum"
+	m.Provider = "etherscan"
+	m.ToolVersion = "0.1.0"
+	m.Filters = map[string]string{"start-page": "2"}
+
+	path := filepath.Join(t.TempDir(), "manifest.json")
+	if err := m.WriteFile(path); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read manifest: %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("manifest is not valid JSON: %v", err)
+	}
+
+	for _, field := range []string{"addresses", "chain", "provider", "start_block", "end_block", "start_time", "end_time", "filters", "counts", "total", "tool_version", "exported_at"} {
+		if _, ok := decoded[field]; !ok {
+			t.Errorf("manifest missing field %q: %s", field, data)
+		}
+	}
+}
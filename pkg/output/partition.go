@@ -0,0 +1,67 @@
+package output
+
+import (
+	"conintracker-hiring/pkg/models"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Date partitions accepted by --partition-by
+const (
+	PartitionYear  = "year"  // One file per calendar year, e.g. transactions-2023.csv
+	PartitionMonth = "month" // One file per calendar month, e.g. transactions-2023-11.csv
+)
+
+// Partitions lists the supported date partitions
+var Partitions = []string{PartitionYear, PartitionMonth}
+
+// Partition is the transactions falling in one year or month
+type Partition struct {
+	Key          string // "2023" or "2023-11"
+	Transactions []*models.Transaction
+}
+
+// PartitionByDate groups txs by the year or month of their timestamp in loc (UTC
+// when nil). Partitions are returned in chronological order and keep the order of
+// txs within each; periods without transactions get no partition.
+func PartitionByDate(txs []*models.Transaction, by string, loc *time.Location) ([]Partition, error) {
+	var layout string
+	switch by {
+	case PartitionYear:
+		layout = "2006"
+	case PartitionMonth:
+		layout = "2006-01"
+	default:
+		return nil, fmt.Errorf("invalid partition %q (available: %s)", by, strings.Join(Partitions, ", "))
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	index := make(map[string]int)
+	var partitions []Partition
+	for _, tx := range txs {
+		key := tx.Timestamp.In(loc).Format(layout)
+		i, ok := index[key]
+		if !ok {
+			i = len(partitions)
+			index[key] = i
+			partitions = append(partitions, Partition{Key: key})
+		}
+		partitions[i].Transactions = append(partitions[i].Transactions, tx)
+	}
+
+	// Keys are zero-padded dates, so they sort chronologically as strings
+	sort.Slice(partitions, func(i, j int) bool { return partitions[i].Key < partitions[j].Key })
+	return partitions, nil
+}
+
+// PartitionPath names a partition's file by inserting its key before the
+// extension: transactions.csv becomes transactions-2023.csv
+func PartitionPath(path, key string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "-" + key + ext
+}
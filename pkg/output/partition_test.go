@@ -0,0 +1,92 @@
+package output
+
+import (
+	"conintracker-hiring/pkg/models"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestPartitionByDate(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("LoadLocation() error = %v", err)
+	}
+	txs := []*models.Transaction{
+		{Hash: "0x1", Timestamp: time.Date(2023, 11, 5, 12, 0, 0, 0, time.UTC)},
+		{Hash: "0x2", Timestamp: time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)},
+		{Hash: "0x3", Timestamp: time.Date(2023, 12, 31, 12, 0, 0, 0, time.UTC)},
+		{Hash: "0x4", Timestamp: time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC)},
+	}
+
+	tests := []struct {
+		name string
+		by   string
+		loc  *time.Location
+		want map[string][]string
+		keys []string
+	}{
+		{
+			name: "year",
+			by:   PartitionYear,
+			keys: []string{"2023", "2024"},
+			want: map[string][]string{"2023": {"0x1", "0x3"}, "2024": {"0x2", "0x4"}},
+		},
+		{
+			name: "month",
+			by:   PartitionMonth,
+			keys: []string{"2023-11", "2023-12", "2024-01"},
+			want: map[string][]string{"2023-11": {"0x1"}, "2023-12": {"0x3"}, "2024-01": {"0x2", "0x4"}},
+		},
+		{
+			// 02:00 UTC on Jan 1 is still Dec 31 in New York
+			name: "year_in_location",
+			by:   PartitionYear,
+			loc:  newYork,
+			keys: []string{"2023", "2024"},
+			want: map[string][]string{"2023": {"0x1", "0x3", "0x4"}, "2024": {"0x2"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			partitions, err := PartitionByDate(txs, tt.by, tt.loc)
+			if err != nil {
+				t.Fatalf("PartitionByDate() error = %v", err)
+			}
+			if len(partitions) != len(tt.keys) {
+				t.Fatalf("Expected %d partitions, got %d", len(tt.keys), len(partitions))
+			}
+			for i, part := range partitions {
+				if part.Key != tt.keys[i] {
+					t.Errorf("Partition %d key mismatch: got %s, want %s", i, part.Key, tt.keys[i])
+				}
+				var hashes []string
+				for _, tx := range part.Transactions {
+					hashes = append(hashes, tx.Hash)
+				}
+				if want := tt.want[part.Key]; !slices.Equal(hashes, want) {
+					t.Errorf("Partition %s mismatch: got %v, want %v", part.Key, hashes, want)
+				}
+			}
+		})
+	}
+
+	if _, err := PartitionByDate(txs, "week", nil); err == nil {
+		t.Error("Expected error for an unknown partition, got none")
+	}
+}
+
+func TestPartitionPath(t *testing.T) {
+	tests := map[string]string{
+		"transactions.csv":             "transactions-2023.csv",
+		"out/export.json":              "out/export-2023.json",
+		"s3://bucket/tax/2023/txs.csv": "s3://bucket/tax/2023/txs-2023.csv",
+		"transactions":                 "transactions-2023",
+	}
+	for path, want := range tests {
+		if got := PartitionPath(path, "2023"); got != want {
+			t.Errorf("PartitionPath(%s) mismatch: got %s, want %s", path, got, want)
+		}
+	}
+}
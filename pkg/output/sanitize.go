@@ -0,0 +1,34 @@
+package output
+
+import "strings"
+
+// formulaPrefixes are the leading characters spreadsheets treat as the start of a formula
+const formulaPrefixes = "=+-@\t\r"
+
+// SanitizeCell guards a CSV cell against formula injection: a value starting with
+// =, +, -, @, tab or carriage return is prefixed with a single quote so Excel and
+// Sheets show it as text. Numbers such as -1.5 are left alone, since they cannot
+// hold a formula.
+func SanitizeCell(value string) string {
+	if value == "" || !strings.ContainsRune(formulaPrefixes, rune(value[0])) || isNumeric(value) {
+		return value
+	}
+	return "'" + value
+}
+
+// sanitizeRecord applies SanitizeCell to every field of a record in place
+func sanitizeRecord(record []string) {
+	for i, value := range record {
+		record[i] = SanitizeCell(value)
+	}
+}
+
+// isNumeric reports whether value is a signed decimal, possibly grouped with commas
+func isNumeric(value string) bool {
+	digits := strings.ReplaceAll(value[1:], ",", "")
+	if value[0] != '-' && value[0] != '+' {
+		return false
+	}
+	intPart, fraction, _ := strings.Cut(digits, ".")
+	return intPart != "" && isDigits(intPart) && isDigits(fraction)
+}
@@ -0,0 +1,30 @@
+package output
+
+import "testing"
+
+func TestSanitizeCell(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"=cmd", "'=cmd"},
+		{"+1+1", "'+1+1"},
+		{"-2+3", "'-2+3"},
+		{"@SUM(A1)", "'@SUM(A1)"},
+		{"\t=1", "'\t=1"},
+		{"-1.5", "-1.5"},
+		{"-1,234.5", "-1,234.5"},
+		{"+42", "+42"},
+		{"USDC", "USDC"},
+		{"0xabc", "0xabc"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := SanitizeCell(tt.input); got != tt.want {
+				t.Errorf("SanitizeCell(%q) mismatch: got %s, want %s", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package output
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// seenSuffix is appended to an export's path to name its seen store
+const seenSuffix = ".seen"
+
+// SeenPath returns the path of the seen store kept next to an append export: a
+// text file holding one Transaction.Key per line for every row written to it
+func SeenPath(path string) string {
+	return path + seenSuffix
+}
+
+// loadSeen adds the keys in the seen store at path to keys and reports whether the
+// store exists; a missing store adds nothing
+func loadSeen(path string, keys map[string]struct{}) (bool, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to open seen store: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 4096), 1024*1024)
+	for scanner.Scan() {
+		if key := strings.TrimSpace(scanner.Text()); key != "" {
+			keys[key] = struct{}{}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return true, fmt.Errorf("failed to read seen store: %w", err)
+	}
+	return true, nil
+}
+
+// appendSeen adds keys to the seen store at path, creating it if needed
+func appendSeen(path string, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open seen store: %w", err)
+	}
+
+	w := bufio.NewWriter(file)
+	for _, key := range keys {
+		w.WriteString(key)
+		w.WriteByte('\n')
+	}
+	if err := w.Flush(); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write seen store: %w", err)
+	}
+	return file.Close()
+}
@@ -0,0 +1,125 @@
+package output
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// S3Scheme prefixes output paths that name an object (s3://bucket/key) rather than a local file
+const S3Scheme = "s3://"
+
+// ErrNoUploader is returned for an s3:// output when no Uploader was configured
+var ErrNoUploader = errors.New("no S3 uploader configured")
+
+// SinkFactory opens the destination an exporter writes to. Closing the returned
+// writer completes the write; for remote sinks that is when the upload succeeds or fails.
+// A failed export should end with AbortSink instead, so it replaces nothing remotely.
+type SinkFactory func(ctx context.Context, path string) (io.WriteCloser, error)
+
+// AbortSink ends w without completing it: sinks with an Abort method, such as
+// S3Sink, discard what was written, and anything else is simply closed. Calling it
+// after Close has no further effect on an S3Sink.
+func AbortSink(w io.WriteCloser, err error) {
+	if a, ok := w.(interface{ Abort(error) }); ok {
+		a.Abort(err)
+		return
+	}
+	w.Close()
+}
+
+// Uploader stores an object read from body under bucket/key. An adapter around an
+// S3 (or S3-compatible) client's upload call satisfies it.
+type Uploader interface {
+	Upload(ctx context.Context, bucket, key string, body io.Reader) error
+}
+
+// NewSinkFactory returns a SinkFactory that routes s3:// paths through uploader and
+// creates a local file for anything else. A nil uploader rejects s3:// paths.
+func NewSinkFactory(uploader Uploader) SinkFactory {
+	return func(ctx context.Context, path string) (io.WriteCloser, error) {
+		if !IsS3Path(path) {
+			return os.Create(path)
+		}
+		bucket, key, err := ParseS3Path(path)
+		if err != nil {
+			return nil, err
+		}
+		if uploader == nil {
+			return nil, fmt.Errorf("%w for %s", ErrNoUploader, path)
+		}
+		return NewS3Sink(ctx, uploader, bucket, key), nil
+	}
+}
+
+// IsS3Path reports whether path names an S3 object
+func IsS3Path(path string) bool {
+	return strings.HasPrefix(strings.ToLower(path), S3Scheme)
+}
+
+// ParseS3Path splits s3://bucket/key into its bucket and key
+func ParseS3Path(path string) (bucket, key string, err error) {
+	if !IsS3Path(path) {
+		return "", "", fmt.Errorf("invalid S3 path %q: must start with %s", path, S3Scheme)
+	}
+	bucket, key, _ = strings.Cut(path[len(S3Scheme):], "/")
+	if bucket == "" || key == "" {
+		return "", "", fmt.Errorf("invalid S3 path %q: expected %sbucket/key", path, S3Scheme)
+	}
+	return bucket, key, nil
+}
+
+// S3Sink streams written bytes to an Uploader as a single object. The upload runs
+// while bytes are written, so nothing is buffered beyond what the uploader holds.
+type S3Sink struct {
+	pw        *io.PipeWriter
+	done      chan error
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// NewS3Sink starts uploading to bucket/key; the object is complete once Close returns nil
+func NewS3Sink(ctx context.Context, uploader Uploader, bucket, key string) *S3Sink {
+	pr, pw := io.Pipe()
+	s := &S3Sink{pw: pw, done: make(chan error, 1)}
+
+	go func() {
+		err := uploader.Upload(ctx, bucket, key, pr)
+		// Unblock writers if the uploader stopped reading early
+		pr.CloseWithError(err)
+		s.done <- err
+	}()
+
+	return s
+}
+
+// Write sends p to the upload, failing if the upload has already failed
+func (s *S3Sink) Write(p []byte) (int, error) {
+	return s.pw.Write(p)
+}
+
+// Close ends the object and waits for the upload to finish. Later calls, and Abort,
+// return or change nothing.
+func (s *S3Sink) Close() error {
+	s.closeOnce.Do(func() {
+		s.pw.Close()
+		if err := <-s.done; err != nil {
+			s.closeErr = fmt.Errorf("failed to upload: %w", err)
+		}
+	})
+	return s.closeErr
+}
+
+// Abort fails the upload with err rather than completing the object, so whatever
+// was stored at bucket/key stays in place. Later Close calls report the abort.
+func (s *S3Sink) Abort(err error) {
+	s.closeOnce.Do(func() {
+		s.pw.CloseWithError(err)
+		<-s.done
+		s.closeErr = fmt.Errorf("upload aborted: %w", err)
+	})
+}
@@ -0,0 +1,146 @@
+package output
+
+import (
+	"bytes"
+	"conintracker-hiring/pkg/models"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// fakeUploader records uploaded objects by bucket/key, or fails with err
+type fakeUploader struct {
+	objects map[string][]byte
+	err     error
+}
+
+func (f *fakeUploader) Upload(ctx context.Context, bucket, key string, body io.Reader) error {
+	if f.err != nil {
+		return f.err
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	f.objects[bucket+"/"+key] = data
+	return nil
+}
+
+func writeSinkCSV(t *testing.T, w io.WriteCloser, txs []*models.Transaction) error {
+	t.Helper()
+	writer, err := NewCSVWriter(CSVConfig{Writer: w})
+	if err != nil {
+		t.Fatalf("NewCSVWriter() error = %v", err)
+	}
+	if err := writer.WriteTransactions(txs); err != nil {
+		t.Fatalf("WriteTransactions() error = %v", err)
+	}
+	return writer.Close()
+}
+
+func TestSinkFactoryUploadsS3Paths(t *testing.T) {
+	txs := []*models.Transaction{
+		{Hash: "0x1", Timestamp: time.Unix(1700000000, 0), Type: models.TypeEthTransfer, Amount: "1"},
+		{Hash: "0x2", Timestamp: time.Unix(1700000001, 0), Type: models.TypeERC20Transfer, Amount: "2.5"},
+	}
+
+	want := &WriteCloserBuffer{Buffer: &bytes.Buffer{}}
+	if err := writeSinkCSV(t, want, txs); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	uploader := &fakeUploader{objects: make(map[string][]byte)}
+	sink, err := NewSinkFactory(uploader)(context.Background(), "s3://exports/2024/transactions.csv")
+	if err != nil {
+		t.Fatalf("SinkFactory() error = %v", err)
+	}
+	if err := writeSinkCSV(t, sink, txs); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	got, ok := uploader.objects["exports/2024/transactions.csv"]
+	if !ok {
+		t.Fatalf("No object uploaded: %v", uploader.objects)
+	}
+	if !bytes.Equal(got, want.Bytes()) {
+		t.Errorf("Uploaded bytes mismatch: got %q, want %q", got, want.String())
+	}
+}
+
+func TestS3SinkReportsUploadFailure(t *testing.T) {
+	errUpload := errors.New("access denied")
+	sink := NewS3Sink(context.Background(), &fakeUploader{err: errUpload}, "exports", "transactions.csv")
+
+	sink.Write([]byte("data"))
+	if err := sink.Close(); !errors.Is(err, errUpload) {
+		t.Fatalf("Expected upload error from Close, got %v", err)
+	}
+	if err := sink.Close(); !errors.Is(err, errUpload) {
+		t.Errorf("Expected repeated Close to return the upload error, got %v", err)
+	}
+}
+
+func TestS3SinkAbortKeepsExistingObject(t *testing.T) {
+	uploader := &fakeUploader{objects: map[string][]byte{"exports/transactions.csv": []byte("previous")}}
+	sink := NewS3Sink(context.Background(), uploader, "exports", "transactions.csv")
+
+	errFetch := errors.New("fetch failed")
+	sink.Write([]byte("partial"))
+	sink.Abort(errFetch)
+	if err := sink.Close(); !errors.Is(err, errFetch) {
+		t.Errorf("Expected Close after Abort to report the abort, got %v", err)
+	}
+	if got := string(uploader.objects["exports/transactions.csv"]); got != "previous" {
+		t.Errorf("Expected the stored object to be kept, got %q", got)
+	}
+}
+
+func TestSinkFactoryLocalAndMissingUploader(t *testing.T) {
+	factory := NewSinkFactory(nil)
+
+	path := filepath.Join(t.TempDir(), "transactions.csv")
+	sink, err := factory(context.Background(), path)
+	if err != nil {
+		t.Fatalf("SinkFactory() error = %v", err)
+	}
+	sink.Write([]byte("local"))
+	sink.Close()
+	if data, _ := os.ReadFile(path); string(data) != "local" {
+		t.Errorf("Local file content mismatch: got %q, want local", data)
+	}
+
+	if _, err := factory(context.Background(), "s3://exports/transactions.csv"); !errors.Is(err, ErrNoUploader) {
+		t.Errorf("Expected ErrNoUploader, got %v", err)
+	}
+}
+
+func TestParseS3Path(t *testing.T) {
+	tests := []struct {
+		path       string
+		wantBucket string
+		wantKey    string
+		wantErr    bool
+	}{
+		{path: "s3://bucket/key.csv", wantBucket: "bucket", wantKey: "key.csv"},
+		{path: "S3://bucket/nested/key.csv", wantBucket: "bucket", wantKey: "nested/key.csv"},
+		{path: "s3://bucket", wantErr: true},
+		{path: "s3://bucket/", wantErr: true},
+		{path: "s3:///key.csv", wantErr: true},
+		{path: "transactions.csv", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		bucket, key, err := ParseS3Path(tt.path)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseS3Path(%q) error = %v, wantErr %v", tt.path, err, tt.wantErr)
+			continue
+		}
+		if bucket != tt.wantBucket || key != tt.wantKey {
+			t.Errorf("ParseS3Path(%q) mismatch: got %s/%s, want %s/%s", tt.path, bucket, key, tt.wantBucket, tt.wantKey)
+		}
+	}
+}
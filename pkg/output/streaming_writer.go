@@ -1,11 +1,13 @@
 package output
 
 import (
+	"bytes"
 	"conintracker-hiring/pkg/models"
 	"context"
 	"encoding/csv"
 	"fmt"
 	"io"
+	"io/fs"
 	"sync"
 	"time"
 )
@@ -16,10 +18,28 @@ type StreamingCSVWriter struct {
 	file          io.Writer
 	batchSize     int
 	flushInterval time.Duration
+	includeHeader bool
 	headerWritten bool
+	location      *time.Location
+	timeLayout    string
+	columns       []Column
+	sanitize      bool
+	expectedTotal int
 	mu            sync.Mutex
+
+	// Byte-size rotation, enabled by SetMaxBytesPerFile
+	maxBytes  int64
+	nextFile  func(part int) (io.Writer, error)
+	part      int             // Current file, counting from 1
+	counter   *countingWriter // Wraps the current file while rotating
+	fileRows  int             // Transactions written to the current file
+	rowBuf    bytes.Buffer    // One encoded row, measured before it is written
+	rowWriter *csv.Writer     // Encodes into rowBuf
 }
 
+// streamingTimeLayout is the streaming writer's default timestamp layout
+const streamingTimeLayout = "2006-01-02 15:04:05 MST"
+
 // NewStreamingCSVWriter creates a new streaming CSV writer
 func NewStreamingCSVWriter(w io.Writer) *StreamingCSVWriter {
 	return &StreamingCSVWriter{
@@ -27,7 +47,10 @@ func NewStreamingCSVWriter(w io.Writer) *StreamingCSVWriter {
 		file:          w,
 		batchSize:     100,
 		flushInterval: 5 * time.Second,
+		includeHeader: true,
 		headerWritten: false,
+		location:      time.UTC,
+		timeLayout:    streamingTimeLayout,
 	}
 }
 
@@ -45,17 +68,116 @@ func (scw *StreamingCSVWriter) SetFlushInterval(interval time.Duration) {
 	}
 }
 
+// SetWriteHeader controls whether the header row is written (enabled by default).
+// Even when enabled, a file that already holds rows gets no second header.
+func (scw *StreamingCSVWriter) SetWriteHeader(enabled bool) {
+	scw.includeHeader = enabled
+}
+
+// SetLocation sets the time zone timestamps are rendered in (defaults to UTC)
+func (scw *StreamingCSVWriter) SetLocation(loc *time.Location) {
+	if loc != nil {
+		scw.location = loc
+	}
+}
+
+// SetTimeLayout sets the layout timestamps are rendered with, e.g. time.RFC3339Nano
+// to keep sub-second precision (defaults to "2006-01-02 15:04:05 MST")
+func (scw *StreamingCSVWriter) SetTimeLayout(layout string) {
+	if layout != "" {
+		scw.timeLayout = layout
+	}
+}
+
+// SetColumns sets optional columns appended after the standard ones
+func (scw *StreamingCSVWriter) SetColumns(columns []Column) {
+	scw.columns = columns
+}
+
+// SetSanitize controls whether cells a spreadsheet would run as a formula are escaped
+// (see SanitizeCell)
+func (scw *StreamingCSVWriter) SetSanitize(enabled bool) {
+	scw.sanitize = enabled
+}
+
+// SetExpectedTotal sets how many transactions the stream will carry, so progress
+// reports include a percentage; 0 (the default) means the total is unknown
+func (scw *StreamingCSVWriter) SetExpectedTotal(total int) {
+	if total >= 0 {
+		scw.expectedTotal = total
+	}
+}
+
+// SetMaxBytesPerFile caps each output file at n bytes, header included, rolling to a
+// file from SetNextFile before a row that would exceed the cap. A row larger than the
+// cap on its own is still written, alone in its file, and the next row rolls.
+// 0 (the default) disables rotation.
+func (scw *StreamingCSVWriter) SetMaxBytesPerFile(n int64) {
+	if n >= 0 {
+		scw.maxBytes = n
+	}
+}
+
+// SetNextFile sets how files after the first are opened when SetMaxBytesPerFile
+// rotates: open is called with part 2, 3, ... and each file gets its own header
+// unless it already holds rows.
+// The caller owns the writers it returns, including closing them.
+func (scw *StreamingCSVWriter) SetNextFile(open func(part int) (io.Writer, error)) {
+	scw.nextFile = open
+}
+
+// Parts returns how many files the writer has written to so far
+func (scw *StreamingCSVWriter) Parts() int {
+	scw.mu.Lock()
+	defer scw.mu.Unlock()
+	return max(scw.part, 1)
+}
+
+// WriteProgress is reported after each flush: Written counts transactions written so far,
+// and Total is the expected total set with SetExpectedTotal (0 when unknown)
+type WriteProgress struct {
+	Written int
+	Total   int
+}
+
+// Percent returns the share of the expected total written so far, capped at 100;
+// ok is false when the total is unknown
+func (p WriteProgress) Percent() (percent int, ok bool) {
+	if p.Total <= 0 {
+		return 0, false
+	}
+	return min(p.Written*100/p.Total, 100), true
+}
+
+// String renders the progress as "42% (420/1000)", or just "420" when the total is unknown
+func (p WriteProgress) String() string {
+	percent, ok := p.Percent()
+	if !ok {
+		return fmt.Sprintf("%d", p.Written)
+	}
+	return fmt.Sprintf("%d%% (%d/%d)", percent, p.Written, p.Total)
+}
+
 // WriteStream reads transactions from a channel and writes them to CSV
 // Returns error if writing fails; returns ctx.Err() on context cancellation
+// onProgress, when non-nil, is called after each flush with the running count
 func (scw *StreamingCSVWriter) WriteStream(
 	ctx context.Context,
 	txChan <-chan *models.Transaction,
-	onProgress func(count int),
+	onProgress func(WriteProgress),
 ) error {
+	if scw.maxBytes > 0 && scw.nextFile == nil {
+		return fmt.Errorf("max bytes per file set without a next file")
+	}
+
 	// Write header once
 	scw.mu.Lock()
-	if !scw.headerWritten {
-		if err := scw.writeHeader(); err != nil {
+	if scw.maxBytes > 0 && scw.counter == nil {
+		scw.startCounting(scw.file)
+		scw.part = 1
+	}
+	if scw.includeHeader && !scw.headerWritten {
+		if err := scw.writeHeaderIfNeeded(scw.file); err != nil {
 			scw.mu.Unlock()
 			return fmt.Errorf("failed to write CSV header: %w", err)
 		}
@@ -72,39 +194,16 @@ func (scw *StreamingCSVWriter) WriteStream(
 	for {
 		select {
 		case <-ctx.Done():
-			// Flush remaining batch before exiting
-			if len(batch) > 0 {
-				scw.mu.Lock()
-				if err := scw.writeBatch(batch); err != nil {
-					scw.mu.Unlock()
-					return fmt.Errorf("failed to write final batch: %w", err)
-				}
-				scw.mu.Unlock()
-				if onProgress != nil {
-					onProgress(count)
-				}
+			// Rows received before cancellation still reach the output
+			if err := scw.writeFinal(batch, count, onProgress); err != nil {
+				return err
 			}
 			return ctx.Err()
 
 		case tx, ok := <-txChan:
 			if !ok {
 				// Channel closed, flush remaining batch
-				if len(batch) > 0 {
-					scw.mu.Lock()
-					if err := scw.writeBatch(batch); err != nil {
-						scw.mu.Unlock()
-						return fmt.Errorf("failed to write final batch: %w", err)
-					}
-					scw.mu.Unlock()
-					if onProgress != nil {
-						onProgress(count)
-					}
-				}
-				// Flush all remaining data
-				scw.mu.Lock()
-				scw.writer.Flush()
-				scw.mu.Unlock()
-				return nil
+				return scw.writeFinal(batch, count, onProgress)
 			}
 
 			batch = append(batch, tx)
@@ -120,7 +219,7 @@ func (scw *StreamingCSVWriter) WriteStream(
 				scw.mu.Unlock()
 				batch = batch[:0] // Reset batch
 				if onProgress != nil {
-					onProgress(count)
+					onProgress(WriteProgress{Written: count, Total: scw.expectedTotal})
 				}
 			}
 
@@ -135,19 +234,92 @@ func (scw *StreamingCSVWriter) WriteStream(
 				scw.mu.Unlock()
 				batch = batch[:0]
 				if onProgress != nil {
-					onProgress(count)
+					onProgress(WriteProgress{Written: count, Total: scw.expectedTotal})
 				}
 			}
 		}
 	}
 }
 
+// writeFinal writes the partial batch and flushes the csv.Writer's buffer through
+// to the output, so every received row is written when WriteStream returns
+func (scw *StreamingCSVWriter) writeFinal(batch []*models.Transaction, count int, onProgress func(WriteProgress)) error {
+	scw.mu.Lock()
+	if len(batch) > 0 {
+		if err := scw.writeBatch(batch); err != nil {
+			scw.mu.Unlock()
+			return fmt.Errorf("failed to write final batch: %w", err)
+		}
+	}
+	scw.writer.Flush()
+	err := scw.writer.Error()
+	scw.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to flush CSV: %w", err)
+	}
+
+	if len(batch) > 0 && onProgress != nil {
+		onProgress(WriteProgress{Written: count, Total: scw.expectedTotal})
+	}
+	return nil
+}
+
+// TransactionSource yields transactions one at a time, returning io.EOF when exhausted.
+// providers.TransactionIterator satisfies it.
+type TransactionSource interface {
+	Next(ctx context.Context) (*models.Transaction, error)
+}
+
+// WriteFrom pulls transactions from src and writes them to CSV until src returns io.EOF.
+// Errors from src are returned after any already-read transactions are flushed.
+func (scw *StreamingCSVWriter) WriteFrom(
+	ctx context.Context,
+	src TransactionSource,
+	onProgress func(WriteProgress),
+) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	txChan := make(chan *models.Transaction, scw.batchSize)
+	errChan := make(chan error, 1)
+
+	go func() {
+		defer close(txChan)
+		for {
+			tx, err := src.Next(ctx)
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				errChan <- err
+				return
+			}
+			select {
+			case txChan <- tx:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	if err := scw.WriteStream(ctx, txChan, onProgress); err != nil {
+		return err
+	}
+
+	select {
+	case err := <-errChan:
+		return fmt.Errorf("failed to read transactions: %w", err)
+	default:
+		return nil
+	}
+}
+
 // writeBatch writes a batch of transactions (must be called with mutex held)
 func (scw *StreamingCSVWriter) writeBatch(txs []*models.Transaction) error {
 	for _, tx := range txs {
 		record := []string{
 			tx.Hash,
-			tx.Timestamp.Format("2006-01-02 15:04:05 MST"),
+			tx.Timestamp.In(scw.location).Format(scw.timeLayout),
 			tx.From,
 			tx.To,
 			string(tx.Type),
@@ -157,6 +329,18 @@ func (scw *StreamingCSVWriter) writeBatch(txs []*models.Transaction) error {
 			tx.Amount,
 			tx.GasFeeETH,
 		}
+		for _, col := range scw.columns {
+			record = append(record, col.Value(tx))
+		}
+		if scw.sanitize {
+			sanitizeRecord(record)
+		}
+		if scw.maxBytes > 0 {
+			if err := scw.writeCapped(record); err != nil {
+				return err
+			}
+			continue
+		}
 		if err := scw.writer.Write(record); err != nil {
 			return err
 		}
@@ -167,18 +351,7 @@ func (scw *StreamingCSVWriter) writeBatch(txs []*models.Transaction) error {
 
 // writeHeader writes the CSV header row (must be called with mutex held)
 func (scw *StreamingCSVWriter) writeHeader() error {
-	header := []string{
-		"Transaction Hash",
-		"Date & Time",
-		"From Address",
-		"To Address",
-		"Transaction Type",
-		"Asset Contract Address",
-		"Asset Symbol / Name",
-		"Token ID",
-		"Value / Amount",
-		"Gas Fee (ETH)",
-	}
+	header := headerWithColumns(scw.columns)
 	if err := scw.writer.Write(header); err != nil {
 		return err
 	}
@@ -186,6 +359,87 @@ func (scw *StreamingCSVWriter) writeHeader() error {
 	return scw.writer.Error()
 }
 
+// writeHeaderIfNeeded writes the header unless w is a file that already holds rows,
+// as decided by NeedsHeader (must be called with mutex held)
+func (scw *StreamingCSVWriter) writeHeaderIfNeeded(w io.Writer) error {
+	need, err := needsHeaderFor(w)
+	if err != nil {
+		return err
+	}
+	if !need {
+		return nil
+	}
+	return scw.writeHeader()
+}
+
+// writeCapped encodes record and writes it to the current file, first rolling to the
+// next file when it would push that file past maxBytes (must be called with mutex held)
+func (scw *StreamingCSVWriter) writeCapped(record []string) error {
+	if scw.rowWriter == nil {
+		scw.rowWriter = csv.NewWriter(&scw.rowBuf)
+	}
+	scw.rowBuf.Reset()
+	if err := scw.rowWriter.Write(record); err != nil {
+		return err
+	}
+	scw.rowWriter.Flush()
+	if err := scw.rowWriter.Error(); err != nil {
+		return err
+	}
+
+	// A file always takes at least one row, so oversized rows still get written
+	if scw.fileRows > 0 && scw.counter.n+int64(scw.rowBuf.Len()) > scw.maxBytes {
+		if err := scw.rotate(); err != nil {
+			return err
+		}
+	}
+
+	if _, err := scw.counter.Write(scw.rowBuf.Bytes()); err != nil {
+		return err
+	}
+	scw.fileRows++
+	return nil
+}
+
+// rotate opens the next file and writes its header (must be called with mutex held)
+func (scw *StreamingCSVWriter) rotate() error {
+	w, err := scw.nextFile(scw.part + 1)
+	if err != nil {
+		return fmt.Errorf("failed to open file %d: %w", scw.part+1, err)
+	}
+	scw.part++
+	scw.startCounting(w)
+	if scw.includeHeader {
+		return scw.writeHeaderIfNeeded(w)
+	}
+	return nil
+}
+
+// startCounting directs output to w, counting the bytes written to it on top of
+// any a file being appended to already holds
+func (scw *StreamingCSVWriter) startCounting(w io.Writer) {
+	scw.counter = &countingWriter{w: w}
+	if file, ok := w.(interface{ Stat() (fs.FileInfo, error) }); ok {
+		if info, err := file.Stat(); err == nil && info.Mode().IsRegular() {
+			scw.counter.n = info.Size()
+		}
+	}
+	scw.file, scw.writer = w, csv.NewWriter(scw.counter)
+	scw.fileRows = 0
+}
+
+// countingWriter counts the bytes written through it
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
 // StreamingOutputMetrics tracks performance metrics during streaming output
 type StreamingOutputMetrics struct {
 	TotalWritten     int64
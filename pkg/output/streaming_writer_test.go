@@ -4,6 +4,13 @@ import (
 	"bytes"
 	"conintracker-hiring/pkg/models"
 	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -87,8 +94,8 @@ func BenchmarkStreamingWithProgress(b *testing.B) {
 		txChan := generateTransactions(1000)
 
 		progressCount := 0
-		if err := writer.WriteStream(ctx, txChan, func(count int) {
-			progressCount = count
+		if err := writer.WriteStream(ctx, txChan, func(p WriteProgress) {
+			progressCount = p.Written
 		}); err != nil {
 			b.Fatalf("WriteStream failed: %v", err)
 		}
@@ -161,6 +168,331 @@ func TestStreamingCSVWriter(t *testing.T) {
 	}
 }
 
+// TestStreamingCSVWriterWithoutHeader tests that disabling the header starts output with data
+func TestStreamingCSVWriterWithoutHeader(t *testing.T) {
+	buf := &bytes.Buffer{}
+	writer := NewStreamingCSVWriter(buf)
+	writer.SetWriteHeader(false)
+
+	txChan := make(chan *models.Transaction, 1)
+	txChan <- &models.Transaction{
+		Hash:      "0xabc",
+		Timestamp: time.Now(),
+		Type:      models.TypeEthTransfer,
+		Amount:    "1.0",
+	}
+	close(txChan)
+
+	if err := writer.WriteStream(context.Background(), txChan, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 data line, got %d", len(lines))
+	}
+	if !strings.HasPrefix(lines[0], "0xabc,") {
+		t.Fatalf("expected first line to be data, got %s", lines[0])
+	}
+}
+
+// TestStreamingCSVWriterProgress tests that progress counts increase with each flush
+// and carry the expected total
+func TestStreamingCSVWriterProgress(t *testing.T) {
+	writer := NewStreamingCSVWriter(&bytes.Buffer{})
+	writer.SetBatchSize(2)
+	writer.SetExpectedTotal(5)
+
+	txChan := make(chan *models.Transaction, 5)
+	for i := 0; i < 5; i++ {
+		txChan <- &models.Transaction{Hash: fmt.Sprintf("0x%d", i), Timestamp: time.Now(), Type: models.TypeEthTransfer}
+	}
+	close(txChan)
+
+	var reports []WriteProgress
+	if err := writer.WriteStream(context.Background(), txChan, func(p WriteProgress) {
+		reports = append(reports, p)
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(reports) != 3 {
+		t.Fatalf("Report count mismatch: got %d, want 3", len(reports))
+	}
+	for i, p := range reports {
+		if i > 0 && p.Written <= reports[i-1].Written {
+			t.Errorf("Progress not increasing: %d after %d", p.Written, reports[i-1].Written)
+		}
+		if p.Total != 5 {
+			t.Errorf("Total mismatch: got %d, want 5", p.Total)
+		}
+	}
+	if got := reports[len(reports)-1].String(); got != "100% (5/5)" {
+		t.Errorf("Final progress mismatch: got %s, want 100%% (5/5)", got)
+	}
+}
+
+// rotatingBuffers writes a streaming writer's files to in-memory buffers
+func rotatingBuffers(maxBytes int64) (*StreamingCSVWriter, *[]*bytes.Buffer) {
+	files := &[]*bytes.Buffer{{}}
+	writer := NewStreamingCSVWriter((*files)[0])
+	writer.SetMaxBytesPerFile(maxBytes)
+	writer.SetNextFile(func(part int) (io.Writer, error) {
+		if part != len(*files)+1 {
+			return nil, fmt.Errorf("unexpected part %d after %d files", part, len(*files))
+		}
+		*files = append(*files, &bytes.Buffer{})
+		return (*files)[part-1], nil
+	})
+	return writer, files
+}
+
+// TestStreamingCSVWriterMaxBytesPerFile tests rotation to new files at a byte cap
+// TestStreamingCSVWriterCancelMidBatch checks rows received before cancellation are
+// flushed to the output even though their batch never filled
+func TestStreamingCSVWriterCancelMidBatch(t *testing.T) {
+	buf := &bytes.Buffer{}
+	writer := NewStreamingCSVWriter(buf)
+	writer.SetBatchSize(100)
+	writer.SetFlushInterval(time.Hour) // Only cancellation can flush
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	txChan := make(chan *models.Transaction) // Unbuffered: each send is a received row
+	done := make(chan error, 1)
+	go func() {
+		done <- writer.WriteStream(ctx, txChan, nil)
+	}()
+
+	hashes := []string{"0xa1", "0xa2", "0xa3", "0xa4", "0xa5"}
+	for _, hash := range hashes[:3] {
+		txChan <- &models.Transaction{Hash: hash, Timestamp: time.Unix(1700000000, 0), Type: models.TypeEthTransfer}
+	}
+	cancel()
+
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+
+	rows, err := csv.NewReader(bytes.NewReader(buf.Bytes())).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse output: %v", err)
+	}
+	if len(rows) != 4 {
+		t.Fatalf("Expected header and 3 rows, got %d rows: %q", len(rows), buf.String())
+	}
+	for i, hash := range hashes[:3] {
+		if rows[i+1][0] != hash {
+			t.Errorf("Row %d hash mismatch: got %s, want %s", i+1, rows[i+1][0], hash)
+		}
+	}
+}
+
+func TestStreamingCSVWriterMaxBytesPerFile(t *testing.T) {
+	header := strings.Join(standardHeaders, ",") + "\n"
+	row := "0x0,2024-01-01 00:00:00 UTC,,,ETH,,,,1,\n"
+	// Room for the header and two rows per file
+	maxBytes := int64(len(header) + 2*len(row))
+
+	writer, files := rotatingBuffers(maxBytes)
+	writer.SetBatchSize(3)
+
+	txChan := make(chan *models.Transaction, 5)
+	for i := 0; i < 5; i++ {
+		txChan <- &models.Transaction{Hash: "0x0", Timestamp: time.Unix(1704067200, 0), Type: models.TypeEthTransfer, Amount: "1"}
+	}
+	close(txChan)
+	if err := writer.WriteStream(context.Background(), txChan, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantRows := []int{2, 2, 1}
+	if len(*files) != len(wantRows) || writer.Parts() != len(wantRows) {
+		t.Fatalf("File count mismatch: got %d (Parts %d), want %d", len(*files), writer.Parts(), len(wantRows))
+	}
+	for i, file := range *files {
+		content := file.String()
+		if want := header + strings.Repeat(row, wantRows[i]); content != want {
+			t.Errorf("File %d mismatch:\ngot  %q\nwant %q", i+1, content, want)
+		}
+		if int64(len(content)) > maxBytes {
+			t.Errorf("File %d exceeds cap: %d > %d bytes", i+1, len(content), maxBytes)
+		}
+	}
+}
+
+// TestStreamingCSVWriterOversizedRow tests that a row larger than the cap is written alone
+func TestStreamingCSVWriterOversizedRow(t *testing.T) {
+	writer, files := rotatingBuffers(10)
+	writer.SetWriteHeader(false)
+
+	txChan := make(chan *models.Transaction, 3)
+	for _, hash := range []string{"0x1", "0x2", "0x3"} {
+		txChan <- &models.Transaction{Hash: hash, Timestamp: time.Unix(0, 0), Type: models.TypeEthTransfer}
+	}
+	close(txChan)
+	if err := writer.WriteStream(context.Background(), txChan, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(*files) != 3 {
+		t.Fatalf("File count mismatch: got %d, want 3", len(*files))
+	}
+	for i, file := range *files {
+		if lines := strings.Count(file.String(), "\n"); lines != 1 {
+			t.Errorf("File %d mismatch: got %d rows, want 1", i+1, lines)
+		}
+		if want := fmt.Sprintf("0x%d,", i+1); !strings.HasPrefix(file.String(), want) {
+			t.Errorf("File %d mismatch: got %q, want prefix %q", i+1, file.String(), want)
+		}
+	}
+}
+
+// TestStreamingCSVWriterAppendsToHeaderedFiles tests that appending to existing files,
+// including one opened on rotation, adds no second header
+func TestStreamingCSVWriterAppendsToHeaderedFiles(t *testing.T) {
+	header := strings.Join(standardHeaders, ",") + "\n"
+	row := "0x0,2024-01-01 00:00:00 UTC,,,ETH,,,,1,\n"
+	dir := t.TempDir()
+	paths := []string{filepath.Join(dir, "part1.csv"), filepath.Join(dir, "part2.csv")}
+	for _, path := range paths {
+		if err := os.WriteFile(path, []byte(header+row), 0644); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+
+	first, err := OpenAppendFile(paths[0])
+	if err != nil {
+		t.Fatalf("OpenAppendFile() error = %v", err)
+	}
+	defer first.Close()
+	var second *os.File
+	defer func() {
+		if second != nil {
+			second.Close()
+		}
+	}()
+
+	// Room for one row per file, so the second row rolls to part2.csv
+	writer := NewStreamingCSVWriter(first)
+	writer.SetMaxBytesPerFile(int64(len(header) + len(row)))
+	writer.SetNextFile(func(part int) (io.Writer, error) {
+		second, err = os.OpenFile(paths[part-1], os.O_WRONLY|os.O_APPEND, 0644)
+		return second, err
+	})
+
+	txChan := make(chan *models.Transaction, 2)
+	for i := 0; i < 2; i++ {
+		txChan <- &models.Transaction{Hash: "0x0", Timestamp: time.Unix(1704067200, 0), Type: models.TypeEthTransfer, Amount: "1"}
+	}
+	close(txChan)
+	if err := writer.WriteStream(context.Background(), txChan, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("ReadFile() error = %v", err)
+		}
+		if want := header + row + row; string(content) != want {
+			t.Errorf("%s mismatch:\ngot  %q\nwant %q", filepath.Base(path), content, want)
+		}
+	}
+}
+
+// TestStreamingCSVWriterMaxBytesRequiresNextFile tests the cap is rejected without a way to rotate
+func TestStreamingCSVWriterMaxBytesRequiresNextFile(t *testing.T) {
+	writer := NewStreamingCSVWriter(&bytes.Buffer{})
+	writer.SetMaxBytesPerFile(100)
+
+	txChan := make(chan *models.Transaction)
+	close(txChan)
+	if err := writer.WriteStream(context.Background(), txChan, nil); err == nil {
+		t.Error("Expected error for a cap without SetNextFile, got none")
+	}
+}
+
+// TestWriteProgressString tests percentage rendering with known and unknown totals
+func TestWriteProgressString(t *testing.T) {
+	tests := []struct {
+		progress WriteProgress
+		want     string
+	}{
+		{WriteProgress{Written: 420, Total: 1000}, "42% (420/1000)"},
+		{WriteProgress{Written: 0, Total: 1000}, "0% (0/1000)"},
+		{WriteProgress{Written: 1200, Total: 1000}, "100% (1200/1000)"},
+		{WriteProgress{Written: 420}, "420"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.progress.String(); got != tt.want {
+			t.Errorf("String() mismatch: got %s, want %s", got, tt.want)
+		}
+	}
+}
+
+// sliceSource yields transactions from a slice, then err (io.EOF when nil)
+type sliceSource struct {
+	txs []*models.Transaction
+	err error
+}
+
+func (s *sliceSource) Next(ctx context.Context) (*models.Transaction, error) {
+	if len(s.txs) == 0 {
+		if s.err != nil {
+			return nil, s.err
+		}
+		return nil, io.EOF
+	}
+	tx := s.txs[0]
+	s.txs = s.txs[1:]
+	return tx, nil
+}
+
+// TestStreamingCSVWriterWriteFrom tests writing every transaction pulled from a source
+func TestStreamingCSVWriterWriteFrom(t *testing.T) {
+	buf := &bytes.Buffer{}
+	writer := NewStreamingCSVWriter(buf)
+
+	src := &sliceSource{txs: []*models.Transaction{
+		{Hash: "0x1", Timestamp: time.Now(), Type: models.TypeEthTransfer},
+		{Hash: "0x2", Timestamp: time.Now(), Type: models.TypeEthTransfer},
+	}}
+
+	if err := writer.WriteFrom(context.Background(), src, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected header and 2 data lines, got %d", len(lines))
+	}
+	if !strings.HasPrefix(lines[1], "0x1,") || !strings.HasPrefix(lines[2], "0x2,") {
+		t.Errorf("rows out of order: %v", lines[1:])
+	}
+}
+
+// TestStreamingCSVWriterWriteFromSourceError tests that source errors are returned after flushing
+func TestStreamingCSVWriterWriteFromSourceError(t *testing.T) {
+	buf := &bytes.Buffer{}
+	writer := NewStreamingCSVWriter(buf)
+	errSource := errors.New("page fetch failed")
+
+	src := &sliceSource{
+		txs: []*models.Transaction{{Hash: "0x1", Timestamp: time.Now(), Type: models.TypeEthTransfer}},
+		err: errSource,
+	}
+
+	if err := writer.WriteFrom(context.Background(), src, nil); !errors.Is(err, errSource) {
+		t.Fatalf("expected source error, got %v", err)
+	}
+	if !strings.Contains(buf.String(), "0x1,") {
+		t.Errorf("rows read before the error were not flushed: %s", buf.String())
+	}
+}
+
 // TestMetricsCollector tests metrics collection
 func TestMetricsCollector(t *testing.T) {
 	collector := NewMetricsCollector()
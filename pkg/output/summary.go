@@ -0,0 +1,64 @@
+package output
+
+import (
+	"conintracker-hiring/pkg/models"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+)
+
+// RunSummary is the machine-readable result of an export, written as one JSON line
+// for wrapping scripts
+type RunSummary struct {
+	Address        string         `json:"address"`
+	Total          int            `json:"total"`
+	ByType         map[string]int `json:"by_type"`        // Exported rows per transaction type
+	GasETH         string         `json:"gas_eth"`        // Gas fees of the exported transactions, each counted once
+	Counterparties int            `json:"counterparties"` // Distinct addresses interacted with, set by the caller before redaction
+	Truncated      bool           `json:"truncated"`
+}
+
+// NewRunSummary totals the exported transactions. Rows sharing a transaction hash
+// carry the same gas fee, so it is added once per hash; unparseable fees are skipped.
+func NewRunSummary(address string, txs []*models.Transaction, truncated bool) *RunSummary {
+	s := &RunSummary{
+		Address:   address,
+		Total:     len(txs),
+		ByType:    make(map[string]int),
+		Truncated: truncated,
+	}
+
+	gas := new(big.Rat)
+	charged := make(map[string]bool)
+	for _, tx := range txs {
+		s.ByType[string(tx.Type)]++
+
+		hash := strings.ToLower(tx.Hash)
+		if charged[hash] {
+			continue
+		}
+		fee, ok := new(big.Rat).SetString(tx.GasFeeETH)
+		if !ok {
+			continue
+		}
+		charged[hash] = true
+		gas.Add(gas, fee)
+	}
+
+	s.GasETH = strings.TrimSuffix(strings.TrimRight(gas.FloatString(18), "0"), ".")
+	return s
+}
+
+// WriteLine writes the summary to w as a single line of JSON
+func (s *RunSummary) WriteLine(w io.Writer) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to encode summary: %w", err)
+	}
+	if _, err := w.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write summary: %w", err)
+	}
+	return nil
+}
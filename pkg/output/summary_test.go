@@ -0,0 +1,48 @@
+package output
+
+import (
+	"bytes"
+	"conintracker-hiring/pkg/models"
+	"strings"
+	"testing"
+)
+
+func TestRunSummaryCountsGasOncePerHash(t *testing.T) {
+	txs := []*models.Transaction{
+		{Hash: "0xAA", Type: models.TypeEthTransfer, GasFeeETH: "0.001"},
+		{Hash: "0xaa", Type: models.TypeERC20Transfer, GasFeeETH: "0.001"}, // Same transaction
+		{Hash: "0xbb", Type: models.TypeERC20Transfer, GasFeeETH: "0.0025"},
+		{Hash: "0xcc", Type: models.TypeInternal, GasFeeETH: ""},
+	}
+
+	summary := NewRunSummary("0xabc", txs, true)
+
+	if summary.Total != 4 {
+		t.Errorf("Total mismatch: got %d, want 4", summary.Total)
+	}
+	if summary.ByType["ERC-20"] != 2 || summary.ByType["ETH"] != 1 || summary.ByType["Internal"] != 1 {
+		t.Errorf("ByType mismatch: got %v", summary.ByType)
+	}
+	if summary.GasETH != "0.0035" {
+		t.Errorf("GasETH mismatch: got %s, want 0.0035", summary.GasETH)
+	}
+
+	var buf bytes.Buffer
+	if err := summary.WriteLine(&buf); err != nil {
+		t.Fatalf("WriteLine() error = %v", err)
+	}
+	want := `{"address":"0xabc","total":4,"by_type":{"ERC-20":2,"ETH":1,"Internal":1},"gas_eth":"0.0035","counterparties":0,"truncated":true}` + "\n"
+	if buf.String() != want {
+		t.Errorf("Line mismatch: got %s, want %s", buf.String(), want)
+	}
+	if strings.Count(buf.String(), "\n") != 1 {
+		t.Error("Expected exactly one line")
+	}
+}
+
+func TestRunSummaryNoTransactions(t *testing.T) {
+	summary := NewRunSummary("0xabc", nil, false)
+	if summary.GasETH != "0" {
+		t.Errorf("GasETH mismatch: got %s, want 0", summary.GasETH)
+	}
+}
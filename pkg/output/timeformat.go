@@ -0,0 +1,29 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Timestamp formats selectable for an export
+const (
+	TimeFormatRFC3339     = "rfc3339"
+	TimeFormatRFC3339Nano = "rfc3339nano"
+)
+
+// TimeFormats lists the supported timestamp formats
+var TimeFormats = []string{TimeFormatRFC3339, TimeFormatRFC3339Nano}
+
+// LookupTimeFormat returns the time layout for a timestamp format name.
+// rfc3339 drops sub-second precision; rfc3339nano keeps it, without trailing zeros.
+func LookupTimeFormat(name string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case TimeFormatRFC3339:
+		return time.RFC3339, nil
+	case TimeFormatRFC3339Nano:
+		return time.RFC3339Nano, nil
+	default:
+		return "", fmt.Errorf("unknown time format %q (available: %s)", name, strings.Join(TimeFormats, ", "))
+	}
+}
@@ -0,0 +1,30 @@
+package output
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLookupTimeFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{name: "rfc3339", want: time.RFC3339},
+		{name: "RFC3339Nano", want: time.RFC3339Nano},
+		{name: "unix", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LookupTimeFormat(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LookupTimeFormat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Layout mismatch: got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+// Package pricing values normalized transactions in USD using historical asset prices
+package pricing
+
+import (
+	"conintracker-hiring/pkg/models"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// NativeSymbol is the symbol native transfers are priced under when they carry no AssetSymbol
+const NativeSymbol = "ETH"
+
+// usdDecimals is the number of decimal places ValueUSD is rounded to
+const usdDecimals = 2
+
+// ErrPriceNotFound is returned by a PriceProvider that has no price for an asset.
+// ApplyValueUSD leaves such transactions unvalued instead of failing.
+var ErrPriceNotFound = errors.New("price not found")
+
+// PriceProvider returns historical USD prices
+type PriceProvider interface {
+	// AssetPriceAt returns the USD price of one unit of symbol at time t
+	AssetPriceAt(ctx context.Context, symbol string, t time.Time) (float64, error)
+}
+
+// ApplyValueUSD sets ValueUSD on each transaction to Amount times the asset's
+// price at the transaction's timestamp. NFTs have no fungible price and are
+// left empty, as are transactions whose asset has no price or no symbol.
+func ApplyValueUSD(ctx context.Context, prices PriceProvider, txs []*models.Transaction) error {
+	for _, tx := range txs {
+		symbol := PriceSymbol(tx)
+		if symbol == "" {
+			continue
+		}
+
+		amount, err := models.ParseAmount(tx)
+		if err != nil {
+			continue
+		}
+
+		price, err := prices.AssetPriceAt(ctx, symbol, tx.Timestamp)
+		if errors.Is(err, ErrPriceNotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to price %s at %s: %w", symbol, tx.Timestamp.Format(time.RFC3339), err)
+		}
+
+		rate := new(big.Rat)
+		if rate.SetFloat64(price) == nil {
+			continue // NaN or infinite price
+		}
+		tx.ValueUSD = amount.Mul(amount, rate).FloatString(usdDecimals)
+	}
+	return nil
+}
+
+// PriceSymbol returns the symbol a transaction's amount is priced under, or "" if it can't be priced
+func PriceSymbol(tx *models.Transaction) string {
+	switch tx.Type {
+	case models.TypeEthTransfer, models.TypeInternal, models.TypeBeaconWithdrawal:
+		if tx.AssetSymbol != "" {
+			return tx.AssetSymbol
+		}
+		return NativeSymbol
+	case models.TypeERC721Transfer, models.TypeERC1155Transfer:
+		return ""
+	default:
+		return tx.AssetSymbol
+	}
+}
@@ -0,0 +1,80 @@
+package pricing
+
+import (
+	"conintracker-hiring/pkg/models"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// stubPrices returns per-symbol prices that change at cutoff
+type stubPrices struct {
+	cutoff time.Time
+	before map[string]float64
+	after  map[string]float64
+	err    error
+}
+
+func (s *stubPrices) AssetPriceAt(ctx context.Context, symbol string, t time.Time) (float64, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+	prices := s.after
+	if t.Before(s.cutoff) {
+		prices = s.before
+	}
+	price, ok := prices[symbol]
+	if !ok {
+		return 0, ErrPriceNotFound
+	}
+	return price, nil
+}
+
+func TestApplyValueUSD(t *testing.T) {
+	cutoff := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	prices := &stubPrices{
+		cutoff: cutoff,
+		before: map[string]float64{"ETH": 2000, "USDC": 1},
+		after:  map[string]float64{"ETH": 2500, "USDC": 0.9998},
+	}
+
+	txs := []*models.Transaction{
+		{Hash: "eth-early", Type: models.TypeEthTransfer, Amount: "1.5", Timestamp: cutoff.Add(-time.Hour)},
+		{Hash: "eth-late", Type: models.TypeEthTransfer, Amount: "1.5", Timestamp: cutoff.Add(time.Hour)},
+		{Hash: "internal", Type: models.TypeInternal, Amount: "0.1", Timestamp: cutoff.Add(time.Hour)},
+		{Hash: "usdc", Type: models.TypeERC20Transfer, AssetSymbol: "USDC", Amount: "250", Timestamp: cutoff.Add(time.Hour)},
+		{Hash: "nft", Type: models.TypeERC721Transfer, AssetSymbol: "BAYC", Amount: "1", Timestamp: cutoff},
+		{Hash: "unknown", Type: models.TypeERC20Transfer, AssetSymbol: "XYZ", Amount: "10", Timestamp: cutoff},
+	}
+
+	if err := ApplyValueUSD(context.Background(), prices, txs); err != nil {
+		t.Fatalf("ApplyValueUSD() error = %v", err)
+	}
+
+	want := map[string]string{
+		"eth-early": "3000.00",
+		"eth-late":  "3750.00",
+		"internal":  "250.00",
+		"usdc":      "249.95",
+		"nft":       "",
+		"unknown":   "",
+	}
+	for _, tx := range txs {
+		if tx.ValueUSD != want[tx.Hash] {
+			t.Errorf("ValueUSD mismatch for %s: got %q, want %q", tx.Hash, tx.ValueUSD, want[tx.Hash])
+		}
+	}
+}
+
+func TestApplyValueUSDProviderError(t *testing.T) {
+	errDown := errors.New("price service down")
+	txs := []*models.Transaction{
+		{Type: models.TypeEthTransfer, Amount: "1", Timestamp: time.Unix(1700000000, 0)},
+	}
+
+	err := ApplyValueUSD(context.Background(), &stubPrices{err: errDown}, txs)
+	if !errors.Is(err, errDown) {
+		t.Fatalf("Expected provider error, got %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
+
+// TokenBalancer is implemented by providers that can look up an address's current
+// token balance. The fetch command uses it for --with-balances.
+type TokenBalancer interface {
+	// FetchTokenBalance returns address's balance of the token at contract in the
+	// token's smallest unit, not adjusted for decimals
+	FetchTokenBalance(ctx context.Context, address, contract string) (string, error)
+}
+
+// FetchTokenBalance returns address's current balance of the ERC-20 token at contract
+// via Etherscan's tokenbalance action, as a raw integer string. The request is rate
+// limited like every other call; use FormatTokenAmount to apply the token's decimals.
+func (c *EtherscanClient) FetchTokenBalance(ctx context.Context, address, contract string) (string, error) {
+	params := c.buildParams("tokenbalance", "account", address)
+	params.Set("contractaddress", contract)
+	params.Set("tag", "latest")
+
+	body, err := c.executeRequest(ctx, params)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch token balance: %w", err)
+	}
+
+	// The balance comes back as a string result, which EtherscanResponse keeps in ResultText
+	var resp EtherscanResponse[json.RawMessage]
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+	if resp.Status != "1" {
+		return "", apiError(c.chainID, resp.ResultText)
+	}
+	if _, ok := new(big.Int).SetString(resp.ResultText, 10); !ok {
+		return "", fmt.Errorf("invalid token balance %q", resp.ResultText)
+	}
+	return resp.ResultText, nil
+}
+
+// FormatTokenAmount scales a raw integer amount by the token's decimals exactly,
+// without trailing zeros: ("1500000", 6) is "1.5"
+func FormatTokenAmount(raw string, decimals int) (string, error) {
+	value, ok := new(big.Int).SetString(raw, 10)
+	if !ok {
+		return "", fmt.Errorf("invalid token amount %q", raw)
+	}
+	if decimals <= 0 {
+		return value.String(), nil
+	}
+
+	divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	scaled := new(big.Rat).SetFrac(value, divisor).FloatString(decimals)
+	return trimTrailingZeros(scaled), nil
+}
@@ -0,0 +1,78 @@
+package providers
+
+import (
+	"conintracker-hiring/internal/testdata"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFetchTokenBalance(t *testing.T) {
+	const contract = "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("action") != "tokenbalance" || query.Get("contractaddress") != contract || query.Get("tag") != "latest" {
+			t.Errorf("Unexpected query: %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(testdata.TokenBalanceResponse))
+	}))
+	defer server.Close()
+
+	client := NewEtherscanClient(ClientConfig{APIKey: "test-key", BaseURL: server.URL})
+	raw, err := client.FetchTokenBalance(context.Background(), "0xa39b189482f984388a34460636fea9eb181ad1a6", contract)
+	if err != nil {
+		t.Fatalf("FetchTokenBalance() error = %v", err)
+	}
+	if raw != "1234500000" {
+		t.Errorf("Raw balance mismatch: got %s, want 1234500000", raw)
+	}
+
+	balance, err := FormatTokenAmount(raw, 6)
+	if err != nil {
+		t.Fatalf("FormatTokenAmount() error = %v", err)
+	}
+	if balance != "1234.5" {
+		t.Errorf("Balance mismatch: got %s, want 1234.5", balance)
+	}
+}
+
+func TestFetchTokenBalanceAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"0","message":"NOTOK","result":"Error! Invalid contract address format"}`))
+	}))
+	defer server.Close()
+
+	client := NewEtherscanClient(ClientConfig{APIKey: "test-key", BaseURL: server.URL})
+	if _, err := client.FetchTokenBalance(context.Background(), "0xabc", "0xnot-a-contract"); err == nil {
+		t.Error("Expected API error, got none")
+	}
+}
+
+func TestFormatTokenAmount(t *testing.T) {
+	tests := []struct {
+		raw      string
+		decimals int
+		want     string
+		wantErr  bool
+	}{
+		{raw: "1500000", decimals: 6, want: "1.5"},
+		{raw: "0", decimals: 18, want: "0"},
+		{raw: "123456789012345678901234567", decimals: 18, want: "123456789.012345678901234567"},
+		{raw: "42", decimals: 0, want: "42"},
+		{raw: "1", decimals: 18, want: "0.000000000000000001"},
+		{raw: "abc", decimals: 6, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := FormatTokenAmount(tt.raw, tt.decimals)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("FormatTokenAmount(%s, %d) error = %v, wantErr %v", tt.raw, tt.decimals, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("FormatTokenAmount(%s, %d) mismatch: got %s, want %s", tt.raw, tt.decimals, got, tt.want)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package providers
 
 import (
 	"conintracker-hiring/pkg/models"
+	"strings"
 )
 
 // BenchmarkFixtures contains reusable test data for benchmarks
@@ -58,12 +59,14 @@ func NewBenchmarkFixtures(size int) *BenchmarkFixtures {
 		}
 	}
 
-	// Generate token transfers
+	// Generate token transfers. They share hashes with the normal transactions,
+	// but every other one is written in uppercase hex, as some sources report
+	// them, so consumers must compare hashes case-insensitively.
 	for i := 0; i < size; i++ {
 		fixtures.TokenTxs[i] = EtherscanTokenTx{
 			BlockNumber:     "19000000",
 			TimeStamp:       "1700000000",
-			Hash:            "0x" + padHex(i, 64),
+			Hash:            "0x" + mixedCaseHex(i, 64),
 			From:            "0x" + padHex(i%10, 40),
 			To:              "0x" + padHex(i%20, 40),
 			Value:           "1000000000000000000",
@@ -116,6 +119,14 @@ func NewBenchmarkFixtures(size int) *BenchmarkFixtures {
 	return fixtures
 }
 
+// mixedCaseHex is padHex with the digits uppercased for odd i
+func mixedCaseHex(i int, length int) string {
+	if i%2 == 1 {
+		return strings.ToUpper(padHex(i, length))
+	}
+	return padHex(i, length)
+}
+
 // padHex pads an integer to a hex string of specified length with leading zeros
 func padHex(i int, length int) string {
 	hexStr := ""
@@ -175,3 +186,7 @@ func GetMediumFixture() *BenchmarkFixtures {
 func GetSmallFixture() *BenchmarkFixtures {
 	return NewBenchmarkFixtures(100)
 }
+
+// ScalingFixtureSizes are the per-type fixture sizes used to check how the
+// pipeline scales; each step is 10x the previous one
+var ScalingFixtureSizes = []int{100, 1000, 10000, 100000}
@@ -2,6 +2,8 @@ package providers
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"testing"
 )
 
@@ -187,10 +189,33 @@ func BenchmarkNormalizationPipeline(b *testing.B) {
 	}
 }
 
+// BenchmarkNormalizePipelineScaling runs normalize, merge, and sort at each of
+// ScalingFixtureSizes; ns/op should grow roughly linearly (n log n for the sort)
+// between sizes. Each size builds its fixtures inside its own sub-benchmark so
+// only one set is live at a time.
+func BenchmarkNormalizePipelineScaling(b *testing.B) {
+	ctx := context.Background()
+
+	for _, size := range ScalingFixtureSizes {
+		b.Run(fmt.Sprintf("size=%d", size), func(b *testing.B) {
+			fixtures := NewBenchmarkFixtures(size)
+			fetcher := NewTransactionFetcher(NewConfigurableProvider(fixtures), NewEtherscanNormalizer())
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := fetcher.FetchAllTransactions(ctx, "0x1234567890123456789012345678901234567890", 1, 1); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
 // BenchmarkFetchAllTransactions benchmarks the fetch orchestration
 func BenchmarkFetchAllTransactions(b *testing.B) {
 	fixtures := GetMediumFixture()
-	mockFetcher := NewBenchmarkMockFetcher(fixtures)
+	mockFetcher := NewConfigurableProvider(fixtures)
 	normalizer := NewEtherscanNormalizer()
 	fetcher := NewTransactionFetcher(mockFetcher, normalizer)
 	ctx := context.Background()
@@ -204,7 +229,7 @@ func BenchmarkFetchAllTransactions(b *testing.B) {
 // BenchmarkParallelFetchAllTransactions benchmarks parallel fetch orchestration
 func BenchmarkParallelFetchAllTransactions(b *testing.B) {
 	fixtures := GetMediumFixture()
-	mockFetcher := NewBenchmarkMockFetcher(fixtures)
+	mockFetcher := NewConfigurableProvider(fixtures)
 	normalizer := NewEtherscanNormalizer()
 	parallelFetcher := NewParallelFetcher(mockFetcher, normalizer)
 	ctx := context.Background()
@@ -218,7 +243,7 @@ func BenchmarkParallelFetchAllTransactions(b *testing.B) {
 // BenchmarkParallelFetchVsSequential compares parallel vs sequential fetch performance
 func BenchmarkParallelFetchVsSequential(b *testing.B) {
 	fixtures := GetMediumFixture()
-	mockFetcher := NewBenchmarkMockFetcher(fixtures)
+	mockFetcher := NewConfigurableProvider(fixtures)
 	normalizer := NewEtherscanNormalizer()
 	ctx := context.Background()
 
@@ -238,3 +263,56 @@ func BenchmarkParallelFetchVsSequential(b *testing.B) {
 		}
 	})
 }
+
+// decodeViaMap is the previous decoding path: generic map, then a marshal
+// round-trip per item. Kept only as a benchmark baseline.
+func decodeViaMap(body []byte) []EtherscanNormalTx {
+	var result map[string]interface{}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil
+	}
+
+	var txs []EtherscanNormalTx
+	if resultData, ok := result["result"].([]interface{}); ok {
+		for _, item := range resultData {
+			if itemMap, ok := item.(map[string]interface{}); ok {
+				jsonData, _ := json.Marshal(itemMap)
+				var tx EtherscanNormalTx
+				if err := json.Unmarshal(jsonData, &tx); err == nil {
+					txs = append(txs, tx)
+				}
+			}
+		}
+	}
+	return txs
+}
+
+// BenchmarkDecodeResponsePage compares typed decoding of a 1000-item page
+// against the map round-trip; run with -benchmem to compare allocations
+func BenchmarkDecodeResponsePage(b *testing.B) {
+	fixtures := NewBenchmarkFixtures(1000)
+	body, err := json.Marshal(map[string]interface{}{
+		"status":  "1",
+		"message": "OK",
+		"result":  fixtures.NormalTxs,
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("Typed", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := decodeResults[EtherscanNormalTx](body, 1, 0); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("MapRoundTrip", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			decodeViaMap(body)
+		}
+	})
+}
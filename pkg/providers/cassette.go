@@ -0,0 +1,128 @@
+package providers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// cassetteEntry is a single recorded HTTP interaction stored on disk
+type cassetteEntry struct {
+	Key        string      `json:"key"`
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"` // Exact response bytes (base64 in JSON)
+}
+
+// RecordingTransport is an http.RoundTripper that forwards requests to Base and
+// saves every response to Dir so it can later be served by ReplayTransport.
+// Plug it into ClientConfig.HTTPClient to capture real Etherscan traffic.
+type RecordingTransport struct {
+	Base http.RoundTripper
+	Dir  string
+}
+
+// RoundTrip implements http.RoundTripper
+func (rt *RecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := rt.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response for recording: %w", err)
+	}
+
+	entry := cassetteEntry{
+		Key:        cassetteKey(req),
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       body,
+	}
+	if err := writeCassetteEntry(rt.Dir, entry); err != nil {
+		return nil, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// ReplayTransport is an http.RoundTripper that serves responses previously saved
+// by RecordingTransport without touching the network
+type ReplayTransport struct {
+	Dir string
+}
+
+// RoundTrip implements http.RoundTripper
+func (rt *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := cassetteKey(req)
+
+	data, err := os.ReadFile(cassettePath(rt.Dir, key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no recorded response for %s", key)
+		}
+		return nil, fmt.Errorf("failed to read recorded response: %w", err)
+	}
+
+	var entry cassetteEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("failed to parse recorded response: %w", err)
+	}
+
+	return &http.Response{
+		Status:        http.StatusText(entry.StatusCode),
+		StatusCode:    entry.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        entry.Header,
+		Body:          io.NopCloser(bytes.NewReader(entry.Body)),
+		ContentLength: int64(len(entry.Body)),
+		Request:       req,
+	}, nil
+}
+
+// cassetteKey identifies a request by method, path and query, ignoring the API key
+// so recordings can be shared and replayed with any credentials
+func cassetteKey(req *http.Request) string {
+	query := req.URL.Query()
+	query.Del("apikey")
+	return req.Method + " " + req.URL.Path + "?" + query.Encode()
+}
+
+// cassettePath maps a request key to its file inside dir
+func cassettePath(dir, key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// writeCassetteEntry saves a recorded interaction to dir
+func writeCassetteEntry(dir string, entry cassetteEntry) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode recorded response: %w", err)
+	}
+
+	if err := os.WriteFile(cassettePath(dir, entry.Key), data, 0644); err != nil {
+		return fmt.Errorf("failed to write recorded response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package providers
+
+import (
+	"conintracker-hiring/internal/testdata"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRecordThenReplayWithServerDown(t *testing.T) {
+	dir := t.TempDir()
+	address := "0xa39b189482f984388a34460636fea9eb181ad1a6"
+
+	callCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(testdata.NormalTxResponse))
+	}))
+
+	recorder := NewEtherscanClient(ClientConfig{
+		APIKey:  "real-key",
+		BaseURL: server.URL,
+		HTTPClient: &http.Client{
+			Transport: &RecordingTransport{Base: server.Client().Transport, Dir: dir},
+		},
+	})
+
+	recorded, err := recorder.FetchNormalTransactions(context.Background(), address, 1, 1)
+	if err != nil {
+		t.Fatalf("recording FetchNormalTransactions() error = %v", err)
+	}
+
+	// Take the server down; replay must not need it
+	server.Close()
+
+	replayer := NewEtherscanClient(ClientConfig{
+		APIKey:     "other-key",
+		BaseURL:    server.URL,
+		HTTPClient: &http.Client{Transport: &ReplayTransport{Dir: dir}},
+	})
+
+	replayed, err := replayer.FetchNormalTransactions(context.Background(), address, 1, 1)
+	if err != nil {
+		t.Fatalf("replaying FetchNormalTransactions() error = %v", err)
+	}
+
+	if callCount != 1 {
+		t.Errorf("Expected 1 live call, got %d", callCount)
+	}
+	if len(replayed) != len(recorded) {
+		t.Fatalf("Expected %d replayed transactions, got %d", len(recorded), len(replayed))
+	}
+	for i := range recorded {
+		if replayed[i] != recorded[i] {
+			t.Errorf("Transaction %d differs after replay: %+v vs %+v", i, replayed[i], recorded[i])
+		}
+	}
+
+	// A request that was never recorded fails instead of hitting the network
+	if _, err := replayer.FetchInternalTransactions(context.Background(), address, 1, 1); err == nil {
+		t.Error("Expected error for unrecorded request, got none")
+	}
+}
@@ -0,0 +1,16 @@
+package providers
+
+import "time"
+
+// Clock is the time source for rate limiting and retry waits. Tests substitute
+// a fake to advance time instantly instead of sleeping.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock is the Clock backed by the time package
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
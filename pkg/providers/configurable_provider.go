@@ -0,0 +1,129 @@
+package providers
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ConfigurableProvider is a Provider that serves canned responses without network
+// calls. Each transaction type can be given its own error and delay, and the
+// provider counts calls per type so tests can assert which fetches ran.
+// Configure the fields before the first fetch; it is safe for concurrent use after.
+type ConfigurableProvider struct {
+	NormalTxs     []EtherscanNormalTx
+	InternalTxs   []EtherscanInternalTx
+	TokenTxs      []EtherscanTokenTx
+	NFTTxs        []EtherscanTokenTx
+	ERC1155Txs    []EtherscanTokenTx
+	WithdrawalTxs []EtherscanWithdrawalTx
+
+	// Err is returned by every fetch whose type has no entry in Errors
+	Err error
+	// Errors holds the error returned for each transaction type
+	Errors map[TransactionType]error
+	// Delays holds how long each transaction type waits before responding.
+	// A cancelled context ends the wait early with the context's error.
+	Delays map[TransactionType]time.Duration
+
+	mu    sync.Mutex
+	calls map[TransactionType]int
+}
+
+// NewConfigurableProvider creates a provider serving the fixtures' transactions
+func NewConfigurableProvider(fixtures *BenchmarkFixtures) *ConfigurableProvider {
+	return &ConfigurableProvider{
+		NormalTxs:   fixtures.NormalTxs,
+		InternalTxs: fixtures.InternalTxs,
+		TokenTxs:    fixtures.TokenTxs,
+		NFTTxs:      fixtures.NFTTxs,
+		ERC1155Txs:  fixtures.ERC1155Txs,
+	}
+}
+
+// NewBenchmarkMockFetcher creates a provider serving the fixtures' transactions.
+//
+// Deprecated: use NewConfigurableProvider.
+func NewBenchmarkMockFetcher(fixtures *BenchmarkFixtures) *ConfigurableProvider {
+	return NewConfigurableProvider(fixtures)
+}
+
+// Calls returns how many times txType has been fetched
+func (cp *ConfigurableProvider) Calls(txType TransactionType) int {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	return cp.calls[txType]
+}
+
+// respond records the call, waits out the type's delay and returns its error
+func (cp *ConfigurableProvider) respond(ctx context.Context, txType TransactionType) error {
+	cp.mu.Lock()
+	if cp.calls == nil {
+		cp.calls = make(map[TransactionType]int)
+	}
+	cp.calls[txType]++
+	cp.mu.Unlock()
+
+	if delay := cp.Delays[txType]; delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if err, ok := cp.Errors[txType]; ok {
+		return err
+	}
+	return cp.Err
+}
+
+// FetchNormalTransactions returns NormalTxs
+func (cp *ConfigurableProvider) FetchNormalTransactions(ctx context.Context, address string, startPage, endPage int) ([]EtherscanNormalTx, error) {
+	if err := cp.respond(ctx, TxTypeNormal); err != nil {
+		return nil, err
+	}
+	return cp.NormalTxs, nil
+}
+
+// FetchInternalTransactions returns InternalTxs
+func (cp *ConfigurableProvider) FetchInternalTransactions(ctx context.Context, address string, startPage, endPage int) ([]EtherscanInternalTx, error) {
+	if err := cp.respond(ctx, TxTypeInternal); err != nil {
+		return nil, err
+	}
+	return cp.InternalTxs, nil
+}
+
+// FetchTokenTransfers returns TokenTxs
+func (cp *ConfigurableProvider) FetchTokenTransfers(ctx context.Context, address string, startPage, endPage int) ([]EtherscanTokenTx, error) {
+	if err := cp.respond(ctx, TxTypeToken); err != nil {
+		return nil, err
+	}
+	return cp.TokenTxs, nil
+}
+
+// FetchNFTTransfers returns NFTTxs
+func (cp *ConfigurableProvider) FetchNFTTransfers(ctx context.Context, address string, startPage, endPage int) ([]EtherscanTokenTx, error) {
+	if err := cp.respond(ctx, TxTypeNFT); err != nil {
+		return nil, err
+	}
+	return cp.NFTTxs, nil
+}
+
+// FetchERC1155Transfers returns ERC1155Txs
+func (cp *ConfigurableProvider) FetchERC1155Transfers(ctx context.Context, address string, startPage, endPage int) ([]EtherscanTokenTx, error) {
+	if err := cp.respond(ctx, TxTypeERC1155); err != nil {
+		return nil, err
+	}
+	return cp.ERC1155Txs, nil
+}
+
+// FetchBeaconWithdrawals returns WithdrawalTxs
+func (cp *ConfigurableProvider) FetchBeaconWithdrawals(ctx context.Context, address string, startPage, endPage int) ([]EtherscanWithdrawalTx, error) {
+	if err := cp.respond(ctx, TxTypeWithdrawal); err != nil {
+		return nil, err
+	}
+	return cp.WithdrawalTxs, nil
+}
@@ -0,0 +1,94 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestConfigurableProviderPerTypeErrors(t *testing.T) {
+	provider := &ConfigurableProvider{
+		NormalTxs: []EtherscanNormalTx{{Hash: "0x1", BlockNumber: "1", TimeStamp: "1000"}},
+		TokenTxs:  []EtherscanTokenTx{{Hash: "0x2", BlockNumber: "2", TimeStamp: "1001", TokenDecimal: "6"}},
+		Errors:    map[TransactionType]error{TxTypeToken: errMock},
+	}
+
+	fetcher := NewParallelFetcher(provider, NewEtherscanNormalizer())
+	fetcher.SetMaxConcurrent(len(fetchTypeOrder))
+
+	_, results, _ := fetcher.FetchAllTransactionsParallel(context.Background(), "0xtest", 1, 1)
+	for _, result := range results {
+		wantErr := result.TxType == TxTypeToken
+		if gotErr := errors.Is(result.Err, errMock); gotErr != wantErr {
+			t.Errorf("%s error mismatch: got %v, want injected error %v", result.TxType, result.Err, wantErr)
+		}
+	}
+	if results[TxTypeNormal].Count != 1 {
+		t.Errorf("Expected 1 normal transaction alongside the failing type, got %d", results[TxTypeNormal].Count)
+	}
+
+	for _, txType := range fetchTypeOrder {
+		if got := provider.Calls(txType); got != 1 {
+			t.Errorf("%s calls mismatch: got %d, want 1", txType, got)
+		}
+	}
+}
+
+func TestConfigurableProviderErrOverriddenPerType(t *testing.T) {
+	provider := &ConfigurableProvider{
+		InternalTxs: []EtherscanInternalTx{{Hash: "0x1"}},
+		Err:         errMock,
+		Errors:      map[TransactionType]error{TxTypeInternal: nil},
+	}
+
+	if _, err := provider.FetchNormalTransactions(context.Background(), "0xtest", 1, 1); !errors.Is(err, errMock) {
+		t.Errorf("Expected the default error for normal transactions, got %v", err)
+	}
+	txs, err := provider.FetchInternalTransactions(context.Background(), "0xtest", 1, 1)
+	if err != nil {
+		t.Fatalf("Expected the per-type nil error to override Err, got %v", err)
+	}
+	if len(txs) != 1 {
+		t.Errorf("Expected 1 internal transaction, got %d", len(txs))
+	}
+}
+
+func TestConfigurableProviderDelay(t *testing.T) {
+	const delay = 50 * time.Millisecond
+	provider := &ConfigurableProvider{
+		Delays: map[TransactionType]time.Duration{TxTypeNFT: delay},
+	}
+
+	start := time.Now()
+	if _, err := provider.FetchNFTTransfers(context.Background(), "0xtest", 1, 1); err != nil {
+		t.Fatalf("FetchNFTTransfers() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < delay {
+		t.Errorf("Expected NFT fetch to wait at least %v, took %v", delay, elapsed)
+	}
+
+	start = time.Now()
+	if _, err := provider.FetchTokenTransfers(context.Background(), "0xtest", 1, 1); err != nil {
+		t.Fatalf("FetchTokenTransfers() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed >= delay {
+		t.Errorf("Expected undelayed token fetch to return immediately, took %v", elapsed)
+	}
+}
+
+func TestConfigurableProviderDelayHonorsCancel(t *testing.T) {
+	provider := &ConfigurableProvider{
+		Delays: map[TransactionType]time.Duration{TxTypeNormal: time.Minute},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := provider.FetchNormalTransactions(ctx, "0xtest", 1, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the delay to end with the context error, got %v", err)
+	}
+	if got := provider.Calls(TxTypeNormal); got != 1 {
+		t.Errorf("Calls mismatch: got %d, want 1", got)
+	}
+}
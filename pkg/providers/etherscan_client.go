@@ -3,41 +3,226 @@ package providers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
 const (
 	// Etherscan API base URL (V2)
 	EtherscanBaseURL = "https://api.etherscan.io/v2/api"
-	
-	// Default pagination
-	DefaultPageSize = 10000
+
+	// DefaultChain is the chain queried when ClientConfig.Chain is empty
+	DefaultChain = "ethereum"
+
+	// Default pagination. Etherscan caps page size (offset) at 10,000 records.
+	DefaultPageSize   = 10000
+	MaxPageSize       = 10000
+	ResultWindow      = 10000 // Etherscan rejects a query whose page times offset exceeds this
 	DefaultStartBlock = 0
-	DefaultEndBlock = 99999999
-	
+	DefaultEndBlock   = 99999999
+
 	// Rate limit delays (Etherscan free tier - V2 API more restrictive)
 	RateLimitDelay = 500 * time.Millisecond
+
+	// Retries on HTTP 429 and truncated bodies, honoring Retry-After up to DefaultMaxRetryWait per wait
+	DefaultMaxRetries   = 3
+	DefaultMaxRetryWait = 30 * time.Second
+
+	// DefaultMaxResultsPerRequest bounds the records decoded from one response. A
+	// well-behaved server never returns more than a page (MaxPageSize records).
+	DefaultMaxResultsPerRequest = MaxPageSize
+
+	// maxBytesPerResult and responseEnvelopeBytes size the response body read for
+	// MaxResultsPerRequest records. Records average well under 1 KiB; contract
+	// creations carry up to ~48 KiB of input, so a page can mix in a few of them.
+	maxBytesPerResult     = 16 << 10
+	responseEnvelopeBytes = 64 << 10
 )
 
+// chainInfo describes a chain reachable through the Etherscan V2 API
+type chainInfo struct {
+	id                int    // Etherscan V2 chain ID
+	nativeSymbol      string // Symbol of the chain's gas token
+	beaconWithdrawals bool   // Whether the chain has consensus-layer withdrawals
+}
+
+// chains maps supported chain names to their details
+var chains = map[string]chainInfo{
+	"ethereum": {id: 1, nativeSymbol: "ETH", beaconWithdrawals: true},
+	"optimism": {id: 10, nativeSymbol: "ETH"},
+	"bsc":      {id: 56, nativeSymbol: "BNB"},
+	"polygon":  {id: 137, nativeSymbol: "POL"},
+	"base":     {id: 8453, nativeSymbol: "ETH"},
+	"arbitrum": {id: 42161, nativeSymbol: "ETH"},
+}
+
+// ChainID returns the Etherscan V2 chain ID for a chain name
+func ChainID(chain string) (int, bool) {
+	info, ok := chains[strings.ToLower(chain)]
+	return info.id, ok
+}
+
+// ChainNativeSymbol returns the native asset symbol for a chain name, or
+// DefaultNativeSymbol for unknown chains
+func ChainNativeSymbol(chain string) string {
+	if info, ok := chains[strings.ToLower(chain)]; ok {
+		return info.nativeSymbol
+	}
+	return DefaultNativeSymbol
+}
+
+// ChainHasBeaconWithdrawals reports whether a chain has consensus-layer withdrawals
+// to fetch. Only Ethereum mainnet does; L2s and sidechains have no beacon chain.
+func ChainHasBeaconWithdrawals(chain string) bool {
+	return chains[strings.ToLower(chain)].beaconWithdrawals
+}
+
+// SupportedChains returns the supported chain names in sorted order
+func SupportedChains() []string {
+	names := make([]string, 0, len(chains))
+	for name := range chains {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ErrRateLimited is returned when the API keeps answering HTTP 429 after all retries
+var ErrRateLimited = errors.New("rate limited by API (HTTP 429)")
+
+// ErrTooManyResults is returned when a response holds more records than the client's
+// MaxResultsPerRequest, or more bytes than that many records can take; reading and
+// decoding stop at the limit rather than growing without bound
+var ErrTooManyResults = errors.New("too many results in response")
+
+// ErrResultWindow is returned for a page past Etherscan's result window, which the
+// API rejects as "Result window is too large". Larger histories must be split by
+// block range (see BlockRanger).
+var ErrResultWindow = fmt.Errorf("page is past the %d-record result window", ResultWindow)
+
+// ErrTruncatedResponse is returned when a response body ends before its JSON does,
+// typically because the connection dropped mid-transfer. Unlike an API error it is
+// retried like HTTP 429; it is returned once the retries run out.
+type ErrTruncatedResponse struct {
+	Err error // Read or parse error that exposed the truncation
+}
+
+func (e *ErrTruncatedResponse) Error() string {
+	return fmt.Sprintf("truncated response: %v", e.Err)
+}
+
+func (e *ErrTruncatedResponse) Unwrap() error {
+	return e.Err
+}
+
+// checkComplete returns *ErrTruncatedResponse when body is JSON cut off before its
+// end. Complete bodies, including malformed ones, pass and fail later in decoding.
+func checkComplete(body []byte) error {
+	if json.Valid(body) {
+		return nil
+	}
+	var syntaxErr *json.SyntaxError
+	if err := json.Unmarshal(body, new(json.RawMessage)); errors.As(err, &syntaxErr) && syntaxErr.Offset >= int64(len(body)) {
+		return &ErrTruncatedResponse{Err: err}
+	}
+	return nil
+}
+
+// retriable reports whether a failed request is worth repeating
+func retriable(err error) bool {
+	var truncated *ErrTruncatedResponse
+	return errors.Is(err, ErrRateLimited) || errors.As(err, &truncated)
+}
+
+// ErrChainNotSupported is returned when the API key has no access to the queried
+// chain, e.g. a free-tier key on a chain that needs a paid Etherscan V2 plan
+type ErrChainNotSupported struct {
+	ChainID int
+	Message string // Etherscan's explanation
+}
+
+func (e *ErrChainNotSupported) Error() string {
+	return fmt.Sprintf("API key has no access to chain %d: %s", e.ChainID, e.Message)
+}
+
+// chainAccessMessages are fragments of the messages Etherscan returns when a key
+// cannot query a chain
+var chainAccessMessages = []string{
+	"not supported for this chain",
+	"unsupported chainid",
+}
+
+// apiError converts an Etherscan error message into an error, recognizing
+// chain access failures as *ErrChainNotSupported
+func apiError(chainID int, message string) error {
+	lower := strings.ToLower(message)
+	for _, fragment := range chainAccessMessages {
+		if strings.Contains(lower, fragment) {
+			return &ErrChainNotSupported{ChainID: chainID, Message: message}
+		}
+	}
+	return fmt.Errorf("etherscan error: %s", message)
+}
+
 // EtherscanClient implements the Provider interface for Etherscan API
 type EtherscanClient struct {
-	apiKey     string
-	httpClient *http.Client
-	baseURL    string
-	lastReq    time.Time // Track last request for rate limiting
+	keys         []*apiKeySlot // Rotated round-robin, one request each
+	nextKey      atomic.Uint64
+	httpClient   *http.Client
+	baseURL      string
+	chain        string
+	chainID      int
+	rateLimit    time.Duration // Minimum spacing between requests on each key
+	flights      flightGroup
+	maxRetries   int
+	maxRetryWait time.Duration
+	pageSize     int // Records per page (Etherscan's offset parameter)
+	maxResults   int // Most records decoded from one response; 0 is unlimited
+	clock        Clock
+}
+
+// apiKeySlot is an API key with its own rate-limit budget
+type apiKeySlot struct {
+	key     string
+	lastReq time.Time  // Track last request for rate limiting
+	mu      sync.Mutex // Guards lastReq
+}
+
+// reserve claims the key's next free request slot at or after now and returns its start time
+func (k *apiKeySlot) reserve(interval time.Duration, now time.Time) time.Time {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	slot := k.lastReq.Add(interval)
+	if slot.Before(now) {
+		slot = now
+	}
+	k.lastReq = slot
+	return slot
 }
 
 // ClientConfig holds configuration for Etherscan client
 type ClientConfig struct {
-	APIKey      string
-	HTTPClient  *http.Client
-	BaseURL     string
-	RateLimit   time.Duration
+	APIKey               string
+	APIKeys              []string // Key pool rotated round-robin per request, each rate limited separately; overrides APIKey
+	HTTPClient           *http.Client
+	BaseURL              string
+	Chain                string        // Chain name, see SupportedChains; empty uses DefaultChain
+	RateLimit            time.Duration // Minimum spacing between requests; 0 uses RateLimitDelay
+	MaxRetries           int           // Retries after HTTP 429 or a truncated body; 0 uses DefaultMaxRetries, negative disables
+	MaxRetryWait         time.Duration // Upper bound on a single Retry-After wait; 0 uses DefaultMaxRetryWait
+	PageSize             int           // Records per page; 0 uses DefaultPageSize
+	MaxResultsPerRequest int           // Records decoded per response before ErrTooManyResults; 0 uses DefaultMaxResultsPerRequest (at least PageSize), negative disables
+	Clock                Clock         // Time source for rate limit and retry waits; nil uses the real clock
 }
 
 // NewEtherscanClient creates a new Etherscan API client
@@ -50,78 +235,275 @@ func NewEtherscanClient(cfg ClientConfig) *EtherscanClient {
 	if cfg.BaseURL == "" {
 		cfg.BaseURL = EtherscanBaseURL
 	}
-	
+	if cfg.MaxRetries == 0 {
+		cfg.MaxRetries = DefaultMaxRetries
+	} else if cfg.MaxRetries < 0 {
+		cfg.MaxRetries = 0
+	}
+	if cfg.MaxRetryWait <= 0 {
+		cfg.MaxRetryWait = DefaultMaxRetryWait
+	}
+	if cfg.PageSize <= 0 {
+		cfg.PageSize = DefaultPageSize
+	}
+	if cfg.MaxResultsPerRequest == 0 {
+		cfg.MaxResultsPerRequest = max(DefaultMaxResultsPerRequest, cfg.PageSize)
+	} else if cfg.MaxResultsPerRequest < 0 {
+		cfg.MaxResultsPerRequest = 0
+	}
+	if cfg.RateLimit <= 0 {
+		cfg.RateLimit = RateLimitDelay
+	}
+	if cfg.Chain == "" {
+		cfg.Chain = DefaultChain
+	}
+	if cfg.Clock == nil {
+		cfg.Clock = realClock{}
+	}
+	chain := strings.ToLower(cfg.Chain)
+	chainID, _ := ChainID(chain) // Unknown chains send chainid 0, which Etherscan rejects
+
+	if len(cfg.APIKeys) == 0 {
+		cfg.APIKeys = []string{cfg.APIKey}
+	}
+	keys := make([]*apiKeySlot, len(cfg.APIKeys))
+	for i, key := range cfg.APIKeys {
+		keys[i] = &apiKeySlot{key: key, lastReq: cfg.Clock.Now()}
+	}
+
 	return &EtherscanClient{
-		apiKey:     cfg.APIKey,
-		httpClient: cfg.HTTPClient,
-		baseURL:    cfg.BaseURL,
-		lastReq:    time.Now(),
+		keys:         keys,
+		httpClient:   cfg.HTTPClient,
+		baseURL:      cfg.BaseURL,
+		chain:        chain,
+		chainID:      chainID,
+		rateLimit:    cfg.RateLimit,
+		maxRetries:   cfg.MaxRetries,
+		maxRetryWait: cfg.MaxRetryWait,
+		pageSize:     cfg.PageSize,
+		maxResults:   cfg.MaxResultsPerRequest,
+		clock:        cfg.Clock,
 	}
 }
 
-// executeRequest performs an HTTP request with rate limiting and error handling
-func (c *EtherscanClient) executeRequest(ctx context.Context, params url.Values) (map[string]interface{}, error) {
-	// Rate limiting: wait if necessary
-	timeSinceLastReq := time.Since(c.lastReq)
-	if timeSinceLastReq < RateLimitDelay {
+// Chain returns the name of the chain the client queries
+func (c *EtherscanClient) Chain() string {
+	return c.chain
+}
+
+// PageSize returns the number of records the client requests per page
+func (c *EtherscanClient) PageSize() int {
+	return c.pageSize
+}
+
+// MaxPage returns the last page the client can request at its page size without
+// passing Etherscan's result window
+func (c *EtherscanClient) MaxPage() int {
+	return max(ResultWindow/c.pageSize, 1)
+}
+
+// executeRequest performs an HTTP request with rate limiting and returns the raw response body.
+// Concurrent identical requests share a single round-trip; if the caller that made it
+// is canceled, the others repeat the request rather than fail with it.
+func (c *EtherscanClient) executeRequest(ctx context.Context, params url.Values) ([]byte, error) {
+	return c.flights.do(ctx, params.Encode(), func(ctx context.Context) ([]byte, error) {
+		return c.executeWithRetry(ctx, params)
+	})
+}
+
+// executeWithRetry performs a request, retrying HTTP 429 responses after the
+// server's Retry-After delay and truncated responses straight away
+func (c *EtherscanClient) executeWithRetry(ctx context.Context, params url.Values) ([]byte, error) {
+	for attempt := 0; ; attempt++ {
+		body, wait, err := c.doRequest(ctx, params)
+		if !retriable(err) || attempt >= c.maxRetries {
+			return body, err
+		}
+
 		select {
-		case <-time.After(RateLimitDelay - timeSinceLastReq):
+		case <-c.clock.After(wait):
 		case <-ctx.Done():
 			return nil, ctx.Err()
 		}
 	}
-	c.lastReq = time.Now()
+}
+
+// doRequest performs a single HTTP request with the next key in the pool. On HTTP 429
+// it returns ErrRateLimited along with how long to wait before retrying, and a body
+// cut off mid-transfer returns *ErrTruncatedResponse.
+func (c *EtherscanClient) doRequest(ctx context.Context, params url.Values) ([]byte, time.Duration, error) {
+	// Rate limiting: reserve the key's next free slot, then wait for it
+	key := c.keys[(c.nextKey.Add(1)-1)%uint64(len(c.keys))]
+	now := c.clock.Now()
+	slot := key.reserve(c.rateLimit, now)
+
+	if wait := slot.Sub(now); wait > 0 {
+		select {
+		case <-c.clock.After(wait):
+		case <-ctx.Done():
+			return nil, 0, ctx.Err()
+		}
+	}
 
 	// Build URL
+	query := maps.Clone(params)
+	query.Set("apikey", key.key)
 	u, _ := url.Parse(c.baseURL)
-	u.RawQuery = params.Encode()
+	u.RawQuery = query.Encode()
 
 	// Create request
 	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, 0, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Execute request
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, 0, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
-	// Read response
-	body, err := io.ReadAll(resp.Body)
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, c.retryWait(resp.Header.Get("Retry-After")), ErrRateLimited
+	}
+
+	// Read response, stopping past the size MaxResultsPerRequest records can take
+	limit := c.maxBodyBytes()
+	reader := io.Reader(resp.Body)
+	if limit > 0 {
+		reader = io.LimitReader(resp.Body, limit+1)
+	}
+	body, err := io.ReadAll(reader)
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, 0, &ErrTruncatedResponse{Err: err}
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read response: %w", err)
+	}
+	if limit > 0 && int64(len(body)) > limit {
+		return nil, 0, fmt.Errorf("%w: response body over %d bytes", ErrTooManyResults, limit)
+	}
+
+	return body, 0, checkComplete(body)
+}
+
+// maxBodyBytes returns the most response bytes read for one request; 0 is unlimited
+func (c *EtherscanClient) maxBodyBytes() int64 {
+	if c.maxResults <= 0 {
+		return 0
+	}
+	return int64(c.maxResults)*maxBytesPerResult + responseEnvelopeBytes
+}
+
+// retryWait converts a Retry-After header (seconds or HTTP-date) into a wait,
+// capped at the client's maximum. A missing or invalid header waits RateLimitDelay.
+func (c *EtherscanClient) retryWait(retryAfter string) time.Duration {
+	wait, ok := parseRetryAfter(retryAfter, c.clock.Now())
+	if !ok {
+		wait = RateLimitDelay
+	}
+	if wait > c.maxRetryWait {
+		wait = c.maxRetryWait
+	}
+	return wait
+}
+
+// parseRetryAfter parses a Retry-After header value relative to now
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		wait := at.Sub(now)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+// fetchPages requests pages startPage..endPage of an account action within blocks
+// startBlock..endBlock, each holding up to the client's page size (Etherscan's offset),
+// and concatenates the results. It stops early once a page comes back short, since
+// later pages would be empty. Needing a page past MaxPage fails with ErrResultWindow.
+func fetchPages[T any](ctx context.Context, c *EtherscanClient, action, address string, startBlock, endBlock uint64, startPage, endPage int) ([]T, error) {
+	if startPage < 1 {
+		startPage = 1
+	}
+
+	var all []T
+	for page := startPage; page <= endPage; page++ {
+		if page > c.MaxPage() {
+			return nil, fmt.Errorf("page %d of %d records: %w", page, c.pageSize, ErrResultWindow)
+		}
+		params := c.buildParams(action, "account", address)
+		params.Set("startblock", strconv.FormatUint(startBlock, 10))
+		params.Set("endblock", strconv.FormatUint(endBlock, 10))
+		params.Set("page", strconv.Itoa(page))
+		params.Set("offset", strconv.Itoa(c.pageSize))
+		params.Set("sort", "asc")
+
+		txs, skipped, err := fetchResults[T](ctx, c, params)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, txs...)
+
+		// Skipped records still filled the page, so they count toward a full one
+		if len(txs)+skipped < c.pageSize {
+			break
+		}
+	}
+
+	return all, nil
+}
+
+// fetchResults executes a request and decodes the result list directly into T,
+// also returning how many records were skipped because they did not decode
+func fetchResults[T any](ctx context.Context, c *EtherscanClient, params url.Values) ([]T, int, error) {
+	body, err := c.executeRequest(ctx, params)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, 0, err
 	}
+	return decodeResults[T](body, c.chainID, c.maxResults)
+}
 
-	// Parse JSON
-	var result map[string]interface{}
-	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+// decodeResults parses an Etherscan response body, surfacing API errors
+// reported as a string result. chainID identifies the queried chain in errors.
+// More than maxResults records fails with ErrTooManyResults; 0 is unlimited.
+// Records that do not decode into T are dropped and counted in skipped.
+func decodeResults[T any](body []byte, chainID, maxResults int) (results []T, skipped int, err error) {
+	resp := EtherscanResponse[T]{maxResults: maxResults}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, 0, fmt.Errorf("failed to parse response: %w", err)
 	}
 
-	// Check for API errors
-	if status, ok := result["status"].(string); ok {
-		if status == "0" {
-			if message, ok := result["message"].(string); ok {
-				if message == "NOTOK" {
-					if resultMsg, ok := result["result"].(string); ok {
-										return nil, fmt.Errorf("etherscan error: %s", resultMsg)
-									}
-								}
-							}
-						}
-					}
+	// Check for API errors. Status 0 with "No transactions found" and an empty
+	// result list is an empty wallet, not a failure, and falls through.
+	if resp.Status == "0" && resp.Message == "NOTOK" && resp.ResultText != "" {
+		return nil, 0, apiError(chainID, resp.ResultText)
+	}
 
-	return result, nil
+	return resp.Result, resp.Skipped, nil
 }
 
-// buildParams creates base query parameters for Etherscan API V2
+// buildParams creates base query parameters for Etherscan API V2. The API key is
+// added per request by doRequest.
 func (c *EtherscanClient) buildParams(action, module string, address string) url.Values {
 	params := url.Values{}
-	params.Set("chainid", "1") // Ethereum mainnet
-	params.Set("apikey", c.apiKey)
+	params.Set("chainid", strconv.Itoa(c.chainID))
 	params.Set("module", module)
 	params.Set("action", action)
 	params.Set("address", address)
@@ -130,156 +512,72 @@ func (c *EtherscanClient) buildParams(action, module string, address string) url
 
 // FetchNormalTransactions fetches normal ETH transfers from Etherscan
 func (c *EtherscanClient) FetchNormalTransactions(ctx context.Context, address string, startPage, endPage int) ([]EtherscanNormalTx, error) {
-	params := c.buildParams("txlist", "account", address)
-	params.Set("startblock", strconv.Itoa(DefaultStartBlock))
-	params.Set("endblock", strconv.Itoa(DefaultEndBlock))
-	params.Set("page", strconv.Itoa(startPage))
-	params.Set("offset", strconv.Itoa(endPage - startPage + 1))
-	params.Set("sort", "asc")
-
-	result, err := c.executeRequest(ctx, params)
-	if err != nil {
-		return nil, err
-	}
-
-	// Parse results
-	var txs []EtherscanNormalTx
-	if resultData, ok := result["result"].([]interface{}); ok {
-		for _, item := range resultData {
-			if itemMap, ok := item.(map[string]interface{}); ok {
-				// Convert map to JSON and back to typed struct
-				jsonData, _ := json.Marshal(itemMap)
-				var tx EtherscanNormalTx
-				if err := json.Unmarshal(jsonData, &tx); err == nil {
-					txs = append(txs, tx)
-				}
-			}
-		}
-	}
-
-	return txs, nil
+	return fetchPages[EtherscanNormalTx](ctx, c, "txlist", address, DefaultStartBlock, DefaultEndBlock, startPage, endPage)
 }
 
 // FetchInternalTransactions fetches internal contract interactions from Etherscan
 func (c *EtherscanClient) FetchInternalTransactions(ctx context.Context, address string, startPage, endPage int) ([]EtherscanInternalTx, error) {
-	params := c.buildParams("txlistinternal", "account", address)
-	params.Set("startblock", strconv.Itoa(DefaultStartBlock))
-	params.Set("endblock", strconv.Itoa(DefaultEndBlock))
-	params.Set("page", strconv.Itoa(startPage))
-	params.Set("offset", strconv.Itoa(endPage - startPage + 1))
-	params.Set("sort", "asc")
-
-	result, err := c.executeRequest(ctx, params)
-	if err != nil {
-		return nil, err
-	}
-
-	// Parse results
-	var txs []EtherscanInternalTx
-	if resultData, ok := result["result"].([]interface{}); ok {
-		for _, item := range resultData {
-			if itemMap, ok := item.(map[string]interface{}); ok {
-				jsonData, _ := json.Marshal(itemMap)
-				var tx EtherscanInternalTx
-				if err := json.Unmarshal(jsonData, &tx); err == nil {
-					txs = append(txs, tx)
-				}
-			}
-		}
-	}
-
-	return txs, nil
+	return fetchPages[EtherscanInternalTx](ctx, c, "txlistinternal", address, DefaultStartBlock, DefaultEndBlock, startPage, endPage)
 }
 
 // FetchTokenTransfers fetches ERC-20 token transfers from Etherscan
 func (c *EtherscanClient) FetchTokenTransfers(ctx context.Context, address string, startPage, endPage int) ([]EtherscanTokenTx, error) {
-	params := c.buildParams("tokentx", "account", address)
-	params.Set("startblock", strconv.Itoa(DefaultStartBlock))
-	params.Set("endblock", strconv.Itoa(DefaultEndBlock))
-	params.Set("page", strconv.Itoa(startPage))
-	params.Set("offset", strconv.Itoa(endPage - startPage + 1))
-	params.Set("sort", "asc")
-
-	result, err := c.executeRequest(ctx, params)
-	if err != nil {
-		return nil, err
-	}
-
-	// Parse results
-	var txs []EtherscanTokenTx
-	if resultData, ok := result["result"].([]interface{}); ok {
-		for _, item := range resultData {
-			if itemMap, ok := item.(map[string]interface{}); ok {
-				jsonData, _ := json.Marshal(itemMap)
-				var tx EtherscanTokenTx
-				if err := json.Unmarshal(jsonData, &tx); err == nil {
-					txs = append(txs, tx)
-				}
-			}
-		}
-	}
-
-	return txs, nil
+	return fetchPages[EtherscanTokenTx](ctx, c, "tokentx", address, DefaultStartBlock, DefaultEndBlock, startPage, endPage)
 }
 
 // FetchNFTTransfers fetches ERC-721 NFT transfers from Etherscan
 func (c *EtherscanClient) FetchNFTTransfers(ctx context.Context, address string, startPage, endPage int) ([]EtherscanTokenTx, error) {
-	params := c.buildParams("tokennfttx", "account", address)
-	params.Set("startblock", strconv.Itoa(DefaultStartBlock))
-	params.Set("endblock", strconv.Itoa(DefaultEndBlock))
-	params.Set("page", strconv.Itoa(startPage))
-	params.Set("offset", strconv.Itoa(endPage - startPage + 1))
-	params.Set("sort", "asc")
-
-	result, err := c.executeRequest(ctx, params)
-	if err != nil {
-		return nil, err
-	}
-
-	// Parse results
-	var txs []EtherscanTokenTx
-	if resultData, ok := result["result"].([]interface{}); ok {
-		for _, item := range resultData {
-			if itemMap, ok := item.(map[string]interface{}); ok {
-				jsonData, _ := json.Marshal(itemMap)
-				var tx EtherscanTokenTx
-				if err := json.Unmarshal(jsonData, &tx); err == nil {
-					txs = append(txs, tx)
-				}
-			}
-		}
-	}
-
-	return txs, nil
+	return fetchPages[EtherscanTokenTx](ctx, c, "tokennfttx", address, DefaultStartBlock, DefaultEndBlock, startPage, endPage)
 }
 
 // FetchERC1155Transfers fetches ERC-1155 multi-token transfers from Etherscan
 func (c *EtherscanClient) FetchERC1155Transfers(ctx context.Context, address string, startPage, endPage int) ([]EtherscanTokenTx, error) {
-	params := c.buildParams("token1155tx", "account", address)
-	params.Set("startblock", strconv.Itoa(DefaultStartBlock))
-	params.Set("endblock", strconv.Itoa(DefaultEndBlock))
-	params.Set("page", strconv.Itoa(startPage))
-	params.Set("offset", strconv.Itoa(endPage - startPage + 1))
-	params.Set("sort", "asc")
-
-	result, err := c.executeRequest(ctx, params)
-	if err != nil {
-		return nil, err
-	}
-
-	// Parse results
-	var txs []EtherscanTokenTx
-	if resultData, ok := result["result"].([]interface{}); ok {
-		for _, item := range resultData {
-			if itemMap, ok := item.(map[string]interface{}); ok {
-				jsonData, _ := json.Marshal(itemMap)
-				var tx EtherscanTokenTx
-				if err := json.Unmarshal(jsonData, &tx); err == nil {
-					txs = append(txs, tx)
-				}
-			}
-		}
-	}
+	return fetchPages[EtherscanTokenTx](ctx, c, "token1155tx", address, DefaultStartBlock, DefaultEndBlock, startPage, endPage)
+}
+
+// FetchBeaconWithdrawals fetches consensus-layer withdrawals from Etherscan
+func (c *EtherscanClient) FetchBeaconWithdrawals(ctx context.Context, address string, startPage, endPage int) ([]EtherscanWithdrawalTx, error) {
+	return fetchPages[EtherscanWithdrawalTx](ctx, c, "txsBeaconWithdrawal", address, DefaultStartBlock, DefaultEndBlock, startPage, endPage)
+}
+
+// WithBlockRange returns a view of the client that only fetches blocks
+// startBlock..endBlock (inclusive). The view shares the client's rate limiter.
+func (c *EtherscanClient) WithBlockRange(startBlock, endBlock uint64) Provider {
+	return &blockRangeClient{EtherscanClient: c, startBlock: startBlock, endBlock: endBlock}
+}
+
+// blockRangeClient is an EtherscanClient restricted to a block range
+type blockRangeClient struct {
+	*EtherscanClient
+	startBlock, endBlock uint64
+}
+
+// FetchNormalTransactions fetches normal ETH transfers within the block range
+func (c *blockRangeClient) FetchNormalTransactions(ctx context.Context, address string, startPage, endPage int) ([]EtherscanNormalTx, error) {
+	return fetchPages[EtherscanNormalTx](ctx, c.EtherscanClient, "txlist", address, c.startBlock, c.endBlock, startPage, endPage)
+}
+
+// FetchInternalTransactions fetches internal contract interactions within the block range
+func (c *blockRangeClient) FetchInternalTransactions(ctx context.Context, address string, startPage, endPage int) ([]EtherscanInternalTx, error) {
+	return fetchPages[EtherscanInternalTx](ctx, c.EtherscanClient, "txlistinternal", address, c.startBlock, c.endBlock, startPage, endPage)
+}
+
+// FetchTokenTransfers fetches ERC-20 token transfers within the block range
+func (c *blockRangeClient) FetchTokenTransfers(ctx context.Context, address string, startPage, endPage int) ([]EtherscanTokenTx, error) {
+	return fetchPages[EtherscanTokenTx](ctx, c.EtherscanClient, "tokentx", address, c.startBlock, c.endBlock, startPage, endPage)
+}
+
+// FetchNFTTransfers fetches ERC-721 NFT transfers within the block range
+func (c *blockRangeClient) FetchNFTTransfers(ctx context.Context, address string, startPage, endPage int) ([]EtherscanTokenTx, error) {
+	return fetchPages[EtherscanTokenTx](ctx, c.EtherscanClient, "tokennfttx", address, c.startBlock, c.endBlock, startPage, endPage)
+}
+
+// FetchERC1155Transfers fetches ERC-1155 multi-token transfers within the block range
+func (c *blockRangeClient) FetchERC1155Transfers(ctx context.Context, address string, startPage, endPage int) ([]EtherscanTokenTx, error) {
+	return fetchPages[EtherscanTokenTx](ctx, c.EtherscanClient, "token1155tx", address, c.startBlock, c.endBlock, startPage, endPage)
+}
 
-	return txs, nil
+// FetchBeaconWithdrawals fetches consensus-layer withdrawals within the block range
+func (c *blockRangeClient) FetchBeaconWithdrawals(ctx context.Context, address string, startPage, endPage int) ([]EtherscanWithdrawalTx, error) {
+	return fetchPages[EtherscanWithdrawalTx](ctx, c.EtherscanClient, "txsBeaconWithdrawal", address, c.startBlock, c.endBlock, startPage, endPage)
 }
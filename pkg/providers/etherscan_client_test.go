@@ -3,8 +3,13 @@ package providers
 import (
 	"conintracker-hiring/internal/testdata"
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -150,6 +155,40 @@ func TestEtherscanClientFetchERC1155Transfers(t *testing.T) {
 	}
 }
 
+func TestEtherscanClientFetchBeaconWithdrawals(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if action := r.URL.Query().Get("action"); action != "txsBeaconWithdrawal" {
+			t.Errorf("Expected action txsBeaconWithdrawal, got %s", action)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(testdata.BeaconWithdrawalResponse))
+	}))
+	defer server.Close()
+
+	cfg := ClientConfig{
+		APIKey:     "test-key",
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+	}
+	client := NewEtherscanClient(cfg)
+
+	txs, err := client.FetchBeaconWithdrawals(context.Background(), "0xa39b189482f984388a34460636fea9eb181ad1a6", 1, 1)
+	if err != nil {
+		t.Fatalf("FetchBeaconWithdrawals() error = %v", err)
+	}
+
+	if len(txs) != 2 {
+		t.Fatalf("Expected 2 withdrawals, got %d", len(txs))
+	}
+
+	if txs[0].ValidatorIndex != "482913" {
+		t.Errorf("Expected validator index 482913, got %s", txs[0].ValidatorIndex)
+	}
+	if txs[1].Amount != "32000000000" {
+		t.Errorf("Expected amount 32000000000, got %s", txs[1].Amount)
+	}
+}
+
 func TestEtherscanClientErrorHandling(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -170,6 +209,101 @@ func TestEtherscanClientErrorHandling(t *testing.T) {
 	}
 }
 
+func TestDecodeResults(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantCount   int
+		wantSkipped int
+		wantErr     bool
+	}{
+		{name: "records", body: testdata.NormalTxResponse, wantCount: 2},
+		{
+			// One record with a mistyped field is dropped; the rest of the page survives
+			name:        "undecodable_record",
+			body:        `{"status":"1","message":"OK","result":[{"hash":"0x1"},{"hash":2},null,{"hash":"0x3"}]}`,
+			wantCount:   2,
+			wantSkipped: 2,
+		},
+		{name: "empty_result", body: testdata.EmptyResultResponse, wantCount: 0},
+		{name: "string_error_result", body: testdata.ErrorResponse, wantErr: true},
+		{name: "no_transactions_found", body: testdata.NoTransactionsResponse, wantCount: 0},
+		{name: "malformed_json", body: `{"status":`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txs, skipped, err := decodeResults[EtherscanNormalTx]([]byte(tt.body), 1, 0)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("decodeResults() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(txs) != tt.wantCount {
+				t.Errorf("Expected %d transactions, got %d", tt.wantCount, len(txs))
+			}
+			if skipped != tt.wantSkipped {
+				t.Errorf("Expected %d skipped records, got %d", tt.wantSkipped, skipped)
+			}
+		})
+	}
+}
+
+func TestEtherscanClientRejectsOversizedResponse(t *testing.T) {
+	var records []string
+	for i := 0; i < 50; i++ {
+		records = append(records, fmt.Sprintf(`{"hash":"0x%x","blockNumber":"%d"}`, i, i))
+	}
+	body := `{"status":"1","message":"OK","result":[` + strings.Join(records, ",") + `]}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client := NewEtherscanClient(ClientConfig{APIKey: "test-key", BaseURL: server.URL, RateLimit: time.Millisecond, PageSize: 10, MaxResultsPerRequest: 10})
+	txs, err := client.FetchNormalTransactions(context.Background(), "0xtest", 1, 1)
+	if !errors.Is(err, ErrTooManyResults) {
+		t.Fatalf("Expected ErrTooManyResults, got %v", err)
+	}
+	if txs != nil {
+		t.Errorf("Expected no transactions, got %d", len(txs))
+	}
+
+	// At the limit the response decodes normally; a negative limit disables the guard
+	for _, limit := range []int{50, -1} {
+		client = NewEtherscanClient(ClientConfig{APIKey: "test-key", BaseURL: server.URL, RateLimit: time.Millisecond, PageSize: 100, MaxResultsPerRequest: limit})
+		txs, err = client.FetchNormalTransactions(context.Background(), "0xtest", 1, 1)
+		if err != nil || len(txs) != 50 {
+			t.Errorf("MaxResultsPerRequest %d: got %d transactions, error %v; want 50", limit, len(txs), err)
+		}
+	}
+}
+
+func TestEtherscanClientStopsReadingOversizedBody(t *testing.T) {
+	// One record padded far past what two records may take
+	padding := strings.Repeat("0", 4<<20)
+	body := `{"status":"1","message":"OK","result":[{"hash":"0x1","input":"0x` + padding + `"}]}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client := NewEtherscanClient(ClientConfig{APIKey: "test-key", BaseURL: server.URL, RateLimit: time.Millisecond, PageSize: 2, MaxResultsPerRequest: 2})
+	_, err := client.FetchNormalTransactions(context.Background(), "0xtest", 1, 1)
+	if !errors.Is(err, ErrTooManyResults) {
+		t.Fatalf("Expected ErrTooManyResults, got %v", err)
+	}
+
+	// Without a limit the whole body is read and decoded
+	client = NewEtherscanClient(ClientConfig{APIKey: "test-key", BaseURL: server.URL, RateLimit: time.Millisecond, PageSize: 2, MaxResultsPerRequest: -1})
+	txs, err := client.FetchNormalTransactions(context.Background(), "0xtest", 1, 1)
+	if err != nil || len(txs) != 1 {
+		t.Errorf("Unlimited client: got %d transactions, error %v; want 1", len(txs), err)
+	}
+}
+
 func TestEtherscanClientRateLimiting(t *testing.T) {
 	callCount := 0
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -204,6 +338,54 @@ func TestEtherscanClientRateLimiting(t *testing.T) {
 	}
 }
 
+// fakeClock is a Clock whose After advances time immediately, recording each wait
+type fakeClock struct {
+	mu    sync.Mutex
+	now   time.Time
+	waits []time.Duration
+}
+
+func (f *fakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *fakeClock) After(d time.Duration) <-chan time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+	f.waits = append(f.waits, d)
+	ch := make(chan time.Time, 1)
+	ch <- f.now
+	return ch
+}
+
+func TestEtherscanClientRateLimitingWithFakeClock(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(testdata.EmptyResultResponse))
+	}))
+	defer server.Close()
+
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	client := NewEtherscanClient(ClientConfig{
+		APIKey:     "test-key",
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		RateLimit:  time.Hour, // Would time the test out if actually slept
+		Clock:      clock,
+	})
+
+	client.FetchNormalTransactions(context.Background(), "0xtest", 1, 1)
+	client.FetchNormalTransactions(context.Background(), "0xtest", 1, 1)
+
+	want := []time.Duration{time.Hour, time.Hour}
+	if fmt.Sprint(clock.waits) != fmt.Sprint(want) {
+		t.Errorf("Waits mismatch: got %v, want %v", clock.waits, want)
+	}
+}
+
 func TestEtherscanClientEmptyResults(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -228,6 +410,28 @@ func TestEtherscanClientEmptyResults(t *testing.T) {
 	}
 }
 
+func TestEtherscanClientNoTransactionsFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(testdata.NoTransactionsResponse))
+	}))
+	defer server.Close()
+
+	client := NewEtherscanClient(ClientConfig{
+		APIKey:     "test-key",
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+	})
+
+	txs, err := client.FetchTokenTransfers(context.Background(), "0xa39b189482f984388a34460636fea9eb181ad1a6", 1, 1)
+	if err != nil {
+		t.Fatalf("Expected no error for empty wallet, got %v", err)
+	}
+	if len(txs) != 0 {
+		t.Errorf("Expected 0 transactions, got %d", len(txs))
+	}
+}
+
 func TestNewEtherscanClient(t *testing.T) {
 	tests := []struct {
 		name string
@@ -251,7 +455,7 @@ func TestNewEtherscanClient(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			client := NewEtherscanClient(tt.cfg)
-			if client.apiKey != tt.cfg.APIKey {
+			if len(client.keys) != 1 || client.keys[0].key != tt.cfg.APIKey {
 				t.Errorf("API key mismatch")
 			}
 			if tt.cfg.BaseURL != "" && client.baseURL != tt.cfg.BaseURL {
@@ -260,3 +464,569 @@ func TestNewEtherscanClient(t *testing.T) {
 		})
 	}
 }
+
+func TestEtherscanClientRotatesAPIKeys(t *testing.T) {
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.URL.Query().Get("apikey"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(testdata.NoTransactionsResponse))
+	}))
+	defer server.Close()
+
+	client := NewEtherscanClient(ClientConfig{
+		APIKeys:    []string{"key-a", "key-b"},
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		RateLimit:  time.Millisecond,
+	})
+
+	for i := 0; i < 4; i++ {
+		if _, err := client.FetchNormalTransactions(context.Background(), "0xtest", 1, 1); err != nil {
+			t.Fatalf("FetchNormalTransactions() error = %v", err)
+		}
+	}
+
+	want := []string{"key-a", "key-b", "key-a", "key-b"}
+	if strings.Join(keys, ",") != strings.Join(want, ",") {
+		t.Errorf("Key order mismatch: got %v, want %v", keys, want)
+	}
+}
+
+func TestEtherscanClientKeysHaveSeparateBudgets(t *testing.T) {
+	const rateLimit = 200 * time.Millisecond
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(testdata.NoTransactionsResponse))
+	}))
+	defer server.Close()
+
+	client := NewEtherscanClient(ClientConfig{
+		APIKeys:    []string{"key-a", "key-b"},
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		RateLimit:  rateLimit,
+	})
+
+	// One request per key fits in a single interval; a shared budget would need two
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		if _, err := client.FetchNormalTransactions(context.Background(), "0xtest", 1, 1); err != nil {
+			t.Fatalf("FetchNormalTransactions() error = %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed >= 2*rateLimit-rateLimit/4 {
+		t.Errorf("Two keys took %v for two requests, expected about one %v interval", elapsed, rateLimit)
+	}
+}
+
+func TestEtherscanClientChainNotSupported(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(testdata.ChainNotSupportedResponse))
+	}))
+	defer server.Close()
+
+	client := NewEtherscanClient(ClientConfig{
+		APIKey:     "free-key",
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		Chain:      "base",
+		RateLimit:  time.Millisecond,
+	})
+
+	_, err := client.FetchNormalTransactions(context.Background(), "0xtest", 1, 1)
+	var chainErr *ErrChainNotSupported
+	if !errors.As(err, &chainErr) {
+		t.Fatalf("Expected *ErrChainNotSupported, got %v", err)
+	}
+	if chainErr.ChainID != 8453 {
+		t.Errorf("ChainID mismatch: got %d, want 8453", chainErr.ChainID)
+	}
+
+	// Other API errors stay generic
+	_, _, err = decodeResults[EtherscanNormalTx]([]byte(testdata.ErrorResponse), 8453, 0)
+	if err == nil || errors.As(err, &chainErr) {
+		t.Errorf("Expected a generic API error, got %v", err)
+	}
+}
+
+func TestEtherscanClientHonorsRetryAfter(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(testdata.NormalTxResponse))
+	}))
+	defer server.Close()
+
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	client := NewEtherscanClient(ClientConfig{
+		APIKey:     "test-key",
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		Clock:      clock,
+	})
+
+	txs, err := client.FetchNormalTransactions(context.Background(), "0xa39b189482f984388a34460636fea9eb181ad1a6", 1, 1)
+	if err != nil {
+		t.Fatalf("FetchNormalTransactions() error = %v", err)
+	}
+	if len(txs) != 2 {
+		t.Errorf("Expected 2 transactions, got %d", len(txs))
+	}
+	if calls != 2 {
+		t.Fatalf("Expected 2 requests, got %d", calls)
+	}
+	// The first request waits out the rate limit, then the retry waits the full Retry-After
+	want := []time.Duration{RateLimitDelay, time.Second}
+	if fmt.Sprint(clock.waits) != fmt.Sprint(want) {
+		t.Errorf("Waits mismatch: got %v, want %v", clock.waits, want)
+	}
+}
+
+func TestEtherscanClientRateLimitedRetriesExhausted(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewEtherscanClient(ClientConfig{
+		APIKey:     "test-key",
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		MaxRetries: 1,
+	})
+
+	_, err := client.FetchNormalTransactions(context.Background(), "0xtest", 1, 1)
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Expected ErrRateLimited, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 requests, got %d", calls)
+	}
+}
+
+// truncatingServer serves NormalTxResponse, cutting the first failures responses off
+// halfway: when dropConnection is set it closes the connection short of the promised
+// Content-Length, otherwise it sends a complete HTTP response holding half the JSON
+func truncatingServer(t *testing.T, failures int, dropConnection bool) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	calls := &atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := testdata.NormalTxResponse
+		if int(calls.Add(1)) > failures {
+			w.Write([]byte(body))
+			return
+		}
+		if !dropConnection {
+			w.Write([]byte(body[:len(body)/2]))
+			return
+		}
+
+		conn, buf, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Errorf("Hijack() error = %v", err)
+			return
+		}
+		defer conn.Close()
+		fmt.Fprintf(buf, "HTTP/1.1 200 OK\r\nContent-Type: application/json\r\nContent-Length: %d\r\n\r\n%s", len(body), body[:len(body)/2])
+		buf.Flush()
+	}))
+	t.Cleanup(server.Close)
+	return server, calls
+}
+
+func TestEtherscanClientRetriesTruncatedResponses(t *testing.T) {
+	for _, dropConnection := range []bool{true, false} {
+		t.Run(fmt.Sprintf("drop_connection_%v", dropConnection), func(t *testing.T) {
+			// Without retries the truncation surfaces as a typed error, not an API error
+			server, _ := truncatingServer(t, 1, dropConnection)
+			client := NewEtherscanClient(ClientConfig{
+				APIKey:     "test-key",
+				BaseURL:    server.URL,
+				HTTPClient: server.Client(),
+				MaxRetries: -1,
+				Clock:      &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+			})
+			_, err := client.FetchNormalTransactions(context.Background(), "0xtest", 1, 1)
+			var truncated *ErrTruncatedResponse
+			if !errors.As(err, &truncated) {
+				t.Fatalf("Expected *ErrTruncatedResponse, got %v", err)
+			}
+
+			// With retries the next, complete response is decoded
+			server, calls := truncatingServer(t, 2, dropConnection)
+			client = NewEtherscanClient(ClientConfig{
+				APIKey:     "test-key",
+				BaseURL:    server.URL,
+				HTTPClient: server.Client(),
+				Clock:      &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+			})
+			txs, err := client.FetchNormalTransactions(context.Background(), "0xtest", 1, 1)
+			if err != nil {
+				t.Fatalf("FetchNormalTransactions() error = %v", err)
+			}
+			if len(txs) != 2 {
+				t.Errorf("Expected 2 transactions, got %d", len(txs))
+			}
+			if calls.Load() != 3 {
+				t.Errorf("Expected 3 requests, got %d", calls.Load())
+			}
+		})
+	}
+}
+
+func TestCheckComplete(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		truncated bool
+	}{
+		{name: "complete", body: `{"status":"1","result":[]}`},
+		{name: "cut_off", body: `{"status":"1","result":[{"hash":"0x`, truncated: true},
+		{name: "empty", body: ``, truncated: true},
+		// Complete but invalid bodies are left to decoding, which reports them as is
+		{name: "html", body: `<html>Bad Gateway</html>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var truncated *ErrTruncatedResponse
+			if got := errors.As(checkComplete([]byte(tt.body)), &truncated); got != tt.truncated {
+				t.Errorf("Truncated mismatch: got %v, want %v", got, tt.truncated)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{name: "seconds", value: "5", want: 5 * time.Second, wantOK: true},
+		{name: "http_date", value: "Mon, 01 Jan 2024 00:00:10 GMT", want: 10 * time.Second, wantOK: true},
+		{name: "http_date_in_past", value: "Sun, 31 Dec 2023 23:59:00 GMT", want: 0, wantOK: true},
+		{name: "empty", value: "", wantOK: false},
+		{name: "negative", value: "-3", wantOK: false},
+		{name: "garbage", value: "soon", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.value, now)
+			if ok != tt.wantOK {
+				t.Fatalf("parseRetryAfter(%q) ok = %v, want %v", tt.value, ok, tt.wantOK)
+			}
+			if ok && got != tt.want {
+				t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryWaitIsCapped(t *testing.T) {
+	client := NewEtherscanClient(ClientConfig{MaxRetryWait: 2 * time.Second})
+
+	if got := client.retryWait("3600"); got != 2*time.Second {
+		t.Errorf("Expected wait capped at 2s, got %v", got)
+	}
+	if got := client.retryWait(""); got != RateLimitDelay {
+		t.Errorf("Expected fallback wait %v, got %v", RateLimitDelay, got)
+	}
+}
+
+func TestEtherscanClientPagination(t *testing.T) {
+	type pageRequest struct {
+		page   string
+		offset string
+	}
+	var requests []pageRequest
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		requests = append(requests, pageRequest{page: query.Get("page"), offset: query.Get("offset")})
+
+		// Serve full pages so every requested page is fetched
+		items := make([]string, 100)
+		for i := range items {
+			items[i] = fmt.Sprintf(`{"hash":"0x%s-%d"}`, query.Get("page"), i)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"status":"1","message":"OK","result":[%s]}`, strings.Join(items, ","))
+	}))
+	defer server.Close()
+
+	client := NewEtherscanClient(ClientConfig{
+		APIKey:     "test-key",
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		PageSize:   100,
+	})
+
+	txs, err := client.FetchNormalTransactions(context.Background(), "0xtest", 1, 3)
+	if err != nil {
+		t.Fatalf("FetchNormalTransactions() error = %v", err)
+	}
+
+	if len(txs) != 300 {
+		t.Errorf("Expected 300 transactions, got %d", len(txs))
+	}
+
+	want := []pageRequest{{"1", "100"}, {"2", "100"}, {"3", "100"}}
+	if len(requests) != len(want) {
+		t.Fatalf("Expected %d requests, got %d: %v", len(want), len(requests), requests)
+	}
+	for i := range want {
+		if requests[i] != want[i] {
+			t.Errorf("Request %d params mismatch: got page=%s offset=%s, want page=%s offset=%s",
+				i, requests[i].page, requests[i].offset, want[i].page, want[i].offset)
+		}
+	}
+}
+
+func TestEtherscanClientPaginationStopsOnShortPage(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(testdata.NormalTxResponse)) // 2 records, short of the page size
+	}))
+	defer server.Close()
+
+	client := NewEtherscanClient(ClientConfig{
+		APIKey:     "test-key",
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		PageSize:   100,
+	})
+
+	txs, err := client.FetchNormalTransactions(context.Background(), "0xtest", 1, 3)
+	if err != nil {
+		t.Fatalf("FetchNormalTransactions() error = %v", err)
+	}
+	if len(txs) != 2 {
+		t.Errorf("Expected 2 transactions, got %d", len(txs))
+	}
+	if calls != 1 {
+		t.Errorf("Expected paging to stop after 1 request, got %d", calls)
+	}
+}
+
+func TestEtherscanClientSharesConcurrentIdenticalRequests(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(100 * time.Millisecond) // Keep the request in flight while others arrive
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(testdata.NormalTxResponse))
+	}))
+	defer server.Close()
+
+	client := NewEtherscanClient(ClientConfig{
+		APIKey:     "test-key",
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+	})
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			txs, err := client.FetchNormalTransactions(context.Background(), "0xa39b189482f984388a34460636fea9eb181ad1a6", 1, 1)
+			if err == nil && len(txs) != 2 {
+				err = fmt.Errorf("expected 2 transactions, got %d", len(txs))
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("FetchNormalTransactions() error = %v", err)
+		}
+	}
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("Expected 1 HTTP request, got %d", n)
+	}
+}
+
+func TestEtherscanClientWaiterOutlivesCanceledLeader(t *testing.T) {
+	var calls int32
+	started := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			// Hold the first request until its caller gives up
+			close(started)
+			<-r.Context().Done()
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(testdata.NormalTxResponse))
+	}))
+	defer server.Close()
+
+	client := NewEtherscanClient(ClientConfig{
+		APIKey:     "test-key",
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		RateLimit:  time.Millisecond,
+	})
+	address := "0xa39b189482f984388a34460636fea9eb181ad1a6"
+
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := client.FetchNormalTransactions(leaderCtx, address, 1, 1)
+		leaderErr <- err
+	}()
+	<-started
+
+	type result struct {
+		txs []EtherscanNormalTx
+		err error
+	}
+	waiter := make(chan result, 1)
+	go func() {
+		txs, err := client.FetchNormalTransactions(context.Background(), address, 1, 1)
+		waiter <- result{txs, err}
+	}()
+	time.Sleep(50 * time.Millisecond) // Let the waiter join the in-flight request
+	cancel()
+
+	if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the leader to fail with context.Canceled, got %v", err)
+	}
+	got := <-waiter
+	if got.err != nil || len(got.txs) != 2 {
+		t.Fatalf("Waiter: got %d transactions, error %v; want 2", len(got.txs), got.err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Errorf("Expected the waiter to repeat the request once, got %d requests", n)
+	}
+}
+
+func TestFetchLabelsConfiguredChain(t *testing.T) {
+	var chainIDs sync.Map
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		chainIDs.Store(r.URL.Query().Get("chainid"), true)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("action") == "txlist" {
+			w.Write([]byte(testdata.NormalTxResponse))
+			return
+		}
+		w.Write([]byte(testdata.EmptyResultResponse))
+	}))
+	defer server.Close()
+
+	client := NewEtherscanClient(ClientConfig{
+		APIKey:  "test-key",
+		BaseURL: server.URL,
+		Chain:   "Polygon",
+	})
+	fetcher := NewTransactionFetcher(client, NewEtherscanNormalizer())
+
+	txs, err := fetcher.FetchAllTransactions(context.Background(), "0xa39b189482f984388a34460636fea9eb181ad1a6", 1, 1)
+	if err != nil {
+		t.Fatalf("FetchAllTransactions() error = %v", err)
+	}
+	if len(txs) == 0 {
+		t.Fatal("Expected transactions")
+	}
+	for _, tx := range txs {
+		if tx.Chain != "polygon" {
+			t.Errorf("Chain mismatch: got %s, want polygon", tx.Chain)
+		}
+	}
+
+	chainIDs.Range(func(key, _ any) bool {
+		if key != "137" {
+			t.Errorf("chainid mismatch: got %v, want 137", key)
+		}
+		return true
+	})
+}
+
+func TestDefaultChainIsEthereum(t *testing.T) {
+	client := NewEtherscanClient(ClientConfig{APIKey: "test-key"})
+	if client.Chain() != DefaultChain {
+		t.Errorf("Chain mismatch: got %s, want %s", client.Chain(), DefaultChain)
+	}
+	if got := client.buildParams("txlist", "account", "0xabc").Get("chainid"); got != "1" {
+		t.Errorf("chainid mismatch: got %s, want 1", got)
+	}
+}
+
+// newWindowServer serves total normal transactions page by page and, like
+// Etherscan, rejects any page whose page times offset passes ResultWindow
+func newWindowServer(t *testing.T, total int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		var page, offset int
+		fmt.Sscan(r.URL.Query().Get("page"), &page)
+		fmt.Sscan(r.URL.Query().Get("offset"), &offset)
+		w.Header().Set("Content-Type", "application/json")
+		if page*offset > ResultWindow {
+			w.Write([]byte(`{"status":"0","message":"NOTOK","result":"Result window is too large, PageNo x Offset size must be less than or equal to 10000"}`))
+			return
+		}
+
+		var records []string
+		for i := (page - 1) * offset; i < min(page*offset, total); i++ {
+			records = append(records, fmt.Sprintf(`{"hash":"0x%x","blockNumber":"%d","timeStamp":"1"}`, i, i))
+		}
+		fmt.Fprintf(w, `{"status":"1","message":"OK","result":[%s]}`, strings.Join(records, ","))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestEtherscanClientStopsAtResultWindow(t *testing.T) {
+	server, calls := newWindowServer(t, 12000)
+	client := NewEtherscanClient(ClientConfig{
+		APIKey:     "test-key",
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		PageSize:   5000,
+		RateLimit:  time.Nanosecond,
+	})
+
+	if got := client.MaxPage(); got != 2 {
+		t.Errorf("MaxPage mismatch: got %d, want 2", got)
+	}
+
+	txs, err := client.FetchNormalTransactions(context.Background(), "0xtest", 1, 2)
+	if err != nil {
+		t.Fatalf("FetchNormalTransactions() error = %v", err)
+	}
+	if len(txs) != 10000 {
+		t.Errorf("Expected the full 10000-record window, got %d", len(txs))
+	}
+
+	before := calls.Load()
+	if _, err := client.FetchNormalTransactions(context.Background(), "0xtest", 1, 3); !errors.Is(err, ErrResultWindow) {
+		t.Errorf("Expected ErrResultWindow for page 3, got %v", err)
+	}
+	if got := calls.Load() - before; got != 2 {
+		t.Errorf("Expected 2 requests before stopping at the window, got %d", got)
+	}
+}
@@ -5,176 +5,471 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strconv"
 )
 
 // TransactionFetcher orchestrates fetching and normalizing transactions from a provider
 type TransactionFetcher struct {
 	provider   Provider
 	normalizer Normalizer
+	windowSize int                      // Max raw records a single fetch can return; a full window may be truncated
+	types      map[TransactionType]bool // Types to fetch; nil fetches all
+	maxTxs     int                      // Stop after this many normalized rows; 0 is unlimited
+	onProgress ProgressFunc             // Called as each type completes; may be nil
 }
 
 // FetchResult holds the result of fetching a specific transaction type
 type FetchResult struct {
 	Transactions []*models.Transaction
 	Err          error
+
+	// Truncated is set when at least one type returned a full result window,
+	// meaning more records likely exist beyond the requested pages
+	Truncated      bool
+	TruncatedTypes []TransactionType
+
+	// Capped is set when fetching stopped at the fetcher's transaction limit
+	Capped bool
+
+	// NormalizationStats counts normalized rows; Errors holds a *NormalizationError
+	// for each raw transaction that was skipped because it failed to normalize
+	NormalizationStats NormalizationStats
+
+	// TypeStats breaks NormalizationStats down by transaction type
+	TypeStats map[TransactionType]NormalizationStats
+}
+
+// AddTypeStats records one type's normalization stats and adds them to the totals
+func (r *FetchResult) AddTypeStats(txType TransactionType, stats NormalizationStats) {
+	if r.TypeStats == nil {
+		r.TypeStats = make(map[TransactionType]NormalizationStats)
+	}
+	typeStats := r.TypeStats[txType]
+	typeStats.merge(stats)
+	r.TypeStats[txType] = typeStats
+	r.NormalizationStats.merge(stats)
 }
 
+// fetchTypeFunc fetches and normalizes one transaction type over a page range,
+// returning the normalized rows and the raw record count
+type fetchTypeFunc func(ctx context.Context, address string, startPage, endPage int, stats *NormalizationStats) ([]*models.Transaction, int, error)
+
 // NewTransactionFetcher creates a new transaction fetcher
 func NewTransactionFetcher(provider Provider, normalizer Normalizer) *TransactionFetcher {
 	return &TransactionFetcher{
 		provider:   provider,
 		normalizer: normalizer,
+		windowSize: DefaultPageSize,
 	}
 }
 
-// FetchAllTransactions fetches all transaction types for an address and returns normalized transactions
-func (tf *TransactionFetcher) FetchAllTransactions(ctx context.Context, address string, startPage, endPage int) ([]*models.Transaction, error) {
-	// Fetch all transaction types sequentially to respect rate limits
-	var allTransactions []*models.Transaction
+// SetWindowSize sets the number of raw records a single fetch can return.
+// A type returning exactly this many records is reported as truncated.
+func (tf *TransactionFetcher) SetWindowSize(size int) {
+	if size > 0 {
+		tf.windowSize = size
+	}
+}
 
-	// Fetch normal transactions
-	normalTxs, err := tf.fetchNormalTransactions(ctx, address, startPage, endPage)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch normal transactions: %w", err)
+// SetTypes restricts fetching to the given transaction types; the provider is not
+// called for any other type. An empty list fetches every type.
+func (tf *TransactionFetcher) SetTypes(types []TransactionType) {
+	if len(types) == 0 {
+		tf.types = nil
+		return
 	}
-	if normalTxs != nil {
-		allTransactions = append(allTransactions, normalTxs...)
+	tf.types = make(map[TransactionType]bool, len(types))
+	for _, txType := range types {
+		tf.types[txType] = true
 	}
+}
 
-	// Fetch internal transactions
-	internalTxs, err := tf.fetchInternalTransactions(ctx, address, startPage, endPage)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch internal transactions: %w", err)
+// SetMaxTransactions caps how many normalized transactions FetchAll collects.
+// Once the cap is reached no further pages or types are requested and the result
+// is marked Capped. Zero or a negative value removes the cap.
+func (tf *TransactionFetcher) SetMaxTransactions(n int) {
+	if n < 0 {
+		n = 0
 	}
-	if internalTxs != nil {
-		allTransactions = append(allTransactions, internalTxs...)
+	tf.maxTxs = n
+}
+
+// SetProgressCallback registers fn to receive progress as FetchAll finishes each
+// type, successfully or not. Types are fetched one at a time, so calls never overlap.
+// FetchMany reports every address through fn, serializing calls across its workers;
+// each call's totals then cover only the address that finished a type.
+func (tf *TransactionFetcher) SetProgressCallback(fn ProgressFunc) {
+	tf.onProgress = fn
+}
+
+// wants reports whether txType is among the types to fetch. Beacon withdrawals
+// are skipped on chains that have none.
+func (tf *TransactionFetcher) wants(txType TransactionType) bool {
+	if txType == TxTypeWithdrawal && !hasBeaconWithdrawals(tf.provider) {
+		return false
 	}
+	return tf.types == nil || tf.types[txType]
+}
 
-	// Fetch ERC-20 token transfers
-	tokenTxs, err := tf.fetchTokenTransfers(ctx, address, startPage, endPage)
+// FetchAllTransactions fetches all transaction types for an address and returns normalized transactions
+func (tf *TransactionFetcher) FetchAllTransactions(ctx context.Context, address string, startPage, endPage int) ([]*models.Transaction, error) {
+	result, err := tf.FetchAll(ctx, address, startPage, endPage)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch token transfers: %w", err)
-	}
-	if tokenTxs != nil {
-		allTransactions = append(allTransactions, tokenTxs...)
+		return nil, err
 	}
+	return result.Transactions, nil
+}
 
-	// Fetch ERC-721 NFT transfers
-	nftTxs, err := tf.fetchNFTTransfers(ctx, address, startPage, endPage)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch NFT transfers: %w", err)
+// FetchAll fetches all transaction types for an address and reports which types may be truncated.
+// If ctx is canceled part-way, the types fetched so far are returned along with the error.
+func (tf *TransactionFetcher) FetchAll(ctx context.Context, address string, startPage, endPage int) (*FetchResult, error) {
+	return tf.fetchAll(ctx, address, startPage, endPage, tf.onProgress)
+}
+
+// fetchAll is FetchAll reporting progress to report, which may be nil
+func (tf *TransactionFetcher) fetchAll(ctx context.Context, address string, startPage, endPage int, report ProgressFunc) (*FetchResult, error) {
+	// Fetch all transaction types sequentially to respect rate limits
+	result := &FetchResult{}
+
+	steps := []struct {
+		txType TransactionType
+		what   string
+		fetch  fetchTypeFunc
+	}{
+		{TxTypeNormal, "normal transactions", tf.fetchNormalTransactions},
+		{TxTypeInternal, "internal transactions", tf.fetchInternalTransactions},
+		{TxTypeToken, "token transfers", tf.fetchTokenTransfers},
+		{TxTypeNFT, "NFT transfers", tf.fetchNFTTransfers},
+		{TxTypeERC1155, "ERC-1155 transfers", tf.fetchERC1155Transfers},
+		{TxTypeWithdrawal, "beacon withdrawals", tf.fetchBeaconWithdrawals},
 	}
-	if nftTxs != nil {
-		allTransactions = append(allTransactions, nftTxs...)
+
+	total := 0
+	for _, step := range steps {
+		if tf.wants(step.txType) {
+			total++
+		}
 	}
+	progress := newProgressTracker(total, report)
 
-	// Fetch ERC-1155 token transfers
-	erc1155Txs, err := tf.fetchERC1155Transfers(ctx, address, startPage, endPage)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch ERC-1155 transfers: %w", err)
+	for _, step := range steps {
+		if !tf.wants(step.txType) {
+			continue
+		}
+		if tf.maxTxs > 0 && len(result.Transactions) >= tf.maxTxs {
+			result.Capped = true
+			break
+		}
+
+		var txs []*models.Transaction
+		var rawCount int
+		var stats NormalizationStats
+		var err error
+		if tf.maxTxs > 0 {
+			txs, rawCount, err = fetchCapped(ctx, step.fetch, address, startPage, endPage, tf.maxTxs-len(result.Transactions), &stats)
+		} else {
+			txs, rawCount, err = step.fetch(ctx, address, startPage, endPage, &stats)
+		}
+		result.AddTypeStats(step.txType, stats)
+		progress.complete(&FetchTypeResult{TxType: step.txType, Txs: txs, Err: err})
+		if err != nil {
+			return partialResult(ctx, result), fmt.Errorf("failed to fetch %s: %w", step.what, err)
+		}
+		tf.collect(result, step.txType, txs, rawCount)
 	}
-	if erc1155Txs != nil {
-		allTransactions = append(allTransactions, erc1155Txs...)
+
+	if tf.maxTxs > 0 && len(result.Transactions) > tf.maxTxs {
+		result.Transactions = result.Transactions[:tf.maxTxs]
+		result.Capped = true
 	}
 
 	// Sort by block number and timestamp
-	sort.Sort(models.TransactionList(allTransactions))
+	sort.Sort(models.TransactionList(result.Transactions))
+
+	return result, nil
+}
+
+// fetchCapped requests one page at a time so it can stop as soon as limit rows
+// have been collected, rather than loading the whole page range first. Paging
+// stops early at an empty page or one shorter than the first.
+func fetchCapped(ctx context.Context, fetch fetchTypeFunc, address string, startPage, endPage, limit int, stats *NormalizationStats) ([]*models.Transaction, int, error) {
+	var all []*models.Transaction
+	total, firstPageSize := 0, 0
+
+	for page := startPage; page <= endPage && len(all) < limit; page++ {
+		txs, rawCount, err := fetch(ctx, address, page, page, stats)
+		if err != nil {
+			return nil, 0, err
+		}
+		all = append(all, txs...)
+		total += rawCount
+
+		if page == startPage {
+			firstPageSize = rawCount
+		}
+		if rawCount == 0 || rawCount < firstPageSize {
+			break
+		}
+	}
+
+	return all, total, nil
+}
 
-	return allTransactions, nil
+// partialResult returns the sorted transactions collected so far when ctx was canceled,
+// so callers can still export them; any other failure discards them
+func partialResult(ctx context.Context, result *FetchResult) *FetchResult {
+	if ctx.Err() == nil {
+		return nil
+	}
+	sort.Sort(models.TransactionList(result.Transactions))
+	return result
+}
+
+// collect appends a type's transactions to the result and flags a full result window
+func (tf *TransactionFetcher) collect(result *FetchResult, txType TransactionType, txs []*models.Transaction, rawCount int) {
+	if txs != nil {
+		labelChain(tf.provider, txs)
+		result.Transactions = append(result.Transactions, txs...)
+	}
+	if rawCount >= tf.windowSize {
+		result.Truncated = true
+		result.TruncatedTypes = append(result.TruncatedTypes, txType)
+	}
 }
 
 // fetchNormalTransactions fetches and normalizes normal ETH transfers
-func (tf *TransactionFetcher) fetchNormalTransactions(ctx context.Context, address string, startPage, endPage int) ([]*models.Transaction, error) {
+func (tf *TransactionFetcher) fetchNormalTransactions(ctx context.Context, address string, startPage, endPage int, stats *NormalizationStats) ([]*models.Transaction, int, error) {
 	rawTxs, err := tf.provider.FetchNormalTransactions(ctx, address, startPage, endPage)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	var normalized []*models.Transaction
 	for _, tx := range rawTxs {
+		stats.TotalProcessed++
 		norm, err := tf.normalizer.NormalizeNormalTx(tx)
 		if err != nil {
-			// Log and skip invalid transactions
+			stats.record(TxTypeNormal, tx.Hash, tx, err)
 			continue
 		}
+		stats.SuccessCount++
 		normalized = append(normalized, norm)
 	}
 
-	return normalized, nil
+	return normalized, len(rawTxs), nil
 }
 
 // fetchInternalTransactions fetches and normalizes internal transfers
-func (tf *TransactionFetcher) fetchInternalTransactions(ctx context.Context, address string, startPage, endPage int) ([]*models.Transaction, error) {
+func (tf *TransactionFetcher) fetchInternalTransactions(ctx context.Context, address string, startPage, endPage int, stats *NormalizationStats) ([]*models.Transaction, int, error) {
 	rawTxs, err := tf.provider.FetchInternalTransactions(ctx, address, startPage, endPage)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	var normalized []*models.Transaction
 	for _, tx := range rawTxs {
+		stats.TotalProcessed++
 		norm, err := tf.normalizer.NormalizeInternalTx(tx)
 		if err != nil {
+			stats.record(TxTypeInternal, tx.Hash, tx, err)
 			continue
 		}
+		stats.SuccessCount++
 		normalized = append(normalized, norm)
 	}
 
-	return normalized, nil
+	return normalized, len(rawTxs), nil
 }
 
 // fetchTokenTransfers fetches and normalizes ERC-20 token transfers
-func (tf *TransactionFetcher) fetchTokenTransfers(ctx context.Context, address string, startPage, endPage int) ([]*models.Transaction, error) {
+func (tf *TransactionFetcher) fetchTokenTransfers(ctx context.Context, address string, startPage, endPage int, stats *NormalizationStats) ([]*models.Transaction, int, error) {
 	rawTxs, err := tf.provider.FetchTokenTransfers(ctx, address, startPage, endPage)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	var normalized []*models.Transaction
 	for _, tx := range rawTxs {
+		stats.TotalProcessed++
 		norm, err := tf.normalizer.NormalizeERC20Tx(tx)
 		if err != nil {
+			stats.record(TxTypeToken, tx.Hash, tx, err)
 			continue
 		}
+		stats.SuccessCount++
 		normalized = append(normalized, norm)
 	}
 
-	return normalized, nil
+	return normalized, len(rawTxs), nil
 }
 
 // fetchNFTTransfers fetches and normalizes ERC-721 NFT transfers
-func (tf *TransactionFetcher) fetchNFTTransfers(ctx context.Context, address string, startPage, endPage int) ([]*models.Transaction, error) {
+func (tf *TransactionFetcher) fetchNFTTransfers(ctx context.Context, address string, startPage, endPage int, stats *NormalizationStats) ([]*models.Transaction, int, error) {
 	rawTxs, err := tf.provider.FetchNFTTransfers(ctx, address, startPage, endPage)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	var normalized []*models.Transaction
 	for _, tx := range rawTxs {
+		stats.TotalProcessed++
 		norm, err := tf.normalizer.NormalizeERC721Tx(tx)
 		if err != nil {
+			stats.record(TxTypeNFT, tx.Hash, tx, err)
 			continue
 		}
+		stats.SuccessCount++
 		normalized = append(normalized, norm)
 	}
 
-	return normalized, nil
+	return normalized, len(rawTxs), nil
 }
 
 // fetchERC1155Transfers fetches and normalizes ERC-1155 multi-token transfers
-func (tf *TransactionFetcher) fetchERC1155Transfers(ctx context.Context, address string, startPage, endPage int) ([]*models.Transaction, error) {
+func (tf *TransactionFetcher) fetchERC1155Transfers(ctx context.Context, address string, startPage, endPage int, stats *NormalizationStats) ([]*models.Transaction, int, error) {
 	rawTxs, err := tf.provider.FetchERC1155Transfers(ctx, address, startPage, endPage)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	var normalized []*models.Transaction
 	for _, tx := range rawTxs {
+		stats.TotalProcessed++
 		norm, err := tf.normalizer.NormalizeERC1155Tx(tx)
 		if err != nil {
+			stats.record(TxTypeERC1155, tx.Hash, tx, err)
 			continue
 		}
+		stats.SuccessCount++
 		normalized = append(normalized, norm)
 	}
 
-	return normalized, nil
+	return normalized, len(rawTxs), nil
+}
+
+// fetchBeaconWithdrawals fetches and normalizes beacon chain withdrawals
+func (tf *TransactionFetcher) fetchBeaconWithdrawals(ctx context.Context, address string, startPage, endPage int, stats *NormalizationStats) ([]*models.Transaction, int, error) {
+	rawTxs, err := tf.provider.FetchBeaconWithdrawals(ctx, address, startPage, endPage)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var normalized []*models.Transaction
+	for _, tx := range rawTxs {
+		stats.TotalProcessed++
+		norm, err := tf.normalizer.NormalizeWithdrawalTx(tx)
+		if err != nil {
+			stats.record(TxTypeWithdrawal, "", tx, err)
+			continue
+		}
+		stats.SuccessCount++
+		normalized = append(normalized, norm)
+	}
+
+	return normalized, len(rawTxs), nil
+}
+
+// maxCountPages bounds how many pages CountTransactions requests per transaction type
+const maxCountPages = 1000
+
+// TransactionCount is the number of raw records of one transaction type. AtLeast
+// is set when paging stopped at the provider's page limit (or maxCountPages) with
+// pages still full, so Count is a lower bound.
+type TransactionCount struct {
+	Count   int
+	AtLeast bool
+}
+
+// String returns the count, prefixed with "≥" when it is a lower bound
+func (c TransactionCount) String() string {
+	if c.AtLeast {
+		return fmt.Sprintf("≥%d", c.Count)
+	}
+	return strconv.Itoa(c.Count)
+}
+
+// CountTransactions pages through each transaction type and returns the number of raw
+// records the provider reports for the address, without normalizing anything.
+// Paging stops at the first empty page, the first page shorter than page 1, or the
+// provider's last page (see PageLimiter).
+func (tf *TransactionFetcher) CountTransactions(ctx context.Context, address string) (map[TransactionType]TransactionCount, error) {
+	counters := []struct {
+		txType    TransactionType
+		fetchPage func(page int) (int, error)
+	}{
+		{TxTypeNormal, func(page int) (int, error) {
+			txs, err := tf.provider.FetchNormalTransactions(ctx, address, page, page)
+			return len(txs), err
+		}},
+		{TxTypeInternal, func(page int) (int, error) {
+			txs, err := tf.provider.FetchInternalTransactions(ctx, address, page, page)
+			return len(txs), err
+		}},
+		{TxTypeToken, func(page int) (int, error) {
+			txs, err := tf.provider.FetchTokenTransfers(ctx, address, page, page)
+			return len(txs), err
+		}},
+		{TxTypeNFT, func(page int) (int, error) {
+			txs, err := tf.provider.FetchNFTTransfers(ctx, address, page, page)
+			return len(txs), err
+		}},
+		{TxTypeERC1155, func(page int) (int, error) {
+			txs, err := tf.provider.FetchERC1155Transfers(ctx, address, page, page)
+			return len(txs), err
+		}},
+		{TxTypeWithdrawal, func(page int) (int, error) {
+			txs, err := tf.provider.FetchBeaconWithdrawals(ctx, address, page, page)
+			return len(txs), err
+		}},
+	}
+
+	lastPage, pageSize := pageLimits(tf.provider, maxCountPages)
+	counts := make(map[TransactionType]TransactionCount, len(counters))
+	for _, c := range counters {
+		if !tf.wants(c.txType) {
+			continue
+		}
+		count, err := countPages(ctx, lastPage, pageSize, c.fetchPage)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count %s transactions: %w", c.txType.String(), err)
+		}
+		counts[c.txType] = count
+	}
+
+	return counts, nil
+}
+
+// countPages sums page sizes until the provider runs out of results or lastPage
+// has been counted. pageSize is the size of a full page, or 0 if unknown.
+func countPages(ctx context.Context, lastPage, pageSize int, fetchPage func(page int) (int, error)) (TransactionCount, error) {
+	total := 0
+	firstPageSize := 0
+
+	for page := 1; page <= lastPage; page++ {
+		if err := ctx.Err(); err != nil {
+			return TransactionCount{}, err
+		}
+
+		n, err := fetchPage(page)
+		if err != nil {
+			return TransactionCount{}, err
+		}
+		if n == 0 {
+			return TransactionCount{Count: total}, nil
+		}
+		total += n
+
+		// A page shorter than a full page, or than the first one, is the last page
+		if n < pageSize {
+			return TransactionCount{Count: total}, nil
+		}
+		if page == 1 {
+			firstPageSize = n
+		} else if n < firstPageSize {
+			return TransactionCount{Count: total}, nil
+		}
+	}
+
+	// Every page up to the limit was full, so more records may follow
+	return TransactionCount{Count: total, AtLeast: true}, nil
 }
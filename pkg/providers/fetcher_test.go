@@ -3,54 +3,11 @@ package providers
 import (
 	"conintracker-hiring/pkg/models"
 	"context"
+	"errors"
 	"testing"
+	"time"
 )
 
-// MockProvider implements Provider interface for testing
-type MockProvider struct {
-	normalTxs     []EtherscanNormalTx
-	internalTxs   []EtherscanInternalTx
-	tokenTxs      []EtherscanTokenTx
-	nftTxs        []EtherscanTokenTx
-	erc1155Txs    []EtherscanTokenTx
-	shouldError   bool
-}
-
-func (mp *MockProvider) FetchNormalTransactions(ctx context.Context, address string, startPage, endPage int) ([]EtherscanNormalTx, error) {
-	if mp.shouldError {
-		return nil, errMock
-	}
-	return mp.normalTxs, nil
-}
-
-func (mp *MockProvider) FetchInternalTransactions(ctx context.Context, address string, startPage, endPage int) ([]EtherscanInternalTx, error) {
-	if mp.shouldError {
-		return nil, errMock
-	}
-	return mp.internalTxs, nil
-}
-
-func (mp *MockProvider) FetchTokenTransfers(ctx context.Context, address string, startPage, endPage int) ([]EtherscanTokenTx, error) {
-	if mp.shouldError {
-		return nil, errMock
-	}
-	return mp.tokenTxs, nil
-}
-
-func (mp *MockProvider) FetchNFTTransfers(ctx context.Context, address string, startPage, endPage int) ([]EtherscanTokenTx, error) {
-	if mp.shouldError {
-		return nil, errMock
-	}
-	return mp.nftTxs, nil
-}
-
-func (mp *MockProvider) FetchERC1155Transfers(ctx context.Context, address string, startPage, endPage int) ([]EtherscanTokenTx, error) {
-	if mp.shouldError {
-		return nil, errMock
-	}
-	return mp.erc1155Txs, nil
-}
-
 var errMock = testError("mock error")
 
 type testError string
@@ -60,8 +17,8 @@ func (e testError) Error() string {
 }
 
 func TestFetchAllTransactions(t *testing.T) {
-	mockProvider := &MockProvider{
-		normalTxs: []EtherscanNormalTx{
+	mockProvider := &ConfigurableProvider{
+		NormalTxs: []EtherscanNormalTx{
 			{
 				Hash:     "0x1234",
 				From:     "0xfrom",
@@ -73,7 +30,7 @@ func TestFetchAllTransactions(t *testing.T) {
 				TimeStamp: "1000",
 			},
 		},
-		internalTxs: []EtherscanInternalTx{
+		InternalTxs: []EtherscanInternalTx{
 			{
 				Hash:     "0x5678",
 				From:     "0xfrom",
@@ -84,7 +41,7 @@ func TestFetchAllTransactions(t *testing.T) {
 				TimeStamp: "999",
 			},
 		},
-		tokenTxs: []EtherscanTokenTx{
+		TokenTxs: []EtherscanTokenTx{
 			{
 				Hash:            "0x9012",
 				From:            "0xfrom",
@@ -136,8 +93,8 @@ func TestFetchAllTransactions(t *testing.T) {
 }
 
 func TestFetchAllTransactionsWithError(t *testing.T) {
-	mockProvider := &MockProvider{
-		shouldError: true,
+	mockProvider := &ConfigurableProvider{
+		Err: errMock,
 	}
 
 	normalizer := NewEtherscanNormalizer()
@@ -150,12 +107,12 @@ func TestFetchAllTransactionsWithError(t *testing.T) {
 }
 
 func TestFetchAllTransactionsEmpty(t *testing.T) {
-	mockProvider := &MockProvider{
-		normalTxs:   []EtherscanNormalTx{},
-		internalTxs: []EtherscanInternalTx{},
-		tokenTxs:    []EtherscanTokenTx{},
-		nftTxs:      []EtherscanTokenTx{},
-		erc1155Txs:  []EtherscanTokenTx{},
+	mockProvider := &ConfigurableProvider{
+		NormalTxs:   []EtherscanNormalTx{},
+		InternalTxs: []EtherscanInternalTx{},
+		TokenTxs:    []EtherscanTokenTx{},
+		NFTTxs:      []EtherscanTokenTx{},
+		ERC1155Txs:  []EtherscanTokenTx{},
 	}
 
 	normalizer := NewEtherscanNormalizer()
@@ -172,8 +129,8 @@ func TestFetchAllTransactionsEmpty(t *testing.T) {
 }
 
 func TestFetchAllTransactionsMixedTypes(t *testing.T) {
-	mockProvider := &MockProvider{
-		normalTxs: []EtherscanNormalTx{
+	mockProvider := &ConfigurableProvider{
+		NormalTxs: []EtherscanNormalTx{
 			{
 				Hash:        "0x1",
 				From:        "0xfrom",
@@ -185,7 +142,7 @@ func TestFetchAllTransactionsMixedTypes(t *testing.T) {
 				TimeStamp:   "1000",
 			},
 		},
-		nftTxs: []EtherscanTokenTx{
+		NFTTxs: []EtherscanTokenTx{
 			{
 				Hash:            "0x2",
 				From:            "0xfrom",
@@ -199,7 +156,7 @@ func TestFetchAllTransactionsMixedTypes(t *testing.T) {
 				TimeStamp:       "1001",
 			},
 		},
-		erc1155Txs: []EtherscanTokenTx{
+		ERC1155Txs: []EtherscanTokenTx{
 			{
 				Hash:            "0x3",
 				From:            "0xfrom",
@@ -247,3 +204,453 @@ func TestFetchAllTransactionsMixedTypes(t *testing.T) {
 		t.Errorf("ERC-1155 Amount mismatch, expected 50 got %s", txs[2].Amount)
 	}
 }
+
+// pagedConfigurableProvider serves a fixed number of records per page for each transaction type
+type pagedConfigurableProvider struct {
+	pageSizes map[TransactionType][]int
+	calls     map[TransactionType]int
+}
+
+func (pp *pagedConfigurableProvider) pageLen(txType TransactionType, page int) int {
+	if pp.calls == nil {
+		pp.calls = make(map[TransactionType]int)
+	}
+	pp.calls[txType]++
+	sizes := pp.pageSizes[txType]
+	if page < 1 || page > len(sizes) {
+		return 0
+	}
+	return sizes[page-1]
+}
+
+func (pp *pagedConfigurableProvider) FetchNormalTransactions(ctx context.Context, address string, startPage, endPage int) ([]EtherscanNormalTx, error) {
+	return make([]EtherscanNormalTx, pp.pageLen(TxTypeNormal, startPage)), nil
+}
+
+func (pp *pagedConfigurableProvider) FetchInternalTransactions(ctx context.Context, address string, startPage, endPage int) ([]EtherscanInternalTx, error) {
+	return make([]EtherscanInternalTx, pp.pageLen(TxTypeInternal, startPage)), nil
+}
+
+func (pp *pagedConfigurableProvider) FetchTokenTransfers(ctx context.Context, address string, startPage, endPage int) ([]EtherscanTokenTx, error) {
+	return make([]EtherscanTokenTx, pp.pageLen(TxTypeToken, startPage)), nil
+}
+
+func (pp *pagedConfigurableProvider) FetchNFTTransfers(ctx context.Context, address string, startPage, endPage int) ([]EtherscanTokenTx, error) {
+	return make([]EtherscanTokenTx, pp.pageLen(TxTypeNFT, startPage)), nil
+}
+
+func (pp *pagedConfigurableProvider) FetchERC1155Transfers(ctx context.Context, address string, startPage, endPage int) ([]EtherscanTokenTx, error) {
+	return make([]EtherscanTokenTx, pp.pageLen(TxTypeERC1155, startPage)), nil
+}
+
+func (pp *pagedConfigurableProvider) FetchBeaconWithdrawals(ctx context.Context, address string, startPage, endPage int) ([]EtherscanWithdrawalTx, error) {
+	return make([]EtherscanWithdrawalTx, pp.pageLen(TxTypeWithdrawal, startPage)), nil
+}
+
+func TestCountTransactions(t *testing.T) {
+	mockProvider := &pagedConfigurableProvider{
+		pageSizes: map[TransactionType][]int{
+			TxTypeNormal:   {100, 100, 42},
+			TxTypeInternal: {7},
+			TxTypeToken:    {100, 100},
+			TxTypeNFT:      {},
+		},
+	}
+
+	fetcher := NewTransactionFetcher(mockProvider, NewEtherscanNormalizer())
+
+	counts, err := fetcher.CountTransactions(context.Background(), "0xtest")
+	if err != nil {
+		t.Fatalf("CountTransactions() error = %v", err)
+	}
+
+	want := map[TransactionType]int{
+		TxTypeNormal:   242,
+		TxTypeInternal: 7,
+		TxTypeToken:    200,
+		TxTypeNFT:      0,
+		TxTypeERC1155:  0,
+	}
+	for txType, expected := range want {
+		if counts[txType] != (TransactionCount{Count: expected}) {
+			t.Errorf("%s count = %s, want %d", txType, counts[txType], expected)
+		}
+	}
+
+	// The short third page ends normal paging without probing page 4
+	if mockProvider.calls[TxTypeNormal] != 3 {
+		t.Errorf("Expected 3 normal page requests, got %d", mockProvider.calls[TxTypeNormal])
+	}
+	// Two full pages need an empty third page to confirm the end
+	if mockProvider.calls[TxTypeToken] != 3 {
+		t.Errorf("Expected 3 token page requests, got %d", mockProvider.calls[TxTypeToken])
+	}
+}
+
+func TestCountTransactionsStopsAtResultWindow(t *testing.T) {
+	tests := []struct {
+		name      string
+		records   int
+		pageSize  int
+		want      TransactionCount
+		wantCalls int32
+	}{
+		{name: "past_window", records: 12000, pageSize: 5000, want: TransactionCount{Count: 10000, AtLeast: true}, wantCalls: 2},
+		{name: "within_window", records: 7000, pageSize: 5000, want: TransactionCount{Count: 7000}, wantCalls: 2},
+		{name: "short_first_page", records: 7, pageSize: DefaultPageSize, want: TransactionCount{Count: 7}, wantCalls: 1},
+		{name: "full_single_page", records: 10000, pageSize: DefaultPageSize, want: TransactionCount{Count: 10000, AtLeast: true}, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, calls := newWindowServer(t, tt.records)
+			client := NewEtherscanClient(ClientConfig{
+				APIKey:     "test-key",
+				BaseURL:    server.URL,
+				HTTPClient: server.Client(),
+				PageSize:   tt.pageSize,
+				RateLimit:  time.Nanosecond,
+			})
+			fetcher := NewTransactionFetcher(client, NewEtherscanNormalizer())
+			fetcher.SetTypes([]TransactionType{TxTypeNormal})
+
+			counts, err := fetcher.CountTransactions(context.Background(), "0xtest")
+			if err != nil {
+				t.Fatalf("CountTransactions() error = %v", err)
+			}
+			if counts[TxTypeNormal] != tt.want {
+				t.Errorf("Count mismatch: got %s, want %s", counts[TxTypeNormal], tt.want)
+			}
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("Request count mismatch: got %d, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestCountTransactionsWithError(t *testing.T) {
+	fetcher := NewTransactionFetcher(&ConfigurableProvider{Err: errMock}, NewEtherscanNormalizer())
+
+	if _, err := fetcher.CountTransactions(context.Background(), "0xtest"); err == nil {
+		t.Error("Expected error, got none")
+	}
+}
+
+func TestFetchAllFlagsTruncatedTypes(t *testing.T) {
+	mockProvider := &ConfigurableProvider{
+		NormalTxs: []EtherscanNormalTx{
+			{Hash: "0x1", BlockNumber: "1", TimeStamp: "1000"},
+		},
+		TokenTxs: []EtherscanTokenTx{
+			{Hash: "0x2", BlockNumber: "2", TimeStamp: "1001", TokenDecimal: "6"},
+			{Hash: "0x3", BlockNumber: "3", TimeStamp: "1002", TokenDecimal: "6"},
+		},
+	}
+
+	fetcher := NewTransactionFetcher(mockProvider, NewEtherscanNormalizer())
+	fetcher.SetWindowSize(2)
+
+	result, err := fetcher.FetchAll(context.Background(), "0xtest", 1, 1)
+	if err != nil {
+		t.Fatalf("FetchAll() error = %v", err)
+	}
+
+	if len(result.Transactions) != 3 {
+		t.Errorf("Expected 3 transactions, got %d", len(result.Transactions))
+	}
+	if !result.Truncated {
+		t.Fatal("Expected result to be flagged as truncated")
+	}
+	if len(result.TruncatedTypes) != 1 || result.TruncatedTypes[0] != TxTypeToken {
+		t.Errorf("Expected only ERC-20 to be truncated, got %v", result.TruncatedTypes)
+	}
+}
+
+func TestFetchAllIncludesBeaconWithdrawals(t *testing.T) {
+	mockProvider := &ConfigurableProvider{
+		NormalTxs: []EtherscanNormalTx{
+			{Hash: "0x1", BlockNumber: "2", TimeStamp: "1001"},
+		},
+		WithdrawalTxs: []EtherscanWithdrawalTx{
+			{Address: "0xtest", Amount: "18234567", BlockNumber: "1", Timestamp: "1000"},
+		},
+	}
+
+	fetcher := NewTransactionFetcher(mockProvider, NewEtherscanNormalizer())
+
+	txs, err := fetcher.FetchAllTransactions(context.Background(), "0xtest", 1, 1)
+	if err != nil {
+		t.Fatalf("FetchAllTransactions() error = %v", err)
+	}
+
+	if len(txs) != 2 {
+		t.Fatalf("Expected 2 transactions, got %d", len(txs))
+	}
+	if txs[0].Type != models.TypeBeaconWithdrawal {
+		t.Errorf("Expected withdrawal first by block order, got %s", txs[0].Type)
+	}
+}
+
+// chainConfigurableProvider is a ConfigurableProvider bound to a named chain
+type chainConfigurableProvider struct {
+	ConfigurableProvider
+	chain string
+}
+
+func (cp *chainConfigurableProvider) Chain() string {
+	return cp.chain
+}
+
+func TestFetchAllSkipsWithdrawalsOffEthereum(t *testing.T) {
+	tests := []struct {
+		chain     string
+		wantCalls int
+	}{
+		{chain: "ethereum", wantCalls: 1},
+		{chain: "polygon", wantCalls: 0},
+		{chain: "base", wantCalls: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.chain, func(t *testing.T) {
+			provider := &chainConfigurableProvider{chain: tt.chain}
+			// A withdrawal request failing would abort the whole fetch
+			provider.Errors = map[TransactionType]error{TxTypeWithdrawal: errMock}
+
+			fetcher := NewTransactionFetcher(provider, NewEtherscanNormalizer())
+			_, err := fetcher.FetchAll(context.Background(), "0xtest", 1, 1)
+			if gotErr := err != nil; gotErr != (tt.wantCalls > 0) {
+				t.Errorf("FetchAll() error = %v", err)
+			}
+			if got := provider.Calls(TxTypeWithdrawal); got != tt.wantCalls {
+				t.Errorf("Withdrawal calls mismatch: got %d, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestNormalizedWithdrawalsKeepDistinctKeys(t *testing.T) {
+	provider := &ConfigurableProvider{
+		WithdrawalTxs: []EtherscanWithdrawalTx{
+			{WithdrawalIndex: "1001", Address: "0xtest", Amount: "18234567", BlockNumber: "1", Timestamp: "1000"},
+			{WithdrawalIndex: "1002", Address: "0xtest", Amount: "18234567", BlockNumber: "2", Timestamp: "1012"},
+		},
+	}
+
+	txs, err := NewTransactionFetcher(provider, NewEtherscanNormalizer()).FetchAllTransactions(context.Background(), "0xtest", 1, 1)
+	if err != nil {
+		t.Fatalf("FetchAllTransactions() error = %v", err)
+	}
+	if len(txs) != 2 {
+		t.Fatalf("Expected 2 withdrawals, got %d", len(txs))
+	}
+	if txs[0].Key() == txs[1].Key() {
+		t.Errorf("Expected distinct keys for equal withdrawals, both got %s", txs[0].Key())
+	}
+}
+
+func TestFetchAllNotTruncatedBelowWindow(t *testing.T) {
+	mockProvider := &ConfigurableProvider{
+		NormalTxs: []EtherscanNormalTx{
+			{Hash: "0x1", BlockNumber: "1", TimeStamp: "1000"},
+		},
+	}
+
+	fetcher := NewTransactionFetcher(mockProvider, NewEtherscanNormalizer())
+
+	result, err := fetcher.FetchAll(context.Background(), "0xtest", 1, 1)
+	if err != nil {
+		t.Fatalf("FetchAll() error = %v", err)
+	}
+	if result.Truncated || len(result.TruncatedTypes) != 0 {
+		t.Errorf("Expected no truncation, got %v", result.TruncatedTypes)
+	}
+}
+
+func TestFetchAllReportsProgress(t *testing.T) {
+	mockProvider := &ConfigurableProvider{
+		NormalTxs: []EtherscanNormalTx{
+			{Hash: "0x1", BlockNumber: "1", TimeStamp: "1000"},
+			{Hash: "0x2", BlockNumber: "2", TimeStamp: "1001"},
+		},
+		WithdrawalTxs: []EtherscanWithdrawalTx{
+			{Address: "0xtest", Amount: "18234567", BlockNumber: "3", Timestamp: "1002"},
+		},
+	}
+
+	fetcher := NewTransactionFetcher(mockProvider, NewEtherscanNormalizer())
+	fetcher.SetTypes([]TransactionType{TxTypeNormal, TxTypeToken, TxTypeWithdrawal})
+	var reports []FetchProgress
+	fetcher.SetProgressCallback(func(p FetchProgress) { reports = append(reports, p) })
+
+	if _, err := fetcher.FetchAll(context.Background(), "0xtest", 1, 1); err != nil {
+		t.Fatalf("FetchAll() error = %v", err)
+	}
+
+	want := []FetchProgress{
+		{TypesComplete: 1, TypesTotal: 3, Transactions: 2, LastType: TxTypeNormal, LastCount: 2},
+		{TypesComplete: 2, TypesTotal: 3, Transactions: 2, LastType: TxTypeToken, LastCount: 0},
+		{TypesComplete: 3, TypesTotal: 3, Transactions: 3, LastType: TxTypeWithdrawal, LastCount: 1},
+	}
+	if len(reports) != len(want) {
+		t.Fatalf("Expected %d progress reports, got %d: %+v", len(want), len(reports), reports)
+	}
+	for i := range want {
+		if reports[i] != want[i] {
+			t.Errorf("Report %d mismatch: got %+v, want %+v", i, reports[i], want[i])
+		}
+	}
+}
+
+// cancelingProvider cancels the fetch while internal transactions are being requested
+type cancelingProvider struct {
+	ConfigurableProvider
+	cancel context.CancelFunc
+}
+
+func (cp *cancelingProvider) FetchInternalTransactions(ctx context.Context, address string, startPage, endPage int) ([]EtherscanInternalTx, error) {
+	cp.cancel()
+	return nil, ctx.Err()
+}
+
+func TestFetchAllReturnsPartialResultOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	provider := &cancelingProvider{
+		ConfigurableProvider: ConfigurableProvider{
+			NormalTxs: []EtherscanNormalTx{
+				{Hash: "0x2", BlockNumber: "2", TimeStamp: "1001"},
+				{Hash: "0x1", BlockNumber: "1", TimeStamp: "1000"},
+			},
+			TokenTxs: []EtherscanTokenTx{
+				{Hash: "0x3", BlockNumber: "3", TimeStamp: "1002", TokenDecimal: "18"},
+			},
+		},
+		cancel: cancel,
+	}
+	fetcher := NewTransactionFetcher(provider, NewEtherscanNormalizer())
+
+	result, err := fetcher.FetchAll(ctx, "0xtest", 1, 1)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	if result == nil {
+		t.Fatal("Expected partial result on cancellation")
+	}
+	if len(result.Transactions) != 2 {
+		t.Fatalf("Expected the 2 normal transactions fetched before cancel, got %d", len(result.Transactions))
+	}
+	if result.Transactions[0].Hash != "0x1" {
+		t.Errorf("Expected partial result to be sorted, got %s first", result.Transactions[0].Hash)
+	}
+}
+
+func TestFetchAllDiscardsResultOnError(t *testing.T) {
+	fetcher := NewTransactionFetcher(&ConfigurableProvider{Err: errMock}, NewEtherscanNormalizer())
+
+	result, err := fetcher.FetchAll(context.Background(), "0xtest", 1, 1)
+	if err == nil {
+		t.Fatal("Expected error, got none")
+	}
+	if result != nil {
+		t.Errorf("Expected nil result for a non-cancellation error, got %d transactions", len(result.Transactions))
+	}
+}
+
+// pageCountingProvider records which normal-transaction pages and token requests were made
+type pageCountingProvider struct {
+	*twoPageProvider
+	normalPages []int
+	tokenCalls  int
+}
+
+func (pp *pageCountingProvider) FetchNormalTransactions(ctx context.Context, address string, startPage, endPage int) ([]EtherscanNormalTx, error) {
+	pp.normalPages = append(pp.normalPages, startPage)
+	return pp.twoPageProvider.FetchNormalTransactions(ctx, address, startPage, endPage)
+}
+
+func (pp *pageCountingProvider) FetchTokenTransfers(ctx context.Context, address string, startPage, endPage int) ([]EtherscanTokenTx, error) {
+	pp.tokenCalls++
+	return pp.twoPageProvider.FetchTokenTransfers(ctx, address, startPage, endPage)
+}
+
+func TestFetchAllStopsAtMaxTransactions(t *testing.T) {
+	provider := &pageCountingProvider{twoPageProvider: &twoPageProvider{
+		normalPages: [][]EtherscanNormalTx{
+			{{Hash: "0x1", BlockNumber: "1"}, {Hash: "0x2", BlockNumber: "2"}},
+			{{Hash: "0x3", BlockNumber: "3"}, {Hash: "0x4", BlockNumber: "4"}},
+			{{Hash: "0x5", BlockNumber: "5"}, {Hash: "0x6", BlockNumber: "6"}},
+		},
+		tokenPages: [][]EtherscanTokenTx{
+			{{Hash: "0x7", BlockNumber: "7", TokenDecimal: "6"}},
+		},
+	}}
+	fetcher := NewTransactionFetcher(provider, NewEtherscanNormalizer())
+	fetcher.SetMaxTransactions(3)
+
+	result, err := fetcher.FetchAll(context.Background(), "0xtest", 1, 3)
+	if err != nil {
+		t.Fatalf("FetchAll() error = %v", err)
+	}
+
+	if !result.Capped {
+		t.Error("Expected result to be marked capped")
+	}
+	if len(result.Transactions) != 3 {
+		t.Fatalf("Expected 3 transactions, got %d", len(result.Transactions))
+	}
+	if len(provider.normalPages) != 2 {
+		t.Errorf("Expected paging to stop after page 2, requested pages %v", provider.normalPages)
+	}
+	if provider.tokenCalls != 0 {
+		t.Errorf("Expected no token requests after the cap, got %d", provider.tokenCalls)
+	}
+}
+
+// failingNormalizer rejects the normal transaction with a given hash
+type failingNormalizer struct {
+	*EtherscanNormalizer
+	badHash string
+}
+
+func (fn *failingNormalizer) NormalizeNormalTx(tx EtherscanNormalTx) (*models.Transaction, error) {
+	if tx.Hash == fn.badHash {
+		return nil, errors.New("value is not a number")
+	}
+	return fn.EtherscanNormalizer.NormalizeNormalTx(tx)
+}
+
+func TestFetchAllRecordsNormalizationErrors(t *testing.T) {
+	provider := &ConfigurableProvider{
+		NormalTxs: []EtherscanNormalTx{
+			{Hash: "0xgood", BlockNumber: "1"},
+			{Hash: "0xbad", BlockNumber: "2", Value: "abc"},
+		},
+	}
+	fetcher := NewTransactionFetcher(provider, &failingNormalizer{EtherscanNormalizer: NewEtherscanNormalizer(), badHash: "0xbad"})
+
+	result, err := fetcher.FetchAll(context.Background(), "0xtest", 1, 1)
+	if err != nil {
+		t.Fatalf("FetchAll() error = %v", err)
+	}
+
+	stats := result.NormalizationStats
+	if stats.TotalProcessed != 2 || stats.SuccessCount != 1 || stats.ErrorCount != 1 {
+		t.Errorf("Stats mismatch: %+v", stats)
+	}
+	if len(stats.Errors) != 1 {
+		t.Fatalf("Expected 1 recorded error, got %d", len(stats.Errors))
+	}
+
+	var normErr *NormalizationError
+	if !errors.As(stats.Errors[0], &normErr) {
+		t.Fatalf("Expected *NormalizationError, got %T", stats.Errors[0])
+	}
+	if normErr.Hash != "0xbad" || normErr.Type != "Normal" || normErr.Message != "value is not a number" {
+		t.Errorf("Error mismatch: %+v", normErr)
+	}
+	if raw, ok := normErr.Raw.(EtherscanNormalTx); !ok || raw.Value != "abc" {
+		t.Errorf("Expected the raw transaction to be kept, got %#v", normErr.Raw)
+	}
+}
@@ -21,6 +21,66 @@ type Provider interface {
 	
 	// FetchERC1155Transfers fetches ERC-1155 multi-token transfers
 	FetchERC1155Transfers(ctx context.Context, address string, startPage, endPage int) ([]EtherscanTokenTx, error)
+	
+	// FetchBeaconWithdrawals fetches consensus-layer withdrawals credited to the address
+	FetchBeaconWithdrawals(ctx context.Context, address string, startPage, endPage int) ([]EtherscanWithdrawalTx, error)
+}
+
+// ChainNamer is implemented by providers bound to a single chain. Fetchers label
+// each transaction's Chain with its name.
+type ChainNamer interface {
+	Chain() string
+}
+
+// labelChain sets Chain on txs when the provider reports one
+func labelChain(provider Provider, txs []*models.Transaction) {
+	namer, ok := provider.(ChainNamer)
+	if !ok {
+		return
+	}
+	chain := namer.Chain()
+	for _, tx := range txs {
+		tx.Chain = chain
+	}
+}
+
+// hasBeaconWithdrawals reports whether provider's chain has beacon withdrawals.
+// Providers that don't name a chain are asked anyway.
+func hasBeaconWithdrawals(provider Provider) bool {
+	namer, ok := provider.(ChainNamer)
+	if !ok || namer.Chain() == "" {
+		return true
+	}
+	return ChainHasBeaconWithdrawals(namer.Chain())
+}
+
+// PageLimiter is implemented by providers that serve page-numbered queries of a
+// fixed size only up to a fixed page, like Etherscan's result window. Pagers stop
+// at MaxPage rather than request pages the provider would reject. Either method
+// may return 0 when the provider has no such limit.
+type PageLimiter interface {
+	PageSize() int // Records in a full page
+	MaxPage() int  // Last page the provider serves
+}
+
+// pageLimits returns the last page to request from provider, at most limit, and
+// the size of a full page, or 0 when the provider doesn't say
+func pageLimits(provider Provider, limit int) (lastPage, pageSize int) {
+	limiter, ok := provider.(PageLimiter)
+	if !ok {
+		return limit, 0
+	}
+	if maxPage := limiter.MaxPage(); maxPage > 0 {
+		limit = min(maxPage, limit)
+	}
+	return limit, limiter.PageSize()
+}
+
+// BlockRanger is implemented by providers that can restrict fetches to a block range.
+// The parallel fetcher uses it to split one transaction type into shards.
+type BlockRanger interface {
+	// WithBlockRange returns a provider that only fetches blocks startBlock..endBlock (inclusive)
+	WithBlockRange(startBlock, endBlock uint64) Provider
 }
 
 // Normalizer defines the interface for converting provider responses to normalized transactions
@@ -39,4 +99,7 @@ type Normalizer interface {
 	
 	// NormalizeERC1155Tx converts Etherscan ERC-1155 tx to normalized transaction
 	NormalizeERC1155Tx(tx EtherscanTokenTx) (*models.Transaction, error)
+	
+	// NormalizeWithdrawalTx converts Etherscan beacon withdrawal to normalized transaction
+	NormalizeWithdrawalTx(tx EtherscanWithdrawalTx) (*models.Transaction, error)
 }
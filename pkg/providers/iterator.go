@@ -0,0 +1,189 @@
+package providers
+
+import (
+	"conintracker-hiring/pkg/models"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrPageLimitReached is returned by TransactionIterator when a type still had full
+// pages at the provider's last page (see PageLimiter), so its history is incomplete
+var ErrPageLimitReached = errors.New("reached the provider's last page with more results pending")
+
+// TransactionIterator yields normalized transactions one at a time, fetching a single
+// page from the provider whenever its buffer runs dry. Transactions are yielded type
+// by type in canonical order (see fetchTypeOrder) and, within a type, in page order,
+// so memory use is bounded by one page rather than the whole history.
+type TransactionIterator struct {
+	provider   Provider
+	normalizer Normalizer
+	address    string
+
+	typeIndex     int // Position in fetchTypeOrder
+	page          int // Next page to fetch for the current type
+	lastPage      int // Last page the provider serves
+	pageSize      int // Records in a full page; 0 if the provider doesn't say
+	firstPageSize int
+	buffer        []*models.Transaction
+	err           error // Returned once the buffer drains, ending iteration
+	stats         NormalizationStats
+}
+
+// NewTransactionIterator creates an iterator over all transaction types for an address
+func NewTransactionIterator(provider Provider, normalizer Normalizer, address string) *TransactionIterator {
+	lastPage, pageSize := pageLimits(provider, maxCountPages)
+	return &TransactionIterator{
+		provider:   provider,
+		normalizer: normalizer,
+		address:    address,
+		page:       1,
+		lastPage:   lastPage,
+		pageSize:   pageSize,
+	}
+}
+
+// Next returns the next transaction, or io.EOF once every type is exhausted.
+// Paging for a type stops at the first empty page or the first short page. A type
+// whose last page the provider serves is still full yields its transactions and
+// then fails with ErrPageLimitReached rather than requesting pages past the limit.
+func (it *TransactionIterator) Next(ctx context.Context) (*models.Transaction, error) {
+	for len(it.buffer) == 0 {
+		if it.err != nil {
+			return nil, it.err
+		}
+		if it.typeIndex >= len(fetchTypeOrder) {
+			return nil, io.EOF
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		txType := fetchTypeOrder[it.typeIndex]
+		txs, rawCount, err := it.fetchPage(ctx, txType, it.page)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch %s page %d: %w", txType.String(), it.page, err)
+		}
+		labelChain(it.provider, txs)
+		it.buffer = txs
+
+		// Decide whether this type has more pages
+		lastPage := rawCount == 0 || rawCount < it.pageSize
+		if it.page == 1 {
+			it.firstPageSize = rawCount
+		} else if rawCount < it.firstPageSize {
+			lastPage = true
+		}
+		if !lastPage && it.page >= it.lastPage {
+			it.err = fmt.Errorf("%s transactions after page %d: %w", txType.String(), it.page, ErrPageLimitReached)
+			lastPage = true
+		}
+
+		if lastPage {
+			it.typeIndex++
+			it.page = 1
+			it.firstPageSize = 0
+		} else {
+			it.page++
+		}
+	}
+
+	tx := it.buffer[0]
+	it.buffer = it.buffer[1:]
+	return tx, nil
+}
+
+// Stats reports the records normalized so far. As in TransactionFetcher, Errors
+// holds a *NormalizationError for each raw transaction that was skipped.
+func (it *TransactionIterator) Stats() NormalizationStats {
+	return it.stats
+}
+
+// fetchPage fetches and normalizes a single page of one transaction type.
+// Transactions that fail to normalize are skipped and recorded in the stats.
+func (it *TransactionIterator) fetchPage(ctx context.Context, txType TransactionType, page int) ([]*models.Transaction, int, error) {
+	var normalized []*models.Transaction
+	keep := func(hash string, raw any, tx *models.Transaction, err error) {
+		it.stats.TotalProcessed++
+		if err != nil {
+			it.stats.record(txType, hash, raw, err)
+			return
+		}
+		it.stats.SuccessCount++
+		normalized = append(normalized, tx)
+	}
+
+	switch txType {
+	case TxTypeNormal:
+		rawTxs, err := it.provider.FetchNormalTransactions(ctx, it.address, page, page)
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, tx := range rawTxs {
+			norm, err := it.normalizer.NormalizeNormalTx(tx)
+			keep(tx.Hash, tx, norm, err)
+		}
+		return normalized, len(rawTxs), nil
+
+	case TxTypeInternal:
+		rawTxs, err := it.provider.FetchInternalTransactions(ctx, it.address, page, page)
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, tx := range rawTxs {
+			norm, err := it.normalizer.NormalizeInternalTx(tx)
+			keep(tx.Hash, tx, norm, err)
+		}
+		return normalized, len(rawTxs), nil
+
+	case TxTypeToken:
+		rawTxs, err := it.provider.FetchTokenTransfers(ctx, it.address, page, page)
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, tx := range rawTxs {
+			norm, err := it.normalizer.NormalizeERC20Tx(tx)
+			keep(tx.Hash, tx, norm, err)
+		}
+		return normalized, len(rawTxs), nil
+
+	case TxTypeNFT:
+		rawTxs, err := it.provider.FetchNFTTransfers(ctx, it.address, page, page)
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, tx := range rawTxs {
+			norm, err := it.normalizer.NormalizeERC721Tx(tx)
+			keep(tx.Hash, tx, norm, err)
+		}
+		return normalized, len(rawTxs), nil
+
+	case TxTypeERC1155:
+		rawTxs, err := it.provider.FetchERC1155Transfers(ctx, it.address, page, page)
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, tx := range rawTxs {
+			norm, err := it.normalizer.NormalizeERC1155Tx(tx)
+			keep(tx.Hash, tx, norm, err)
+		}
+		return normalized, len(rawTxs), nil
+
+	case TxTypeWithdrawal:
+		if !hasBeaconWithdrawals(it.provider) {
+			return nil, 0, nil
+		}
+		rawTxs, err := it.provider.FetchBeaconWithdrawals(ctx, it.address, page, page)
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, tx := range rawTxs {
+			norm, err := it.normalizer.NormalizeWithdrawalTx(tx)
+			keep("", tx, norm, err)
+		}
+		return normalized, len(rawTxs), nil
+	}
+
+	return nil, 0, fmt.Errorf("unsupported transaction type %s", txType.String())
+}
@@ -0,0 +1,150 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+// twoPageProvider serves normal transactions across two pages and one page of token transfers
+type twoPageProvider struct {
+	ConfigurableProvider
+	normalPages [][]EtherscanNormalTx
+	tokenPages  [][]EtherscanTokenTx
+}
+
+func (tp *twoPageProvider) FetchNormalTransactions(ctx context.Context, address string, startPage, endPage int) ([]EtherscanNormalTx, error) {
+	if startPage < 1 || startPage > len(tp.normalPages) {
+		return nil, nil
+	}
+	return tp.normalPages[startPage-1], nil
+}
+
+func (tp *twoPageProvider) FetchTokenTransfers(ctx context.Context, address string, startPage, endPage int) ([]EtherscanTokenTx, error) {
+	if startPage < 1 || startPage > len(tp.tokenPages) {
+		return nil, nil
+	}
+	return tp.tokenPages[startPage-1], nil
+}
+
+func TestTransactionIteratorYieldsAllPagesInOrder(t *testing.T) {
+	provider := &twoPageProvider{
+		normalPages: [][]EtherscanNormalTx{
+			{{Hash: "0x1", BlockNumber: "1"}, {Hash: "0x2", BlockNumber: "2"}},
+			{{Hash: "0x3", BlockNumber: "3"}},
+		},
+		tokenPages: [][]EtherscanTokenTx{
+			{{Hash: "0x4", BlockNumber: "4", TokenDecimal: "6"}},
+		},
+	}
+
+	iterator := NewTransactionIterator(provider, NewEtherscanNormalizer(), "0xtest")
+
+	var hashes []string
+	for {
+		tx, err := iterator.Next(context.Background())
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		hashes = append(hashes, tx.Hash)
+	}
+
+	want := []string{"0x1", "0x2", "0x3", "0x4"}
+	if len(hashes) != len(want) {
+		t.Fatalf("Expected %d transactions, got %d: %v", len(want), len(hashes), hashes)
+	}
+	for i := range want {
+		if hashes[i] != want[i] {
+			t.Errorf("Transaction %d hash mismatch: got %s, want %s", i, hashes[i], want[i])
+		}
+	}
+
+	// Exhausted iterators keep returning io.EOF
+	if _, err := iterator.Next(context.Background()); err != io.EOF {
+		t.Errorf("Expected io.EOF after exhaustion, got %v", err)
+	}
+}
+
+func TestTransactionIteratorRecordsNormalizationErrors(t *testing.T) {
+	provider := &twoPageProvider{
+		normalPages: [][]EtherscanNormalTx{
+			{{Hash: "0x1", BlockNumber: "1"}, {Hash: "0xbad", BlockNumber: "2", Value: "1.5 ETH"}},
+		},
+	}
+
+	iterator := NewTransactionIterator(provider, NewEtherscanNormalizer(), "0xtest")
+
+	var hashes []string
+	for {
+		tx, err := iterator.Next(context.Background())
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		hashes = append(hashes, tx.Hash)
+	}
+	if len(hashes) != 1 || hashes[0] != "0x1" {
+		t.Errorf("Expected only 0x1 to be yielded, got %v", hashes)
+	}
+
+	stats := iterator.Stats()
+	if stats.TotalProcessed != 2 || stats.SuccessCount != 1 || stats.ErrorCount != 1 {
+		t.Errorf("Stats mismatch: got %+v, want 2 processed, 1 success, 1 error", stats)
+	}
+	var normErr *NormalizationError
+	if len(stats.Errors) != 1 || !errors.As(stats.Errors[0], &normErr) {
+		t.Fatalf("Expected one *NormalizationError, got %v", stats.Errors)
+	}
+	if normErr.Type != TxTypeNormal.String() || normErr.Hash != "0xbad" {
+		t.Errorf("NormalizationError mismatch: got type %s hash %s", normErr.Type, normErr.Hash)
+	}
+}
+
+func TestTransactionIteratorPropagatesErrors(t *testing.T) {
+	iterator := NewTransactionIterator(&ConfigurableProvider{Err: errMock}, NewEtherscanNormalizer(), "0xtest")
+
+	if _, err := iterator.Next(context.Background()); err == nil || err == io.EOF {
+		t.Errorf("Expected provider error, got %v", err)
+	}
+}
+
+func TestTransactionIteratorStopsAtResultWindow(t *testing.T) {
+	server, calls := newWindowServer(t, 12000)
+	client := NewEtherscanClient(ClientConfig{
+		APIKey:     "test-key",
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		PageSize:   5000,
+		RateLimit:  time.Nanosecond,
+	})
+	iterator := NewTransactionIterator(client, NewEtherscanNormalizer(), "0xtest")
+
+	count := 0
+	var err error
+	for {
+		if _, err = iterator.Next(context.Background()); err != nil {
+			break
+		}
+		count++
+	}
+
+	if !errors.Is(err, ErrPageLimitReached) {
+		t.Fatalf("Expected ErrPageLimitReached, got %v", err)
+	}
+	if count != 10000 {
+		t.Errorf("Expected the 10000 transactions inside the window first, got %d", count)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("Expected 2 requests, got %d", got)
+	}
+	if _, err := iterator.Next(context.Background()); !errors.Is(err, ErrPageLimitReached) {
+		t.Errorf("Expected the error to persist, got %v", err)
+	}
+}
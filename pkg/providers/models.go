@@ -1,5 +1,11 @@
 package providers
 
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
 // EtherscanNormalTx represents a normal ETH transfer response from Etherscan
 type EtherscanNormalTx struct {
 	BlockNumber      string `json:"blockNumber"`
@@ -69,30 +75,104 @@ type EtherscanTokenTx struct {
 	TokenValue        string `json:"tokenValue"` // For ERC-1155
 }
 
-// EtherscanResponse is the common response wrapper
-type EtherscanResponse struct {
-	Status  string          `json:"status"`
-	Message string          `json:"message"`
-	Result  []interface{}   `json:"result"`
+// EtherscanWithdrawalTx represents a consensus-layer (beacon chain) withdrawal from Etherscan
+type EtherscanWithdrawalTx struct {
+	WithdrawalIndex string `json:"withdrawalIndex"`
+	ValidatorIndex  string `json:"validatorIndex"`
+	Address         string `json:"address"`
+	Amount          string `json:"amount"` // in Gwei
+	BlockNumber     string `json:"blockNumber"`
+	Timestamp       string `json:"timestamp"`
 }
 
-// NormalTxResponse wraps Etherscan normal transaction results
-type NormalTxResponse struct {
-	Status  string                `json:"status"`
-	Message string                `json:"message"`
-	Result  []EtherscanNormalTx    `json:"result"`
+// EtherscanResponse is the common response wrapper. Etherscan returns the
+// result as a list of records on success and as a string on errors, so a
+// string result is kept in ResultText instead of Result.
+type EtherscanResponse[T any] struct {
+	Status     string
+	Message    string
+	Result     []T
+	ResultText string
+	Skipped    int // Records in the result list that did not decode into T and were dropped
+
+	maxResults int // Decoding fails with ErrTooManyResults past this many records; 0 is unlimited
 }
 
-// InternalTxResponse wraps Etherscan internal transaction results
-type InternalTxResponse struct {
-	Status  string                 `json:"status"`
-	Message string                 `json:"message"`
-	Result  []EtherscanInternalTx   `json:"result"`
+// UnmarshalJSON decodes the result list directly into T, accepting a string result
+func (r *EtherscanResponse[T]) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Status  string          `json:"status"`
+		Message string          `json:"message"`
+		Result  json.RawMessage `json:"result"`
+	}
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+
+	r.Status = raw.Status
+	r.Message = raw.Message
+	r.Result = nil
+	r.ResultText = ""
+	r.Skipped = 0
+
+	result := bytes.TrimSpace(raw.Result)
+	if len(result) == 0 {
+		return nil
+	}
+	switch result[0] {
+	case '[':
+		r.Result, r.Skipped, err = decodeList[T](result, r.maxResults)
+		return err
+	case '"':
+		return json.Unmarshal(result, &r.ResultText)
+	}
+	return nil
 }
 
-// TokenTxResponse wraps Etherscan token transfer results
-type TokenTxResponse struct {
-	Status  string              `json:"status"`
-	Message string              `json:"message"`
-	Result  []EtherscanTokenTx   `json:"result"`
+// decodeList decodes a JSON array one element at a time, failing with
+// ErrTooManyResults as soon as it holds more than limit elements (0 is unlimited)
+// so an oversized response is never fully materialized. An element that does not
+// decode into T, such as null or a record with a number where a string is
+// expected, is skipped and counted rather than failing the whole page.
+func decodeList[T any](data []byte, limit int) ([]T, int, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if _, err := dec.Token(); err != nil { // Opening [
+		return nil, 0, err
+	}
+	list := []T{}
+	count, skipped := 0, 0
+	for dec.More() {
+		if limit > 0 && count == limit {
+			return nil, 0, fmt.Errorf("%w: more than %d records", ErrTooManyResults, limit)
+		}
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, 0, err
+		}
+		count++
+
+		var item T
+		if bytes.Equal(raw, []byte("null")) || json.Unmarshal(raw, &item) != nil {
+			skipped++
+			continue
+		}
+		list = append(list, item)
+	}
+	if _, err := dec.Token(); err != nil { // Closing ]
+		return nil, 0, err
+	}
+	return list, skipped, nil
 }
+
+// NormalTxResponse wraps Etherscan normal transaction results
+type NormalTxResponse = EtherscanResponse[EtherscanNormalTx]
+
+// InternalTxResponse wraps Etherscan internal transaction results
+type InternalTxResponse = EtherscanResponse[EtherscanInternalTx]
+
+// TokenTxResponse wraps Etherscan token transfer results
+type TokenTxResponse = EtherscanResponse[EtherscanTokenTx]
+
+// WithdrawalTxResponse wraps Etherscan beacon withdrawal results
+type WithdrawalTxResponse = EtherscanResponse[EtherscanWithdrawalTx]
@@ -0,0 +1,419 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// MoralisBaseURL is the Moralis EVM (deep index) API endpoint
+	MoralisBaseURL = "https://deep-index.moralis.io/api/v2.2"
+
+	// DefaultMoralisPageSize is the records requested per cursor page (Moralis's limit parameter)
+	DefaultMoralisPageSize = 100
+
+	// MoralisRateLimitDelay is the default spacing between Moralis requests
+	MoralisRateLimitDelay = 100 * time.Millisecond
+)
+
+// MoralisClient implements the Provider interface on Moralis's wallet history
+// endpoints, mapping each response onto the Etherscan raw structs so the shared
+// normalizer can be used unchanged. Moralis pages with opaque cursors; page N is
+// reached by following N-1 cursors.
+type MoralisClient struct {
+	apiKey     string
+	httpClient *http.Client
+	baseURL    string
+	chain      string
+	chainID    int
+	pageSize   int
+	rateLimit  time.Duration // Minimum spacing between requests
+	lastReq    time.Time     // Track last request for rate limiting
+	mu         sync.Mutex    // Guards lastReq
+}
+
+// MoralisConfig holds configuration for the Moralis client
+type MoralisConfig struct {
+	APIKey     string
+	HTTPClient *http.Client
+	BaseURL    string
+	Chain      string        // Chain name, see SupportedChains; empty uses DefaultChain
+	PageSize   int           // Records per cursor page; 0 uses DefaultMoralisPageSize
+	RateLimit  time.Duration // Minimum spacing between requests; 0 uses MoralisRateLimitDelay
+}
+
+// NewMoralisClient creates a new Moralis API client
+func NewMoralisClient(cfg MoralisConfig) *MoralisClient {
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{
+			Timeout: 30 * time.Second,
+		}
+	}
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = MoralisBaseURL
+	}
+	if cfg.Chain == "" {
+		cfg.Chain = DefaultChain
+	}
+	if cfg.PageSize <= 0 {
+		cfg.PageSize = DefaultMoralisPageSize
+	}
+	if cfg.RateLimit <= 0 {
+		cfg.RateLimit = MoralisRateLimitDelay
+	}
+	chain := strings.ToLower(cfg.Chain)
+	chainID, _ := ChainID(chain)
+
+	return &MoralisClient{
+		apiKey:     cfg.APIKey,
+		httpClient: cfg.HTTPClient,
+		baseURL:    strings.TrimRight(cfg.BaseURL, "/"),
+		chain:      chain,
+		chainID:    chainID,
+		pageSize:   cfg.PageSize,
+		rateLimit:  cfg.RateLimit,
+		lastReq:    time.Now().Add(-cfg.RateLimit),
+	}
+}
+
+// Chain returns the name of the chain the client queries
+func (c *MoralisClient) Chain() string {
+	return c.chain
+}
+
+// moralisPage is the envelope of a cursor-paginated Moralis response
+type moralisPage[T any] struct {
+	Cursor string `json:"cursor"`
+	Result []T    `json:"result"`
+}
+
+// moralisNativeTx is a wallet transaction from GET /{address}
+type moralisNativeTx struct {
+	Hash                 string                `json:"hash"`
+	Nonce                string                `json:"nonce"`
+	TransactionIndex     string                `json:"transaction_index"`
+	FromAddress          string                `json:"from_address"`
+	ToAddress            string                `json:"to_address"`
+	Value                string                `json:"value"`
+	Gas                  string                `json:"gas"`
+	GasPrice             string                `json:"gas_price"`
+	Input                string                `json:"input"`
+	ReceiptGasUsed       string                `json:"receipt_gas_used"`
+	ReceiptStatus        string                `json:"receipt_status"`
+	ReceiptContract      string                `json:"receipt_contract_address"`
+	BlockTimestamp       string                `json:"block_timestamp"`
+	BlockNumber          string                `json:"block_number"`
+	BlockHash            string                `json:"block_hash"`
+	InternalTransactions []moralisInternalCall `json:"internal_transactions"`
+}
+
+// moralisInternalCall is a trace entry included with include=internal_transactions
+type moralisInternalCall struct {
+	TransactionHash string `json:"transaction_hash"`
+	BlockNumber     string `json:"block_number"`
+	Type            string `json:"type"`
+	From            string `json:"from"`
+	To              string `json:"to"`
+	Value           string `json:"value"`
+	Gas             string `json:"gas"`
+	GasUsed         string `json:"gas_used"`
+	Input           string `json:"input"`
+}
+
+// moralisTokenTransfer is an ERC-20 transfer from GET /{address}/erc20/transfers
+type moralisTokenTransfer struct {
+	TokenName        string `json:"token_name"`
+	TokenSymbol      string `json:"token_symbol"`
+	TokenDecimals    string `json:"token_decimals"`
+	Address          string `json:"address"` // Token contract
+	TransactionHash  string `json:"transaction_hash"`
+	TransactionIndex string `json:"transaction_index"`
+	BlockTimestamp   string `json:"block_timestamp"`
+	BlockNumber      string `json:"block_number"`
+	BlockHash        string `json:"block_hash"`
+	FromAddress      string `json:"from_address"`
+	ToAddress        string `json:"to_address"`
+	Value            string `json:"value"`
+}
+
+// moralisNFTTransfer is an ERC-721 or ERC-1155 transfer from GET /{address}/nft/transfers
+type moralisNFTTransfer struct {
+	TokenAddress     string `json:"token_address"`
+	TokenID          string `json:"token_id"`
+	TokenName        string `json:"token_name"`
+	TokenSymbol      string `json:"token_symbol"`
+	ContractType     string `json:"contract_type"` // ERC721 or ERC1155
+	Amount           string `json:"amount"`
+	TransactionHash  string `json:"transaction_hash"`
+	TransactionIndex string `json:"transaction_index"`
+	BlockTimestamp   string `json:"block_timestamp"`
+	BlockNumber      string `json:"block_number"`
+	BlockHash        string `json:"block_hash"`
+	FromAddress      string `json:"from_address"`
+	ToAddress        string `json:"to_address"`
+}
+
+// FetchNormalTransactions fetches the wallet's transactions
+func (c *MoralisClient) FetchNormalTransactions(ctx context.Context, address string, startPage, endPage int) ([]EtherscanNormalTx, error) {
+	txs, err := moralisPages[moralisNativeTx](ctx, c, "/"+address, nil, startPage, endPage)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]EtherscanNormalTx, 0, len(txs))
+	for _, tx := range txs {
+		result = append(result, EtherscanNormalTx{
+			BlockNumber:      tx.BlockNumber,
+			TimeStamp:        moralisTimestamp(tx.BlockTimestamp),
+			Hash:             tx.Hash,
+			Nonce:            tx.Nonce,
+			BlockHash:        tx.BlockHash,
+			TransactionIndex: tx.TransactionIndex,
+			From:             tx.FromAddress,
+			To:               tx.ToAddress,
+			Value:            tx.Value,
+			Gas:              tx.Gas,
+			GasPrice:         tx.GasPrice,
+			IsError:          moralisIsError(tx.ReceiptStatus),
+			TxReceiptStatus:  tx.ReceiptStatus,
+			Input:            tx.Input,
+			ContractAddress:  tx.ReceiptContract,
+			GasUsed:          tx.ReceiptGasUsed,
+		})
+	}
+	return result, nil
+}
+
+// FetchInternalTransactions fetches value-carrying internal calls. Moralis returns
+// them nested under each wallet transaction; calls that move no value are dropped,
+// matching Etherscan's txlistinternal.
+func (c *MoralisClient) FetchInternalTransactions(ctx context.Context, address string, startPage, endPage int) ([]EtherscanInternalTx, error) {
+	params := url.Values{"include": {"internal_transactions"}}
+	txs, err := moralisPages[moralisNativeTx](ctx, c, "/"+address, params, startPage, endPage)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []EtherscanInternalTx
+	for _, tx := range txs {
+		for i, call := range tx.InternalTransactions {
+			if call.Value == "" || call.Value == "0" {
+				continue
+			}
+			result = append(result, EtherscanInternalTx{
+				BlockNumber: call.BlockNumber,
+				TimeStamp:   moralisTimestamp(tx.BlockTimestamp),
+				Hash:        call.TransactionHash,
+				From:        call.From,
+				To:          call.To,
+				Value:       call.Value,
+				Input:       call.Input,
+				Type:        strings.ToLower(call.Type),
+				Gas:         call.Gas,
+				GasUsed:     call.GasUsed,
+				TraceId:     strconv.Itoa(i),
+				IsError:     moralisIsError(tx.ReceiptStatus),
+			})
+		}
+	}
+	return result, nil
+}
+
+// FetchTokenTransfers fetches ERC-20 transfers. Moralis does not report gas for
+// token transfers, so these rows carry no gas fee.
+func (c *MoralisClient) FetchTokenTransfers(ctx context.Context, address string, startPage, endPage int) ([]EtherscanTokenTx, error) {
+	transfers, err := moralisPages[moralisTokenTransfer](ctx, c, "/"+address+"/erc20/transfers", nil, startPage, endPage)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]EtherscanTokenTx, 0, len(transfers))
+	for _, tx := range transfers {
+		result = append(result, EtherscanTokenTx{
+			BlockNumber:      tx.BlockNumber,
+			TimeStamp:        moralisTimestamp(tx.BlockTimestamp),
+			Hash:             tx.TransactionHash,
+			BlockHash:        tx.BlockHash,
+			From:             tx.FromAddress,
+			ContractAddress:  tx.Address,
+			To:               tx.ToAddress,
+			Value:            tx.Value,
+			TokenName:        tx.TokenName,
+			TokenSymbol:      tx.TokenSymbol,
+			TokenDecimal:     tx.TokenDecimals,
+			TransactionIndex: tx.TransactionIndex,
+		})
+	}
+	return result, nil
+}
+
+// FetchNFTTransfers fetches ERC-721 transfers
+func (c *MoralisClient) FetchNFTTransfers(ctx context.Context, address string, startPage, endPage int) ([]EtherscanTokenTx, error) {
+	return c.fetchNFTTransfers(ctx, address, "ERC721", startPage, endPage)
+}
+
+// FetchERC1155Transfers fetches ERC-1155 transfers
+func (c *MoralisClient) FetchERC1155Transfers(ctx context.Context, address string, startPage, endPage int) ([]EtherscanTokenTx, error) {
+	return c.fetchNFTTransfers(ctx, address, "ERC1155", startPage, endPage)
+}
+
+// FetchBeaconWithdrawals returns no withdrawals; Moralis does not index the consensus layer
+func (c *MoralisClient) FetchBeaconWithdrawals(ctx context.Context, address string, startPage, endPage int) ([]EtherscanWithdrawalTx, error) {
+	return nil, nil
+}
+
+// fetchNFTTransfers fetches NFT transfers and keeps those of one contract type.
+// Moralis serves both standards from one endpoint, so each type pages it separately.
+func (c *MoralisClient) fetchNFTTransfers(ctx context.Context, address, contractType string, startPage, endPage int) ([]EtherscanTokenTx, error) {
+	transfers, err := moralisPages[moralisNFTTransfer](ctx, c, "/"+address+"/nft/transfers", nil, startPage, endPage)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []EtherscanTokenTx
+	for _, tx := range transfers {
+		if !strings.EqualFold(tx.ContractType, contractType) {
+			continue
+		}
+		converted := EtherscanTokenTx{
+			BlockNumber:      tx.BlockNumber,
+			TimeStamp:        moralisTimestamp(tx.BlockTimestamp),
+			Hash:             tx.TransactionHash,
+			BlockHash:        tx.BlockHash,
+			From:             tx.FromAddress,
+			ContractAddress:  tx.TokenAddress,
+			To:               tx.ToAddress,
+			TokenName:        tx.TokenName,
+			TokenSymbol:      tx.TokenSymbol,
+			TransactionIndex: tx.TransactionIndex,
+			TokenID:          tx.TokenID,
+		}
+		if contractType == "ERC1155" {
+			converted.TokenValue = tx.Amount
+		}
+		result = append(result, converted)
+	}
+	return result, nil
+}
+
+// moralisPages follows cursors to collect pages startPage..endPage of an endpoint.
+// Earlier pages are requested only to obtain their cursor. Paging stops when
+// Moralis returns no cursor.
+func moralisPages[T any](ctx context.Context, c *MoralisClient, path string, params url.Values, startPage, endPage int) ([]T, error) {
+	if startPage < 1 {
+		startPage = 1
+	}
+
+	query := url.Values{}
+	for key, values := range params {
+		query[key] = values
+	}
+	query.Set("chain", fmt.Sprintf("0x%x", c.chainID))
+	query.Set("limit", strconv.Itoa(c.pageSize))
+	query.Set("order", "ASC")
+
+	var all []T
+	cursor := ""
+	for page := 1; page <= endPage; page++ {
+		if cursor != "" {
+			query.Set("cursor", cursor)
+		}
+
+		var resp moralisPage[T]
+		if err := c.get(ctx, path, query, &resp); err != nil {
+			return nil, err
+		}
+		if page >= startPage {
+			all = append(all, resp.Result...)
+		}
+
+		if resp.Cursor == "" {
+			break
+		}
+		cursor = resp.Cursor
+	}
+
+	return all, nil
+}
+
+// get performs a rate-limited GET request and decodes the JSON body into out
+func (c *MoralisClient) get(ctx context.Context, path string, query url.Values, out any) error {
+	c.mu.Lock()
+	slot := c.lastReq.Add(c.rateLimit)
+	if now := time.Now(); slot.Before(now) {
+		slot = now
+	}
+	c.lastReq = slot
+	c.mu.Unlock()
+
+	if wait := time.Until(slot); wait > 0 {
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("X-API-Key", c.apiKey)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return ErrRateLimited
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Message != "" {
+			return fmt.Errorf("Moralis API error (HTTP %d): %s", resp.StatusCode, apiErr.Message)
+		}
+		return fmt.Errorf("Moralis API returned HTTP %d", resp.StatusCode)
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
+
+// moralisTimestamp converts Moralis's ISO 8601 block_timestamp to Unix seconds,
+// the form the normalizer expects. Unparseable values are passed through.
+func moralisTimestamp(value string) string {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return value
+	}
+	return strconv.FormatInt(t.Unix(), 10)
+}
+
+// moralisIsError maps receipt_status ("1" success, "0" failure) to Etherscan's isError
+func moralisIsError(receiptStatus string) string {
+	if receiptStatus == "0" {
+		return "1"
+	}
+	return "0"
+}
@@ -0,0 +1,146 @@
+package providers
+
+import (
+	"conintracker-hiring/internal/testdata"
+	"conintracker-hiring/pkg/models"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newMoralisTestServer serves the canned Moralis pages, following the wallet cursor
+func newMoralisTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") != "test-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(testdata.MoralisErrorResponse))
+			return
+		}
+		if got := r.URL.Query().Get("chain"); got != "0x1" {
+			t.Errorf("Chain mismatch: got %s, want 0x1", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/erc20/transfers"):
+			w.Write([]byte(testdata.MoralisERC20Response))
+		case strings.HasSuffix(r.URL.Path, "/nft/transfers"):
+			w.Write([]byte(testdata.MoralisNFTResponse))
+		case r.URL.Query().Get("cursor") == "cursor-page-2":
+			w.Write([]byte(testdata.MoralisWalletPage2Response))
+		default:
+			w.Write([]byte(testdata.MoralisWalletPage1Response))
+		}
+	}))
+}
+
+func TestMoralisClientNormalizedTransfers(t *testing.T) {
+	server := newMoralisTestServer(t)
+	defer server.Close()
+
+	client := NewMoralisClient(MoralisConfig{
+		APIKey:     "test-key",
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		RateLimit:  1,
+	})
+	fetcher := NewTransactionFetcher(client, NewEtherscanNormalizer())
+
+	txs, err := fetcher.FetchAllTransactions(context.Background(), "0xa39b189482f984388a34460636fea9eb181ad1a6", 1, 5)
+	if err != nil {
+		t.Fatalf("FetchAllTransactions() error = %v", err)
+	}
+
+	byHash := make(map[string]*models.Transaction)
+	var internal *models.Transaction
+	for _, tx := range txs {
+		if tx.Type == models.TypeInternal {
+			internal = tx
+			continue
+		}
+		byHash[tx.Hash[:4]] = tx
+	}
+
+	tests := []struct {
+		name   string
+		tx     *models.Transaction
+		typ    models.TransactionType
+		amount string
+		symbol string
+		fee    string
+	}{
+		{"eth_transfer", byHash["0x11"], models.TypeEthTransfer, "1.5", "ETH", "0.00042"},
+		{"second_cursor_page", byHash["0x22"], models.TypeEthTransfer, "0", "ETH", "0.0015"},
+		{"internal", internal, models.TypeInternal, "0.25", "ETH", ""},
+		{"erc20", byHash["0x33"], models.TypeERC20Transfer, "2500", "USDC", "0"},
+		{"erc721", byHash["0x44"], models.TypeERC721Transfer, "1", "BAYC", "0"},
+		{"erc1155", byHash["0x55"], models.TypeERC1155Transfer, "3", "LL", "0"},
+	}
+
+	if len(txs) != len(tests) {
+		t.Fatalf("Expected %d transactions, got %d", len(tests), len(txs))
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.tx == nil {
+				t.Fatal("transaction missing")
+			}
+			if tt.tx.Type != tt.typ {
+				t.Errorf("Type mismatch: got %s, want %s", tt.tx.Type, tt.typ)
+			}
+			if tt.tx.Amount != tt.amount {
+				t.Errorf("Amount mismatch: got %s, want %s", tt.tx.Amount, tt.amount)
+			}
+			if tt.tx.AssetSymbol != tt.symbol {
+				t.Errorf("Symbol mismatch: got %s, want %s", tt.tx.AssetSymbol, tt.symbol)
+			}
+			if tt.tx.GasFeeETH != tt.fee {
+				t.Errorf("Gas fee mismatch: got %s, want %s", tt.tx.GasFeeETH, tt.fee)
+			}
+			if tt.tx.Chain != "ethereum" {
+				t.Errorf("Chain mismatch: got %s, want ethereum", tt.tx.Chain)
+			}
+		})
+	}
+
+	if got := byHash["0x11"].Timestamp.Unix(); got != 1700000000 {
+		t.Errorf("Timestamp mismatch: got %d, want 1700000000", got)
+	}
+	if !byHash["0x22"].IsError {
+		t.Error("Expected receipt_status 0 to mark the transaction as failed")
+	}
+	if byHash["0x55"].TokenID != "10064" {
+		t.Errorf("Token ID mismatch: got %s, want 10064", byHash["0x55"].TokenID)
+	}
+}
+
+func TestMoralisClientStartPageFollowsCursor(t *testing.T) {
+	server := newMoralisTestServer(t)
+	defer server.Close()
+
+	client := NewMoralisClient(MoralisConfig{APIKey: "test-key", BaseURL: server.URL, HTTPClient: server.Client(), RateLimit: 1})
+
+	txs, err := client.FetchNormalTransactions(context.Background(), "0xa39b189482f984388a34460636fea9eb181ad1a6", 2, 2)
+	if err != nil {
+		t.Fatalf("FetchNormalTransactions() error = %v", err)
+	}
+	if len(txs) != 1 || !strings.HasPrefix(txs[0].Hash, "0x22") {
+		t.Errorf("Expected only the second page, got %+v", txs)
+	}
+}
+
+func TestMoralisClientAPIError(t *testing.T) {
+	server := newMoralisTestServer(t)
+	defer server.Close()
+
+	client := NewMoralisClient(MoralisConfig{APIKey: "wrong", BaseURL: server.URL, HTTPClient: server.Client(), RateLimit: 1})
+
+	_, err := client.FetchNormalTransactions(context.Background(), "0xa39b189482f984388a34460636fea9eb181ad1a6", 1, 1)
+	if err == nil || !strings.Contains(err.Error(), "Invalid key") {
+		t.Errorf("Expected the API error message, got %v", err)
+	}
+}
@@ -2,21 +2,181 @@ package providers
 
 import (
 	"conintracker-hiring/pkg/models"
-	"math"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"math/big"
 	"strconv"
+	"strings"
 	"time"
 )
 
+// MaxTokenDecimals is the largest decimals value accepted from a token contract.
+// 10^77 is the largest power of ten that fits in a uint256, so anything above it
+// cannot describe a real token amount.
+const MaxTokenDecimals = 77
+
+// FullPrecision disables rounding of formatted amounts
+const FullPrecision = -1
+
+// nativeDecimals is the number of decimals of native amounts (wei per ETH)
+const nativeDecimals = 18
+
+// DefaultNativeSymbol is the asset symbol given to native transfers unless configured otherwise
+const DefaultNativeSymbol = "ETH"
+
+// AddressCase selects how the normalizer cases From, To, and contract addresses
+type AddressCase string
+
+// Address casing modes
+const (
+	AddressCaseLower    AddressCase = "lower"    // All lowercase (default), for dedupe and joins
+	AddressCaseChecksum AddressCase = "checksum" // EIP-55 mixed-case checksum
+	AddressCaseAsIs     AddressCase = "asis"     // Whatever the provider returned
+)
+
+// ParseAddressCase validates an address casing mode name
+func ParseAddressCase(name string) (AddressCase, error) {
+	switch c := AddressCase(strings.ToLower(name)); c {
+	case AddressCaseLower, AddressCaseChecksum, AddressCaseAsIs:
+		return c, nil
+	}
+	return "", fmt.Errorf("unknown address case %q (expected lower, checksum, or asis)", name)
+}
+
 // EtherscanNormalizer implements the Normalizer interface for Etherscan responses
-type EtherscanNormalizer struct{}
+type EtherscanNormalizer struct {
+	decimalPlaces int         // Places to round amounts and gas fees to; FullPrecision keeps them as-is
+	addressCase   AddressCase // Casing applied to From, To, and AssetContractAddress
+	nativeSymbol  string      // AssetSymbol for normal, internal, and withdrawal rows
+	trimZeros     bool        // Drop trailing fractional zeros rather than padding
+	strict        bool        // Reject records with unknown token decimals rather than flag them
+}
 
 // NewEtherscanNormalizer creates a new normalizer instance
 func NewEtherscanNormalizer() *EtherscanNormalizer {
-	return &EtherscanNormalizer{}
+	return &EtherscanNormalizer{
+		decimalPlaces: FullPrecision,
+		addressCase:   AddressCaseLower,
+		nativeSymbol:  DefaultNativeSymbol,
+		trimZeros:     true,
+	}
+}
+
+// SetNativeSymbol sets the symbol of the chain's native asset (e.g. "POL" on Polygon),
+// recorded as AssetSymbol on normal, internal, and beacon withdrawal rows
+func (n *EtherscanNormalizer) SetNativeSymbol(symbol string) {
+	if symbol != "" {
+		n.nativeSymbol = symbol
+	}
+}
+
+// SetAddressCase sets how addresses are cased (AddressCaseLower by default)
+func (n *EtherscanNormalizer) SetAddressCase(c AddressCase) {
+	n.addressCase = c
+}
+
+// address applies the configured casing to an address
+func (n *EtherscanNormalizer) address(addr string) string {
+	switch n.addressCase {
+	case AddressCaseLower:
+		return strings.ToLower(addr)
+	case AddressCaseChecksum:
+		return models.ChecksumAddress(addr)
+	default:
+		return addr
+	}
+}
+
+// SetDecimalPlaces rounds amounts and gas fees to a fixed number of decimal places.
+// A negative value restores full precision.
+func (n *EtherscanNormalizer) SetDecimalPlaces(places int) {
+	if places < 0 {
+		places = FullPrecision
+	}
+	n.decimalPlaces = places
+}
+
+// SetTrimTrailingZeros controls whether amounts and gas fees are compact ("1", the default)
+// or padded with trailing zeros to the rounding places, or at full precision to the
+// asset's decimals ("1.000000" for USDC)
+func (n *EtherscanNormalizer) SetTrimTrailingZeros(enabled bool) {
+	n.trimZeros = enabled
+}
+
+// SetStrict controls how the normalizer treats records it can only export in a degraded
+// form. Lenient (the default) flags ERC-20 transfers with missing or invalid token
+// decimals and exports their raw integer amount; strict rejects them with an error.
+// Records with unparseable numbers fail in either mode.
+func (n *EtherscanNormalizer) SetStrict(enabled bool) {
+	n.strict = enabled
+}
+
+// rawField is a named raw value checked by checkNumeric
+type rawField struct {
+	name  string
+	value string
+}
+
+// checkNumeric fails when a field is present but not a non-negative integer, which
+// parsing would otherwise coerce to zero and export as a wrong amount or date.
+// Empty fields pass: providers omit some of them (e.g. gasPrice on old records).
+func checkNumeric(fields ...rawField) error {
+	for _, f := range fields {
+		if f.value == "" {
+			continue
+		}
+		if v, ok := new(big.Int).SetString(f.value, 10); !ok || v.Sign() < 0 {
+			return fmt.Errorf("unparseable %s %q", f.name, f.value)
+		}
+	}
+	return nil
+}
+
+// amount rounds a decimal string, then trims or pads its fraction per SetTrimTrailingZeros.
+// decimals is the asset's precision, used for padding at full precision.
+func (n *EtherscanNormalizer) amount(value string, decimals int) string {
+	value = n.round(value)
+	if n.trimZeros {
+		return trimTrailingZeros(value)
+	}
+	if n.decimalPlaces >= 0 {
+		decimals = n.decimalPlaces
+	}
+	return padFraction(value, decimals)
+}
+
+// trimTrailingZeros drops trailing fractional zeros and a dangling point ("1.50" -> "1.5", "2.00" -> "2")
+func trimTrailingZeros(value string) string {
+	if !strings.Contains(value, ".") {
+		return value
+	}
+	return strings.TrimSuffix(strings.TrimRight(value, "0"), ".")
+}
+
+// padFraction appends zeros until value has places fractional digits
+func padFraction(value string, places int) string {
+	whole, frac, _ := strings.Cut(value, ".")
+	if places <= 0 || len(frac) >= places {
+		return value
+	}
+	return whole + "." + frac + strings.Repeat("0", places-len(frac))
+}
+
+// round formats a decimal string to the configured number of places
+func (n *EtherscanNormalizer) round(value string) string {
+	if n.decimalPlaces < 0 {
+		return value
+	}
+
+	r, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return value
+	}
+	return r.FloatString(n.decimalPlaces)
 }
 
-// weiToETH converts wei (big.Int) to ETH with proper decimal formatting
+// weiToETH converts wei to ETH exactly, without trailing zeros
 func weiToETH(weiStr string) string {
 	if weiStr == "" || weiStr == "0" {
 		return "0"
@@ -30,8 +190,7 @@ func weiToETH(weiStr string) string {
 	eth := new(big.Rat).SetInt(wei)
 	eth.Quo(eth, new(big.Rat).SetInt(divisor))
 
-	f, _ := eth.Float64()
-	return strconv.FormatFloat(f, 'f', -1, 64)
+	return trimTrailingZeros(eth.FloatString(nativeDecimals))
 }
 
 // parseUint64 safely parses a string to uint64
@@ -46,13 +205,19 @@ func parseTimestamp(timestampStr string) time.Time {
 	return time.Unix(ts, 0)
 }
 
-// calculateGasFeeETH calculates gas fee in ETH (gasUsed * gasPrice / 1e18)
+// calculateGasFeeETH calculates gas fee in ETH (gasUsed * gasPrice / 1e18).
+// Returns "0" when either input is missing or unparseable, e.g. internal rows
+// or historical transactions without a gasPrice.
 func calculateGasFeeETH(gasUsedStr, gasPriceStr string) string {
-	gasUsed := new(big.Int)
-	gasUsed.SetString(gasUsedStr, 10)
+	gasUsed, ok := new(big.Int).SetString(gasUsedStr, 10)
+	if !ok {
+		return "0"
+	}
 
-	gasPrice := new(big.Int)
-	gasPrice.SetString(gasPriceStr, 10)
+	gasPrice, ok := new(big.Int).SetString(gasPriceStr, 10)
+	if !ok {
+		return "0"
+	}
 
 	// totalFeeWei = gasUsed * gasPrice
 	totalFeeWei := new(big.Int)
@@ -63,8 +228,7 @@ func calculateGasFeeETH(gasUsedStr, gasPriceStr string) string {
 	fee := new(big.Rat).SetInt(totalFeeWei)
 	fee.Quo(fee, new(big.Rat).SetInt(divisor))
 
-	f, _ := fee.Float64()
-	return strconv.FormatFloat(f, 'f', -1, 64)
+	return trimTrailingZeros(fee.FloatString(nativeDecimals))
 }
 
 // adjustForDecimals scales a token value based on its decimal places
@@ -81,95 +245,171 @@ func adjustForDecimals(valueStr string, decimals int) string {
 		return val.String()
 	}
 
-	divisor := big.NewInt(int64(math.Pow(10, float64(decimals))))
+	divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
 	result := new(big.Rat).SetInt(val)
 	result.Quo(result, new(big.Rat).SetInt(divisor))
 
-	f, _ := result.Float64()
-	return strconv.FormatFloat(f, 'f', -1, 64)
+	// Dividing by 10^decimals leaves at most decimals fractional digits, so this is exact
+	return trimTrailingZeros(result.FloatString(decimals))
+}
+
+// errorReason describes a failed transaction: the provider's error code (e.g. "Out of gas")
+// when it reports one, otherwise "Failed". Successful transactions have no reason.
+func errorReason(failed bool, code string) string {
+	if code = strings.TrimSpace(code); code != "" {
+		return code
+	}
+	if failed {
+		return "Failed"
+	}
+	return ""
+}
+
+// transferSubtype labels token transfers from the zero address as mints and
+// transfers to the zero (or an empty) address as burns
+func transferSubtype(from, to string) string {
+	if strings.EqualFold(from, models.ZeroAddress) {
+		return models.SubtypeMint
+	}
+	if to == "" || strings.EqualFold(to, models.ZeroAddress) {
+		return models.SubtypeBurn
+	}
+	return ""
 }
 
 // NormalizeNormalTx implements Normalizer interface for normal ETH transfers
 func (n *EtherscanNormalizer) NormalizeNormalTx(tx EtherscanNormalTx) (*models.Transaction, error) {
+	if err := checkNumeric(rawField{"timeStamp", tx.TimeStamp}, rawField{"blockNumber", tx.BlockNumber}, rawField{"value", tx.Value}, rawField{"gasUsed", tx.GasUsed}, rawField{"gasPrice", tx.GasPrice}); err != nil {
+		return nil, err
+	}
+
 	isError := tx.IsError == "1"
 	blockNum := parseUint64(tx.BlockNumber)
 
 	return &models.Transaction{
-		Hash:      tx.Hash,
-		Timestamp: parseTimestamp(tx.TimeStamp),
-		From:      tx.From,
-		To:        tx.To,
-		Type:      models.TypeEthTransfer,
-		Amount:    weiToETH(tx.Value),
-		GasFeeETH: calculateGasFeeETH(tx.GasUsed, tx.GasPrice),
-		BlockNumber: blockNum,
-		GasUsed:     parseUint64(tx.GasUsed),
-		GasPrice:    tx.GasPrice,
+		Hash:           tx.Hash,
+		Timestamp:      parseTimestamp(tx.TimeStamp),
+		From:           n.address(tx.From),
+		To:             n.address(tx.To),
+		Type:           models.TypeEthTransfer,
+		AssetSymbol:    n.nativeSymbol,
+		Amount:         n.amount(weiToETH(tx.Value), nativeDecimals),
+		RawAmount:      tx.Value,
+		Decimals:       nativeDecimals,
+		GasFeeETH:      n.amount(calculateGasFeeETH(tx.GasUsed, tx.GasPrice), nativeDecimals),
+		BlockNumber:    blockNum,
+		GasUsed:        parseUint64(tx.GasUsed),
+		GasPrice:       tx.GasPrice,
+		Nonce:          parseUint64(tx.Nonce),
+		Confirmations:  parseUint64(tx.Confirmations),
 		TransactionFee: tx.GasUsed, // This is calculated later
-		IsError:     isError,
-		Input:       tx.Input,
-		MethodID:    tx.MethodId,
-		FunctionName: tx.FunctionName,
+		IsError:        isError,
+		ErrorReason:    errorReason(isError || tx.TxReceiptStatus == "0", ""),
+		Input:          tx.Input,
+		MethodID:       tx.MethodId,
+		FunctionName:   tx.FunctionName,
 	}, nil
 }
 
 // NormalizeInternalTx implements Normalizer interface for internal transfers
 func (n *EtherscanNormalizer) NormalizeInternalTx(tx EtherscanInternalTx) (*models.Transaction, error) {
+	if err := checkNumeric(rawField{"timeStamp", tx.TimeStamp}, rawField{"blockNumber", tx.BlockNumber}, rawField{"value", tx.Value}); err != nil {
+		return nil, err
+	}
+
 	isError := tx.IsError == "1"
 	blockNum := parseUint64(tx.BlockNumber)
 
 	return &models.Transaction{
-		Hash:      tx.Hash,
-		Timestamp: parseTimestamp(tx.TimeStamp),
-		From:      tx.From,
-		To:        tx.To,
-		Type:      models.TypeInternal,
-		Amount:    weiToETH(tx.Value),
+		Hash:        tx.Hash,
+		Timestamp:   parseTimestamp(tx.TimeStamp),
+		From:        n.address(tx.From),
+		To:          n.address(tx.To),
+		Type:        models.TypeInternal,
+		AssetSymbol: n.nativeSymbol,
+		Amount:      n.amount(weiToETH(tx.Value), nativeDecimals),
+		RawAmount:   tx.Value,
+		Decimals:    nativeDecimals,
 		BlockNumber: blockNum,
 		GasUsed:     parseUint64(tx.GasUsed),
 		IsError:     isError,
 		Input:       tx.Input,
+		ErrorReason: errorReason(isError, tx.ErrCode),
+		TraceID:     tx.TraceId,
 	}, nil
 }
 
 // NormalizeERC20Tx implements Normalizer interface for ERC-20 token transfers
 func (n *EtherscanNormalizer) NormalizeERC20Tx(tx EtherscanTokenTx) (*models.Transaction, error) {
-	decimals, _ := strconv.Atoi(tx.TokenDecimal)
+	if err := checkNumeric(rawField{"timeStamp", tx.TimeStamp}, rawField{"blockNumber", tx.BlockNumber}, rawField{"value", tx.Value}, rawField{"gasUsed", tx.GasUsed}, rawField{"gasPrice", tx.GasPrice}); err != nil {
+		return nil, err
+	}
+
+	// Guessing decimals would misstate the amount by orders of magnitude, so a token
+	// without valid decimals keeps its raw integer amount and is flagged instead
+	decimals, known := parseTokenDecimals(tx.TokenDecimal)
+	if !known && n.strict {
+		return nil, fmt.Errorf("malformed token decimals %q", tx.TokenDecimal)
+	}
 
 	return &models.Transaction{
 		Hash:                 tx.Hash,
 		Timestamp:            parseTimestamp(tx.TimeStamp),
-		From:                 tx.From,
-		To:                   tx.To,
+		From:                 n.address(tx.From),
+		To:                   n.address(tx.To),
 		Type:                 models.TypeERC20Transfer,
-		AssetContractAddress: tx.ContractAddress,
+		Subtype:              transferSubtype(tx.From, tx.To),
+		AssetContractAddress: n.address(tx.ContractAddress),
 		AssetSymbol:          tx.TokenSymbol,
-		Amount:               adjustForDecimals(tx.Value, decimals),
-		GasFeeETH:            calculateGasFeeETH(tx.GasUsed, tx.GasPrice),
+		AssetName:            tx.TokenName,
+		Amount:               n.amount(adjustForDecimals(tx.Value, decimals), decimals),
+		RawAmount:            tx.Value,
+		GasFeeETH:            n.amount(calculateGasFeeETH(tx.GasUsed, tx.GasPrice), nativeDecimals),
 		BlockNumber:          parseUint64(tx.BlockNumber),
 		GasUsed:              parseUint64(tx.GasUsed),
 		GasPrice:             tx.GasPrice,
+		Confirmations:        parseUint64(tx.Confirmations),
 		IsError:              tx.IsError == "1",
 		Decimals:             decimals,
+		UnknownDecimals:      !known,
 	}, nil
 }
 
+// parseTokenDecimals parses a token's reported decimals, which must be an integer
+// in [0, MaxTokenDecimals]. Missing, non-numeric, or out-of-range values return
+// 0 and false.
+func parseTokenDecimals(s string) (int, bool) {
+	decimals, err := strconv.Atoi(s)
+	if err != nil || decimals < 0 || decimals > MaxTokenDecimals {
+		return 0, false
+	}
+	return decimals, true
+}
+
 // NormalizeERC721Tx implements Normalizer interface for ERC-721 NFT transfers
 func (n *EtherscanNormalizer) NormalizeERC721Tx(tx EtherscanTokenTx) (*models.Transaction, error) {
+	if err := checkNumeric(rawField{"timeStamp", tx.TimeStamp}, rawField{"blockNumber", tx.BlockNumber}, rawField{"gasUsed", tx.GasUsed}, rawField{"gasPrice", tx.GasPrice}); err != nil {
+		return nil, err
+	}
+
 	return &models.Transaction{
 		Hash:                 tx.Hash,
 		Timestamp:            parseTimestamp(tx.TimeStamp),
-		From:                 tx.From,
-		To:                   tx.To,
+		From:                 n.address(tx.From),
+		To:                   n.address(tx.To),
 		Type:                 models.TypeERC721Transfer,
-		AssetContractAddress: tx.ContractAddress,
+		Subtype:              transferSubtype(tx.From, tx.To),
+		AssetContractAddress: n.address(tx.ContractAddress),
 		AssetSymbol:          tx.TokenSymbol,
+		AssetName:            tx.TokenName,
 		TokenID:              tx.TokenID,
 		Amount:               "1", // NFTs are always 1
-		GasFeeETH:            calculateGasFeeETH(tx.GasUsed, tx.GasPrice),
+		Decimals:             0,   // NFTs are indivisible
+		GasFeeETH:            n.amount(calculateGasFeeETH(tx.GasUsed, tx.GasPrice), nativeDecimals),
 		BlockNumber:          parseUint64(tx.BlockNumber),
 		GasUsed:              parseUint64(tx.GasUsed),
 		GasPrice:             tx.GasPrice,
+		Confirmations:        parseUint64(tx.Confirmations),
 		IsError:              tx.IsError == "1",
 	}, nil
 }
@@ -181,21 +421,124 @@ func (n *EtherscanNormalizer) NormalizeERC1155Tx(tx EtherscanTokenTx) (*models.T
 	if amount == "" {
 		amount = tx.Value
 	}
+	if err := checkNumeric(rawField{"timeStamp", tx.TimeStamp}, rawField{"blockNumber", tx.BlockNumber}, rawField{"tokenValue", amount}, rawField{"gasUsed", tx.GasUsed}, rawField{"gasPrice", tx.GasPrice}); err != nil {
+		return nil, err
+	}
 
 	return &models.Transaction{
 		Hash:                 tx.Hash,
 		Timestamp:            parseTimestamp(tx.TimeStamp),
-		From:                 tx.From,
-		To:                   tx.To,
+		From:                 n.address(tx.From),
+		To:                   n.address(tx.To),
 		Type:                 models.TypeERC1155Transfer,
-		AssetContractAddress: tx.ContractAddress,
+		Subtype:              transferSubtype(tx.From, tx.To),
+		AssetContractAddress: n.address(tx.ContractAddress),
 		AssetSymbol:          tx.TokenSymbol,
+		AssetName:            tx.TokenName,
 		TokenID:              tx.TokenID,
 		Amount:               amount,
-		GasFeeETH:            calculateGasFeeETH(tx.GasUsed, tx.GasPrice),
+		Decimals:             0, // Counts of indivisible tokens
+		GasFeeETH:            n.amount(calculateGasFeeETH(tx.GasUsed, tx.GasPrice), nativeDecimals),
 		BlockNumber:          parseUint64(tx.BlockNumber),
 		GasUsed:              parseUint64(tx.GasUsed),
 		GasPrice:             tx.GasPrice,
+		Confirmations:        parseUint64(tx.Confirmations),
 		IsError:              tx.IsError == "1",
 	}, nil
 }
+
+// NormalizeWithdrawalTx implements Normalizer interface for beacon chain withdrawals.
+// Withdrawals have no transaction hash or sender and carry their amount in Gwei.
+func (n *EtherscanNormalizer) NormalizeWithdrawalTx(tx EtherscanWithdrawalTx) (*models.Transaction, error) {
+	if err := checkNumeric(rawField{"timestamp", tx.Timestamp}, rawField{"blockNumber", tx.BlockNumber}, rawField{"amount", tx.Amount}); err != nil {
+		return nil, err
+	}
+
+	return &models.Transaction{
+		Timestamp:       parseTimestamp(tx.Timestamp),
+		To:              n.address(tx.Address),
+		Type:            models.TypeBeaconWithdrawal,
+		AssetSymbol:     n.nativeSymbol,
+		Amount:          n.amount(adjustForDecimals(tx.Amount, 9), nativeDecimals),
+		RawAmount:       tx.Amount,
+		Decimals:        nativeDecimals,
+		GasFeeETH:       n.amount("0", nativeDecimals),
+		BlockNumber:     parseUint64(tx.BlockNumber),
+		WithdrawalIndex: tx.WithdrawalIndex,
+	}, nil
+}
+
+// ErrUnsupportedRawType is returned by NormalizeAny for a value that is not a raw record
+var ErrUnsupportedRawType = errors.New("unsupported raw transaction type")
+
+// NormalizeAny normalizes a single raw record of any type, sparing callers the
+// switch over the NormalizeXxx methods. Typed records (EtherscanNormalTx,
+// EtherscanInternalTx, EtherscanTokenTx, EtherscanWithdrawalTx, or pointers to
+// them) dispatch on their struct type. Since ERC-20, ERC-721 and ERC-1155
+// transfers share EtherscanTokenTx, a token hint picks among them; any other hint
+// infers the standard from TokenValue (ERC-1155) and TokenID (ERC-721). Raw JSON
+// ([]byte, json.RawMessage or string) is decoded as the record type of hint.
+func (n *EtherscanNormalizer) NormalizeAny(raw any, hint TransactionType) (*models.Transaction, error) {
+	switch tx := raw.(type) {
+	case EtherscanNormalTx:
+		return n.NormalizeNormalTx(tx)
+	case *EtherscanNormalTx:
+		return n.NormalizeNormalTx(*tx)
+	case EtherscanInternalTx:
+		return n.NormalizeInternalTx(tx)
+	case *EtherscanInternalTx:
+		return n.NormalizeInternalTx(*tx)
+	case EtherscanTokenTx:
+		return n.normalizeTokenTx(tx, hint)
+	case *EtherscanTokenTx:
+		return n.normalizeTokenTx(*tx, hint)
+	case EtherscanWithdrawalTx:
+		return n.NormalizeWithdrawalTx(tx)
+	case *EtherscanWithdrawalTx:
+		return n.NormalizeWithdrawalTx(*tx)
+	case json.RawMessage:
+		return n.normalizeJSON(tx, hint)
+	case []byte:
+		return n.normalizeJSON(tx, hint)
+	case string:
+		return n.normalizeJSON([]byte(tx), hint)
+	}
+	return nil, fmt.Errorf("%w: %T", ErrUnsupportedRawType, raw)
+}
+
+// normalizeTokenTx normalizes a token transfer as the token type hint names, or
+// as the standard its fields indicate when hint is not a token type
+func (n *EtherscanNormalizer) normalizeTokenTx(tx EtherscanTokenTx, hint TransactionType) (*models.Transaction, error) {
+	switch {
+	case hint == TxTypeToken:
+		return n.NormalizeERC20Tx(tx)
+	case hint == TxTypeNFT:
+		return n.NormalizeERC721Tx(tx)
+	case hint == TxTypeERC1155, tx.TokenValue != "":
+		return n.NormalizeERC1155Tx(tx)
+	case tx.TokenID != "":
+		return n.NormalizeERC721Tx(tx)
+	}
+	return n.NormalizeERC20Tx(tx)
+}
+
+// normalizeJSON decodes data as the record type of hint and normalizes it
+func (n *EtherscanNormalizer) normalizeJSON(data []byte, hint TransactionType) (*models.Transaction, error) {
+	var raw any
+	switch hint {
+	case TxTypeNormal:
+		raw = &EtherscanNormalTx{}
+	case TxTypeInternal:
+		raw = &EtherscanInternalTx{}
+	case TxTypeToken, TxTypeNFT, TxTypeERC1155:
+		raw = &EtherscanTokenTx{}
+	case TxTypeWithdrawal:
+		raw = &EtherscanWithdrawalTx{}
+	default:
+		return nil, fmt.Errorf("%w: JSON with unknown hint %d", ErrUnsupportedRawType, hint)
+	}
+	if err := json.Unmarshal(data, raw); err != nil {
+		return nil, fmt.Errorf("failed to decode %s record: %w", hint.Name(), err)
+	}
+	return n.NormalizeAny(raw, hint)
+}
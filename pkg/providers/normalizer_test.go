@@ -2,6 +2,8 @@ package providers
 
 import (
 	"conintracker-hiring/pkg/models"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 )
@@ -33,7 +35,7 @@ func TestNormalizeNormalTx(t *testing.T) {
 				Hash:      "0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef",
 				Timestamp: time.Unix(1700000000, 0),
 				From:      "0xa39b189482f984388a34460636fea9eb181ad1a6",
-				To:        "0xd620AADaBaA20d2af700853C4504028cba7C3333",
+				To:        "0xd620aadabaa20d2af700853c4504028cba7c3333",
 				Type:      models.TypeEthTransfer,
 				Amount:    "1",
 				GasFeeETH: "0.00105",
@@ -132,8 +134,10 @@ func TestNormalizeInternalTx(t *testing.T) {
 				ContractAddress: "0x3333333354fb6c44bac0bed2854e76f90643097d",
 				GasUsed:         "40000",
 				IsError:         "0",
+				TraceId:         "0_1",
 			},
 			want: &models.Transaction{
+				TraceID:   "0_1",
 				Hash:      "0x9999999999999999999999999999999999999999999999999999999999999999",
 				Timestamp: time.Unix(1699999980, 0),
 				From:      "0xa39b189482f984388a34460636fea9eb181ad1a6",
@@ -166,6 +170,9 @@ func TestNormalizeInternalTx(t *testing.T) {
 				if got.Amount != tt.want.Amount {
 					t.Errorf("Amount mismatch: got %s, want %s", got.Amount, tt.want.Amount)
 				}
+				if got.TraceID != tt.want.TraceID {
+					t.Errorf("TraceID mismatch: got %s, want %s", got.TraceID, tt.want.TraceID)
+				}
 			}
 		})
 	}
@@ -202,10 +209,11 @@ func TestNormalizeERC20Tx(t *testing.T) {
 				Hash:                 "0x8888888888888888888888888888888888888888888888888888888888888888",
 				Timestamp:            time.Unix(1699999970, 0),
 				From:                 "0xa39b189482f984388a34460636fea9eb181ad1a6",
-				To:                   "0xd620AADaBaA20d2af700853C4504028cba7C3333",
+				To:                   "0xd620aadabaa20d2af700853c4504028cba7c3333",
 				Type:                 models.TypeERC20Transfer,
 				AssetContractAddress: "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48",
 				AssetSymbol:          "USDC",
+				AssetName:            "USD Coin",
 				Amount:               "1000.0",
 				GasFeeETH:            "0.0044",
 				BlockNumber:          19999997,
@@ -233,6 +241,111 @@ func TestNormalizeERC20Tx(t *testing.T) {
 				if got.AssetSymbol != tt.want.AssetSymbol {
 					t.Errorf("AssetSymbol mismatch: got %s, want %s", got.AssetSymbol, tt.want.AssetSymbol)
 				}
+				if got.AssetName != tt.want.AssetName {
+					t.Errorf("AssetName mismatch: got %s, want %s", got.AssetName, tt.want.AssetName)
+				}
+			}
+		})
+	}
+}
+
+func TestNormalizeERC20TxUnknownDecimals(t *testing.T) {
+	normalizer := NewEtherscanNormalizer()
+
+	tests := []struct {
+		name         string
+		tokenDecimal string
+		wantAmount   string
+		wantUnknown  bool
+	}{
+		{name: "valid", tokenDecimal: "6", wantAmount: "0.001"},
+		{name: "zero", tokenDecimal: "0", wantAmount: "1000"},
+		{name: "negative", tokenDecimal: "-1", wantAmount: "1000", wantUnknown: true},
+		{name: "non_numeric", tokenDecimal: "abc", wantAmount: "1000", wantUnknown: true},
+		{name: "out_of_range", tokenDecimal: "100", wantAmount: "1000", wantUnknown: true},
+		{name: "uint8_max", tokenDecimal: "255", wantAmount: "1000", wantUnknown: true},
+		{name: "missing", tokenDecimal: "", wantAmount: "1000", wantUnknown: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizer.NormalizeERC20Tx(EtherscanTokenTx{Hash: "0x9", Value: "1000", TokenSymbol: "BAD", TokenDecimal: tt.tokenDecimal})
+			if err != nil {
+				t.Fatalf("NormalizeERC20Tx() error = %v", err)
+			}
+			if got.Amount != tt.wantAmount {
+				t.Errorf("Amount mismatch: got %s, want %s", got.Amount, tt.wantAmount)
+			}
+			if got.UnknownDecimals != tt.wantUnknown {
+				t.Errorf("UnknownDecimals mismatch: got %v, want %v", got.UnknownDecimals, tt.wantUnknown)
+			}
+			if tt.wantUnknown && got.Decimals != 0 {
+				t.Errorf("Decimals mismatch: got %d, want 0", got.Decimals)
+			}
+		})
+	}
+}
+
+func TestNormalizerStrictness(t *testing.T) {
+	unknownDecimals := EtherscanTokenTx{Hash: "0x9", Value: "1000", TokenSymbol: "BAD", TokenDecimal: "abc"}
+	badValue := EtherscanNormalTx{Hash: "0x8", TimeStamp: "1700000000", Value: "1.5 ETH"}
+
+	for _, strict := range []bool{false, true} {
+		normalizer := NewEtherscanNormalizer()
+		normalizer.SetStrict(strict)
+
+		// Unknown decimals are flagged when lenient and rejected when strict
+		if _, err := normalizer.NormalizeERC20Tx(unknownDecimals); (err != nil) != strict {
+			t.Errorf("strict=%v: NormalizeERC20Tx() error = %v", strict, err)
+		}
+		// Unparseable numbers are rejected either way rather than exported as 0
+		if _, err := normalizer.NormalizeNormalTx(badValue); err == nil || !strings.Contains(err.Error(), `unparseable value "1.5 ETH"`) {
+			t.Errorf("strict=%v: NormalizeNormalTx() error = %v, want unparseable value", strict, err)
+		}
+	}
+}
+
+func TestCalculateGasFeeETH(t *testing.T) {
+	tests := []struct {
+		name     string
+		gasUsed  string
+		gasPrice string
+		want     string
+	}{
+		{name: "standard_transfer", gasUsed: "21000", gasPrice: "20000000000", want: "0.00042"},
+		{name: "empty_gas_price", gasUsed: "21000", gasPrice: "", want: "0"},
+		{name: "empty_gas_used", gasUsed: "", gasPrice: "20000000000", want: "0"},
+		{name: "both_empty", gasUsed: "", gasPrice: "", want: "0"},
+		{name: "unparseable_gas_price", gasUsed: "21000", gasPrice: "n/a", want: "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := calculateGasFeeETH(tt.gasUsed, tt.gasPrice); got != tt.want {
+				t.Errorf("calculateGasFeeETH(%q, %q) = %s, want %s", tt.gasUsed, tt.gasPrice, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdjustForDecimalsLargeDecimals(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		decimals int
+		want     string
+	}{
+		{name: "24_decimals_whole", value: "1000000000000000000000000", decimals: 24, want: "1"},
+		{name: "24_decimals_fraction", value: "2500000000000000000000000", decimals: 24, want: "2.5"},
+		{name: "24_decimals_non_round", value: "1234567890123456789012345678", decimals: 24, want: "1234.567890123456789012345678"},
+		{name: "24_decimals_dust", value: "1", decimals: 24, want: "0.000000000000000000000001"},
+		{name: "max_decimals", value: "1" + strings.Repeat("0", MaxTokenDecimals), decimals: MaxTokenDecimals, want: "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := adjustForDecimals(tt.value, tt.decimals); got != tt.want {
+				t.Errorf("adjustForDecimals() = %s, want %s", got, tt.want)
 			}
 		})
 	}
@@ -269,7 +382,7 @@ func TestNormalizeERC721Tx(t *testing.T) {
 				Hash:                 "0x6666666666666666666666666666666666666666666666666666666666666666",
 				Timestamp:            time.Unix(1699999950, 0),
 				From:                 "0xa39b189482f984388a34460636fea9eb181ad1a6",
-				To:                   "0xd620AADaBaA20d2af700853C4504028cba7C3333",
+				To:                   "0xd620aadabaa20d2af700853c4504028cba7c3333",
 				Type:                 models.TypeERC721Transfer,
 				AssetContractAddress: "0xbc4ca0eda7647a8ab7c2061c2e2ad183",
 				AssetSymbol:          "BAYC",
@@ -338,7 +451,7 @@ func TestNormalizeERC1155Tx(t *testing.T) {
 				Hash:                 "0x5555555555555555555555555555555555555555555555555555555555555555",
 				Timestamp:            time.Unix(1699999940, 0),
 				From:                 "0xa39b189482f984388a34460636fea9eb181ad1a6",
-				To:                   "0xd620AADaBaA20d2af700853C4504028cba7C3333",
+				To:                   "0xd620aadabaa20d2af700853c4504028cba7c3333",
 				Type:                 models.TypeERC1155Transfer,
 				AssetContractAddress: "0x76be3b62873462d2142405439777e053",
 				AssetSymbol:          "POLY",
@@ -371,3 +484,437 @@ func TestNormalizeERC1155Tx(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizeWithdrawalTx(t *testing.T) {
+	normalizer := NewEtherscanNormalizer()
+
+	tests := []struct {
+		name       string
+		tx         EtherscanWithdrawalTx
+		wantAmount string
+	}{
+		{
+			name: "partial_withdrawal",
+			tx: EtherscanWithdrawalTx{
+				WithdrawalIndex: "10435271",
+				ValidatorIndex:  "482913",
+				Address:         "0xa39b189482f984388a34460636fea9eb181ad1a6",
+				Amount:          "18234567",
+				BlockNumber:     "19999995",
+				Timestamp:       "1699999950",
+			},
+			wantAmount: "0.018234567",
+		},
+		{
+			name: "full_withdrawal",
+			tx: EtherscanWithdrawalTx{
+				Address:     "0xa39b189482f984388a34460636fea9eb181ad1a6",
+				Amount:      "32000000000",
+				BlockNumber: "19999996",
+				Timestamp:   "1699999962",
+			},
+			wantAmount: "32",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizer.NormalizeWithdrawalTx(tt.tx)
+			if err != nil {
+				t.Fatalf("NormalizeWithdrawalTx() error = %v", err)
+			}
+
+			if got.Type != models.TypeBeaconWithdrawal {
+				t.Errorf("Type mismatch: got %s, want %s", got.Type, models.TypeBeaconWithdrawal)
+			}
+			if got.Amount != tt.wantAmount {
+				t.Errorf("Amount mismatch: got %s, want %s", got.Amount, tt.wantAmount)
+			}
+			if got.To != tt.tx.Address {
+				t.Errorf("To mismatch: got %s, want %s", got.To, tt.tx.Address)
+			}
+			if got.From != "" {
+				t.Errorf("Expected empty From for withdrawal, got %s", got.From)
+			}
+			if got.GasFeeETH != "0" {
+				t.Errorf("GasFeeETH mismatch: got %s, want 0", got.GasFeeETH)
+			}
+		})
+	}
+}
+
+func TestNormalizerDecimalPlaces(t *testing.T) {
+	raw := EtherscanTokenTx{
+		Hash:         "0x1",
+		Value:        "1234567800",
+		TokenSymbol:  "USDC",
+		TokenDecimal: "6",
+		GasUsed:      "21000",
+		GasPrice:     "20000000000",
+	}
+
+	tests := []struct {
+		name       string
+		places     int
+		wantAmount string
+		wantGasFee string
+	}{
+		{name: "full_precision_default", places: FullPrecision, wantAmount: "1234.5678", wantGasFee: "0.00042"},
+		{name: "two_places", places: 2, wantAmount: "1234.57", wantGasFee: "0"},
+		{name: "five_places", places: 5, wantAmount: "1234.5678", wantGasFee: "0.00042"},
+		{name: "negative_means_full_precision", places: -7, wantAmount: "1234.5678", wantGasFee: "0.00042"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			normalizer := NewEtherscanNormalizer()
+			normalizer.SetDecimalPlaces(tt.places)
+
+			got, err := normalizer.NormalizeERC20Tx(raw)
+			if err != nil {
+				t.Fatalf("NormalizeERC20Tx() error = %v", err)
+			}
+			if got.Amount != tt.wantAmount {
+				t.Errorf("Amount mismatch: got %s, want %s", got.Amount, tt.wantAmount)
+			}
+			if got.GasFeeETH != tt.wantGasFee {
+				t.Errorf("GasFeeETH mismatch: got %s, want %s", got.GasFeeETH, tt.wantGasFee)
+			}
+		})
+	}
+}
+
+func TestNormalizerTrimTrailingZeros(t *testing.T) {
+	eth := EtherscanNormalTx{Hash: "0x1", Value: "1000000000000000000", GasUsed: "21000", GasPrice: "20000000000"}
+	usdc := EtherscanTokenTx{Hash: "0x2", Value: "1500000", TokenSymbol: "USDC", TokenDecimal: "6"}
+
+	tests := []struct {
+		name       string
+		trim       bool
+		places     int
+		wantETH    string
+		wantGasFee string
+		wantUSDC   string
+	}{
+		{name: "compact_full_precision", trim: true, places: FullPrecision, wantETH: "1", wantGasFee: "0.00042", wantUSDC: "1.5"},
+		{name: "compact_rounded", trim: true, places: 4, wantETH: "1", wantGasFee: "0.0004", wantUSDC: "1.5"},
+		{name: "padded_full_precision", trim: false, places: FullPrecision, wantETH: "1.000000000000000000", wantGasFee: "0.000420000000000000", wantUSDC: "1.500000"},
+		{name: "padded_rounded", trim: false, places: 4, wantETH: "1.0000", wantGasFee: "0.0004", wantUSDC: "1.5000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			normalizer := NewEtherscanNormalizer()
+			normalizer.SetTrimTrailingZeros(tt.trim)
+			normalizer.SetDecimalPlaces(tt.places)
+
+			ethTx, err := normalizer.NormalizeNormalTx(eth)
+			if err != nil {
+				t.Fatalf("NormalizeNormalTx() error = %v", err)
+			}
+			usdcTx, err := normalizer.NormalizeERC20Tx(usdc)
+			if err != nil {
+				t.Fatalf("NormalizeERC20Tx() error = %v", err)
+			}
+
+			if ethTx.Amount != tt.wantETH {
+				t.Errorf("ETH amount mismatch: got %s, want %s", ethTx.Amount, tt.wantETH)
+			}
+			if ethTx.GasFeeETH != tt.wantGasFee {
+				t.Errorf("GasFeeETH mismatch: got %s, want %s", ethTx.GasFeeETH, tt.wantGasFee)
+			}
+			if usdcTx.Amount != tt.wantUSDC {
+				t.Errorf("USDC amount mismatch: got %s, want %s", usdcTx.Amount, tt.wantUSDC)
+			}
+		})
+	}
+}
+
+func TestNormalizerErrorReason(t *testing.T) {
+	normalizer := NewEtherscanNormalizer()
+
+	tests := []struct {
+		name      string
+		normalize func() (*models.Transaction, error)
+		want      string
+	}{
+		{
+			name: "reverted_internal_with_code",
+			normalize: func() (*models.Transaction, error) {
+				return normalizer.NormalizeInternalTx(EtherscanInternalTx{Hash: "0x1", IsError: "1", ErrCode: "Out of gas"})
+			},
+			want: "Out of gas",
+		},
+		{
+			name: "failed_internal_without_code",
+			normalize: func() (*models.Transaction, error) {
+				return normalizer.NormalizeInternalTx(EtherscanInternalTx{Hash: "0x2", IsError: "1"})
+			},
+			want: "Failed",
+		},
+		{
+			name: "failed_normal",
+			normalize: func() (*models.Transaction, error) {
+				return normalizer.NormalizeNormalTx(EtherscanNormalTx{Hash: "0x3", IsError: "1", TxReceiptStatus: "0"})
+			},
+			want: "Failed",
+		},
+		{
+			name: "normal_receipt_status_zero",
+			normalize: func() (*models.Transaction, error) {
+				return normalizer.NormalizeNormalTx(EtherscanNormalTx{Hash: "0x4", IsError: "0", TxReceiptStatus: "0"})
+			},
+			want: "Failed",
+		},
+		{
+			name: "successful_normal",
+			normalize: func() (*models.Transaction, error) {
+				return normalizer.NormalizeNormalTx(EtherscanNormalTx{Hash: "0x5", IsError: "0", TxReceiptStatus: "1"})
+			},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.normalize()
+			if err != nil {
+				t.Fatalf("normalize error = %v", err)
+			}
+			if got.ErrorReason != tt.want {
+				t.Errorf("ErrorReason mismatch: got %q, want %q", got.ErrorReason, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizerDecimalPlacesKeepsNFTQuantity(t *testing.T) {
+	normalizer := NewEtherscanNormalizer()
+	normalizer.SetDecimalPlaces(2)
+
+	got, _ := normalizer.NormalizeERC721Tx(EtherscanTokenTx{Hash: "0x1", TokenID: "7"})
+	if got.Amount != "1" {
+		t.Errorf("Amount mismatch: got %s, want 1", got.Amount)
+	}
+}
+
+func TestTokenTransferSubtypes(t *testing.T) {
+	normalizer := NewEtherscanNormalizer()
+	owner := "0xa39b189482f984388a34460636fea9eb181ad1a6"
+
+	tests := []struct {
+		name        string
+		from        string
+		to          string
+		wantSubtype string
+	}{
+		{name: "mint_from_zero_address", from: models.ZeroAddress, to: owner, wantSubtype: models.SubtypeMint},
+		{name: "burn_to_zero_address", from: owner, to: models.ZeroAddress, wantSubtype: models.SubtypeBurn},
+		{name: "burn_to_empty_address", from: owner, to: "", wantSubtype: models.SubtypeBurn},
+		{name: "regular_transfer", from: owner, to: "0xd620AADaBaA20d2af700853C4504028cba7C3333", wantSubtype: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := EtherscanTokenTx{
+				Hash:         "0x1",
+				From:         tt.from,
+				To:           tt.to,
+				Value:        "1000000",
+				TokenSymbol:  "USDC",
+				TokenDecimal: "6",
+				TokenID:      "1",
+			}
+
+			erc20, _ := normalizer.NormalizeERC20Tx(raw)
+			erc721, _ := normalizer.NormalizeERC721Tx(raw)
+			erc1155, _ := normalizer.NormalizeERC1155Tx(raw)
+
+			for _, got := range []*models.Transaction{erc20, erc721, erc1155} {
+				if got.Subtype != tt.wantSubtype {
+					t.Errorf("%s Subtype = %q, want %q", got.Type, got.Subtype, tt.wantSubtype)
+				}
+			}
+		})
+	}
+}
+
+func TestNormalizerAddressCase(t *testing.T) {
+	raw := EtherscanTokenTx{
+		Hash:            "0xabc",
+		TimeStamp:       "1700000000",
+		From:            "0xa39b189482f984388a34460636fea9eb181ad1a6",
+		To:              "0xD620AADABAA20D2AF700853C4504028CBA7C3333",
+		ContractAddress: "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48",
+		TokenDecimal:    "6",
+		Value:           "1000000",
+	}
+
+	tests := []struct {
+		mode         AddressCase
+		wantTo       string
+		wantContract string
+	}{
+		{AddressCaseLower, "0xd620aadabaa20d2af700853c4504028cba7c3333", "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"},
+		{AddressCaseChecksum, "0xd620AADaBaA20d2af700853C4504028cba7C3333", "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"},
+		{AddressCaseAsIs, raw.To, raw.ContractAddress},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			normalizer := NewEtherscanNormalizer()
+			normalizer.SetAddressCase(tt.mode)
+
+			tx, err := normalizer.NormalizeERC20Tx(raw)
+			if err != nil {
+				t.Fatalf("NormalizeERC20Tx() error = %v", err)
+			}
+			if tx.To != tt.wantTo {
+				t.Errorf("To mismatch: got %s, want %s", tx.To, tt.wantTo)
+			}
+			if tx.AssetContractAddress != tt.wantContract {
+				t.Errorf("AssetContractAddress mismatch: got %s, want %s", tx.AssetContractAddress, tt.wantContract)
+			}
+		})
+	}
+
+	if _, err := ParseAddressCase("upper"); err == nil {
+		t.Error("Expected error for unknown address case")
+	}
+}
+
+func TestNormalizerNativeSymbol(t *testing.T) {
+	normalizer := NewEtherscanNormalizer()
+	normalizer.SetNativeSymbol("MATIC")
+
+	normal, err := normalizer.NormalizeNormalTx(EtherscanNormalTx{Hash: "0x1", Value: "1000000000000000000"})
+	if err != nil {
+		t.Fatalf("NormalizeNormalTx() error = %v", err)
+	}
+	internal, err := normalizer.NormalizeInternalTx(EtherscanInternalTx{Hash: "0x2", Value: "1"})
+	if err != nil {
+		t.Fatalf("NormalizeInternalTx() error = %v", err)
+	}
+	token, err := normalizer.NormalizeERC20Tx(EtherscanTokenTx{Hash: "0x3", TokenSymbol: "USDC", TokenDecimal: "6"})
+	if err != nil {
+		t.Fatalf("NormalizeERC20Tx() error = %v", err)
+	}
+
+	if normal.AssetSymbol != "MATIC" {
+		t.Errorf("Normal AssetSymbol mismatch: got %s, want MATIC", normal.AssetSymbol)
+	}
+	if internal.AssetSymbol != "MATIC" {
+		t.Errorf("Internal AssetSymbol mismatch: got %s, want MATIC", internal.AssetSymbol)
+	}
+	if token.AssetSymbol != "USDC" {
+		t.Errorf("Token AssetSymbol mismatch: got %s, want USDC", token.AssetSymbol)
+	}
+
+	if got := NewEtherscanNormalizer(); got.nativeSymbol != DefaultNativeSymbol {
+		t.Errorf("Default native symbol mismatch: got %s, want %s", got.nativeSymbol, DefaultNativeSymbol)
+	}
+	if got := ChainNativeSymbol("polygon"); got != "POL" {
+		t.Errorf("Polygon native symbol mismatch: got %s, want POL", got)
+	}
+}
+
+func TestNormalizerDecimalsByType(t *testing.T) {
+	normalizer := NewEtherscanNormalizer()
+
+	tests := []struct {
+		name      string
+		normalize func() (*models.Transaction, error)
+		want      int
+	}{
+		{name: "normal", normalize: func() (*models.Transaction, error) {
+			return normalizer.NormalizeNormalTx(EtherscanNormalTx{Hash: "0x1", Value: "1000000000000000000"})
+		}, want: 18},
+		{name: "internal", normalize: func() (*models.Transaction, error) {
+			return normalizer.NormalizeInternalTx(EtherscanInternalTx{Hash: "0x2", Value: "1"})
+		}, want: 18},
+		{name: "erc20", normalize: func() (*models.Transaction, error) {
+			return normalizer.NormalizeERC20Tx(EtherscanTokenTx{Hash: "0x3", Value: "1500000", TokenDecimal: "6"})
+		}, want: 6},
+		{name: "erc721", normalize: func() (*models.Transaction, error) {
+			return normalizer.NormalizeERC721Tx(EtherscanTokenTx{Hash: "0x4", TokenID: "7", TokenDecimal: "18"})
+		}, want: 0},
+		{name: "erc1155", normalize: func() (*models.Transaction, error) {
+			return normalizer.NormalizeERC1155Tx(EtherscanTokenTx{Hash: "0x5", TokenID: "7", TokenValue: "3"})
+		}, want: 0},
+		{name: "withdrawal", normalize: func() (*models.Transaction, error) {
+			return normalizer.NormalizeWithdrawalTx(EtherscanWithdrawalTx{Amount: "32000000000"})
+		}, want: 18},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, err := tt.normalize()
+			if err != nil {
+				t.Fatalf("normalize error = %v", err)
+			}
+			if tx.Decimals != tt.want {
+				t.Errorf("Decimals mismatch: got %d, want %d", tx.Decimals, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeAnyDispatchesByType(t *testing.T) {
+	normalTx := EtherscanNormalTx{Hash: "0x1", From: "0xa", To: "0xb", Value: "1000000000000000000", TimeStamp: "1000"}
+	tokenTx := EtherscanTokenTx{Hash: "0x3", From: "0xa", To: "0xb", Value: "1500000", TokenSymbol: "USDC", TokenDecimal: "6", TimeStamp: "1000"}
+	nftTx := EtherscanTokenTx{Hash: "0x4", From: "0xa", To: "0xb", TokenID: "42", TokenSymbol: "PUNK", TimeStamp: "1000"}
+	multiTx := EtherscanTokenTx{Hash: "0x5", From: "0xa", To: "0xb", TokenID: "7", TokenValue: "3", TimeStamp: "1000"}
+
+	tests := []struct {
+		name     string
+		raw      any
+		hint     TransactionType
+		wantType models.TransactionType
+		wantHash string
+	}{
+		{name: "normal", raw: normalTx, hint: TxTypeNormal, wantType: models.TypeEthTransfer, wantHash: "0x1"},
+		{name: "normal_pointer", raw: &normalTx, hint: TxTypeNormal, wantType: models.TypeEthTransfer, wantHash: "0x1"},
+		{name: "internal", raw: EtherscanInternalTx{Hash: "0x2", From: "0xa", To: "0xb", Value: "1"}, hint: TxTypeNormal, wantType: models.TypeInternal, wantHash: "0x2"},
+		{name: "erc20_hint", raw: tokenTx, hint: TxTypeToken, wantType: models.TypeERC20Transfer, wantHash: "0x3"},
+		{name: "erc721_hint", raw: &nftTx, hint: TxTypeNFT, wantType: models.TypeERC721Transfer, wantHash: "0x4"},
+		{name: "erc1155_hint", raw: multiTx, hint: TxTypeERC1155, wantType: models.TypeERC1155Transfer, wantHash: "0x5"},
+		{name: "erc20_inferred", raw: tokenTx, hint: TxTypeNormal, wantType: models.TypeERC20Transfer, wantHash: "0x3"},
+		{name: "erc721_inferred", raw: nftTx, hint: TxTypeNormal, wantType: models.TypeERC721Transfer, wantHash: "0x4"},
+		{name: "erc1155_inferred", raw: multiTx, hint: TxTypeNormal, wantType: models.TypeERC1155Transfer, wantHash: "0x5"},
+		{name: "withdrawal", raw: EtherscanWithdrawalTx{Address: "0xa", Amount: "1000000000", BlockNumber: "1", Timestamp: "1000"}, hint: TxTypeNormal, wantType: models.TypeBeaconWithdrawal},
+		{name: "json_normal", raw: []byte(`{"hash":"0x6","from":"0xa","to":"0xb","value":"1","timeStamp":"1000"}`), hint: TxTypeNormal, wantType: models.TypeEthTransfer, wantHash: "0x6"},
+		{name: "json_erc721", raw: `{"hash":"0x7","from":"0xa","to":"0xb","tokenID":"9","timeStamp":"1000"}`, hint: TxTypeNFT, wantType: models.TypeERC721Transfer, wantHash: "0x7"},
+	}
+
+	normalizer := NewEtherscanNormalizer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, err := normalizer.NormalizeAny(tt.raw, tt.hint)
+			if err != nil {
+				t.Fatalf("NormalizeAny() error = %v", err)
+			}
+			if tx.Type != tt.wantType {
+				t.Errorf("Type mismatch: got %s, want %s", tx.Type, tt.wantType)
+			}
+			if tt.wantHash != "" && tx.Hash != tt.wantHash {
+				t.Errorf("Hash mismatch: got %s, want %s", tx.Hash, tt.wantHash)
+			}
+		})
+	}
+}
+
+func TestNormalizeAnyRejectsUnknownTypes(t *testing.T) {
+	normalizer := NewEtherscanNormalizer()
+
+	for _, raw := range []any{nil, 42, map[string]string{"hash": "0x1"}, models.Transaction{}} {
+		if _, err := normalizer.NormalizeAny(raw, TxTypeNormal); !errors.Is(err, ErrUnsupportedRawType) {
+			t.Errorf("NormalizeAny(%T) expected ErrUnsupportedRawType, got %v", raw, err)
+		}
+	}
+	if _, err := normalizer.NormalizeAny([]byte(`{"hash":"0x1"}`), TransactionType(99)); !errors.Is(err, ErrUnsupportedRawType) {
+		t.Errorf("Expected ErrUnsupportedRawType for an unknown hint, got %v", err)
+	}
+	if _, err := normalizer.NormalizeAny([]byte(`{"hash":`), TxTypeNormal); err == nil {
+		t.Error("Expected error for malformed JSON, got none")
+	}
+}
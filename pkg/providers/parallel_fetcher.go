@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 )
@@ -16,6 +17,11 @@ type ParallelFetcher struct {
 	normalizer    Normalizer
 	maxConcurrent int // Max concurrent fetch operations (default 3 for Etherscan)
 	timeout       time.Duration // Per-fetch timeout
+	onProgress    ProgressFunc  // Called as each type completes; may be nil
+	shards        int           // Block sub-ranges per type in FetchTypeSharded
+	startBlock    uint64        // First block FetchTypeSharded covers
+	endBlock      uint64        // Last block FetchTypeSharded covers
+	windowSize    int           // Max raw records one shard's fetch can return; a full window may be truncated
 }
 
 // FetchTypeResult holds the result of fetching a specific transaction type
@@ -25,6 +31,7 @@ type FetchTypeResult struct {
 	Err                error
 	Count              int
 	NormalizationStats NormalizationStats // Track normalization errors
+	Truncated          bool               // Set by FetchTypeSharded when a shard filled its result window
 }
 
 // TransactionType enum for identifying fetch type
@@ -36,8 +43,19 @@ const (
 	TxTypeToken
 	TxTypeNFT
 	TxTypeERC1155
+	TxTypeWithdrawal
 )
 
+// fetchTypeOrder is the canonical order of per-type results
+var fetchTypeOrder = []TransactionType{
+	TxTypeNormal,
+	TxTypeInternal,
+	TxTypeToken,
+	TxTypeNFT,
+	TxTypeERC1155,
+	TxTypeWithdrawal,
+}
+
 func (t TransactionType) String() string {
 	switch t {
 	case TxTypeNormal:
@@ -50,11 +68,48 @@ func (t TransactionType) String() string {
 		return "ERC-721"
 	case TxTypeERC1155:
 		return "ERC-1155"
+	case TxTypeWithdrawal:
+		return "Beacon Withdrawal"
 	default:
 		return "Unknown"
 	}
 }
 
+// typeNames are the names used to select transaction types, e.g. on the CLI
+var typeNames = map[TransactionType]string{
+	TxTypeNormal:     "normal",
+	TxTypeInternal:   "internal",
+	TxTypeToken:      "erc20",
+	TxTypeNFT:        "erc721",
+	TxTypeERC1155:    "erc1155",
+	TxTypeWithdrawal: "withdrawal",
+}
+
+// Name returns the selector name of the type (normal, internal, erc20, ...)
+func (t TransactionType) Name() string {
+	return typeNames[t]
+}
+
+// TransactionTypeNames returns the selector names of all types in canonical order
+func TransactionTypeNames() []string {
+	names := make([]string, 0, len(fetchTypeOrder))
+	for _, txType := range fetchTypeOrder {
+		names = append(names, txType.Name())
+	}
+	return names
+}
+
+// ParseTransactionType resolves a selector name (case-insensitive) to its type
+func ParseTransactionType(name string) (TransactionType, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, txType := range fetchTypeOrder {
+		if typeNames[txType] == name {
+			return txType, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown transaction type %q (available: %s)", name, strings.Join(TransactionTypeNames(), ", "))
+}
+
 // NewParallelFetcher creates a new parallel fetcher with sensible defaults
 func NewParallelFetcher(provider Provider, normalizer Normalizer) *ParallelFetcher {
 	return &ParallelFetcher{
@@ -62,6 +117,10 @@ func NewParallelFetcher(provider Provider, normalizer Normalizer) *ParallelFetch
 		normalizer:    normalizer,
 		maxConcurrent: 3, // Etherscan allows ~5 req/sec, so 3 concurrent is safe
 		timeout:       30 * time.Second,
+		shards:        1,
+		startBlock:    DefaultStartBlock,
+		endBlock:      DefaultEndBlock,
+		windowSize:    DefaultPageSize,
 	}
 }
 
@@ -79,19 +138,29 @@ func (pf *ParallelFetcher) SetTimeout(timeout time.Duration) {
 	}
 }
 
-// FetchAllTransactionsParallel fetches all transaction types concurrently
+// SetProgressCallback sets a function called each time a transaction type finishes.
+// Calls are serialized across the concurrent fetches (see ProgressFunc).
+func (pf *ParallelFetcher) SetProgressCallback(fn ProgressFunc) {
+	pf.onProgress = fn
+}
+
+// FetchAllTransactionsParallel fetches all transaction types concurrently. Alongside the
+// merged, sorted transactions it returns one FetchTypeResult per type in canonical order
+// (Normal, Internal, ERC-20, ERC-721, ERC-1155, Beacon Withdrawal), regardless of the
+// order in which fetches complete.
 func (pf *ParallelFetcher) FetchAllTransactionsParallel(
 	ctx context.Context,
 	address string,
 	startPage, endPage int,
-) ([]*models.Transaction, error) {
+) ([]*models.Transaction, []FetchTypeResult, error) {
 	// Create a semaphore to limit concurrent operations
 	sem := make(chan struct{}, pf.maxConcurrent)
 	defer close(sem)
 
 	// Result channel to collect all results
-	resultChan := make(chan *FetchTypeResult, 5) // 5 fetch types
+	resultChan := make(chan *FetchTypeResult, len(fetchTypeOrder))
 	var wg sync.WaitGroup
+	tracker := newProgressTracker(len(fetchTypeOrder), pf.onProgress)
 
 	// Helper function to wrap fetch operations with semaphore
 	fetchWithSemaphore := func(fetchFunc func(context.Context) (*FetchTypeResult), txType TransactionType) {
@@ -106,13 +175,15 @@ func (pf *ParallelFetcher) FetchAllTransactionsParallel(
 		defer cancel()
 
 		// Execute fetch in goroutine
-		resultChan <- pf.executeFetch(fetchCtx, func() *FetchTypeResult {
+		result := pf.executeFetch(fetchCtx, func() *FetchTypeResult {
 			return fetchFunc(fetchCtx)
 		}, txType)
+		tracker.complete(result)
+		resultChan <- result
 	}
 
 	// Launch all fetch operations
-	wg.Add(5)
+	wg.Add(len(fetchTypeOrder))
 	go fetchWithSemaphore(func(fetchCtx context.Context) *FetchTypeResult {
 		return pf.fetchNormalTransactionsConcurrent(fetchCtx, address, startPage, endPage)
 	}, TxTypeNormal)
@@ -133,6 +204,10 @@ func (pf *ParallelFetcher) FetchAllTransactionsParallel(
 		return pf.fetchERC1155TransfersConcurrent(fetchCtx, address, startPage, endPage)
 	}, TxTypeERC1155)
 
+	go fetchWithSemaphore(func(fetchCtx context.Context) *FetchTypeResult {
+		return pf.fetchBeaconWithdrawalsConcurrent(fetchCtx, address, startPage, endPage)
+	}, TxTypeWithdrawal)
+
 	// Close result channel when all operations complete
 	go func() {
 		wg.Wait()
@@ -142,20 +217,32 @@ func (pf *ParallelFetcher) FetchAllTransactionsParallel(
 	// Collect all results
 	var allTransactions []*models.Transaction
 	var errors []error
-	var fetchStats map[TransactionType]int = make(map[TransactionType]int)
+	byType := make(map[TransactionType]*FetchTypeResult, len(fetchTypeOrder))
 
 	for result := range resultChan {
+		byType[result.TxType] = result
+	}
+
+	// Merge in canonical type order so stats and errors are reported predictably
+	typeResults := make([]FetchTypeResult, 0, len(fetchTypeOrder))
+	for _, txType := range fetchTypeOrder {
+		result, ok := byType[txType]
+		if !ok {
+			continue
+		}
+		typeResults = append(typeResults, *result)
+
 		if result.Err != nil {
 			errors = append(errors, fmt.Errorf("%s fetch failed: %w", result.TxType.String(), result.Err))
 		} else if result.Txs != nil {
+			labelChain(pf.provider, result.Txs)
 			allTransactions = append(allTransactions, result.Txs...)
-			fetchStats[result.TxType] = result.Count
 		}
 	}
 
 	// If all fetches failed, return error with no data
-	if len(errors) == 5 {
-		return nil, fmt.Errorf("all transaction fetches failed: %v", errors)
+	if len(errors) == len(fetchTypeOrder) {
+		return nil, typeResults, fmt.Errorf("all transaction fetches failed: %v", errors)
 	}
 
 	// Sort all transactions
@@ -165,10 +252,10 @@ func (pf *ParallelFetcher) FetchAllTransactionsParallel(
 
 	// If some fetches failed, return partial data with error indicating failures
 	if len(errors) > 0 {
-		return allTransactions, fmt.Errorf("partial fetch failures occurred: %v", errors)
+		return allTransactions, typeResults, fmt.Errorf("partial fetch failures occurred: %v", errors)
 	}
 
-	return allTransactions, nil
+	return allTransactions, typeResults, nil
 }
 
 // executeFetch safely executes a fetch operation with timeout handling
@@ -212,8 +299,7 @@ func (pf *ParallelFetcher) fetchNormalTransactionsConcurrent(
 	for _, tx := range rawTxs {
 		stats.TotalProcessed++
 		if norm, err := pf.normalizer.NormalizeNormalTx(tx); err != nil {
-			stats.ErrorCount++
-			stats.Errors = append(stats.Errors, fmt.Errorf("failed to normalize normal transaction %s: %w", tx.Hash, err))
+			stats.record(TxTypeNormal, tx.Hash, tx, err)
 		} else if norm != nil {
 			stats.SuccessCount++
 			normalized = append(normalized, norm)
@@ -245,8 +331,7 @@ func (pf *ParallelFetcher) fetchInternalTransactionsConcurrent(
 	for _, tx := range rawTxs {
 		stats.TotalProcessed++
 		if norm, err := pf.normalizer.NormalizeInternalTx(tx); err != nil {
-			stats.ErrorCount++
-			stats.Errors = append(stats.Errors, fmt.Errorf("failed to normalize internal transaction %s: %w", tx.Hash, err))
+			stats.record(TxTypeInternal, tx.Hash, tx, err)
 		} else if norm != nil {
 			stats.SuccessCount++
 			normalized = append(normalized, norm)
@@ -278,8 +363,7 @@ func (pf *ParallelFetcher) fetchTokenTransfersConcurrent(
 	for _, tx := range rawTxs {
 		stats.TotalProcessed++
 		if norm, err := pf.normalizer.NormalizeERC20Tx(tx); err != nil {
-			stats.ErrorCount++
-			stats.Errors = append(stats.Errors, fmt.Errorf("failed to normalize token transaction %s: %w", tx.Hash, err))
+			stats.record(TxTypeToken, tx.Hash, tx, err)
 		} else if norm != nil {
 			stats.SuccessCount++
 			normalized = append(normalized, norm)
@@ -311,8 +395,7 @@ func (pf *ParallelFetcher) fetchNFTTransfersConcurrent(
 	for _, tx := range rawTxs {
 		stats.TotalProcessed++
 		if norm, err := pf.normalizer.NormalizeERC721Tx(tx); err != nil {
-			stats.ErrorCount++
-			stats.Errors = append(stats.Errors, fmt.Errorf("failed to normalize NFT transaction %s: %w", tx.Hash, err))
+			stats.record(TxTypeNFT, tx.Hash, tx, err)
 		} else if norm != nil {
 			stats.SuccessCount++
 			normalized = append(normalized, norm)
@@ -344,8 +427,7 @@ func (pf *ParallelFetcher) fetchERC1155TransfersConcurrent(
 	for _, tx := range rawTxs {
 		stats.TotalProcessed++
 		if norm, err := pf.normalizer.NormalizeERC1155Tx(tx); err != nil {
-			stats.ErrorCount++
-			stats.Errors = append(stats.Errors, fmt.Errorf("failed to normalize ERC1155 transaction %s: %w", tx.Hash, err))
+			stats.record(TxTypeERC1155, tx.Hash, tx, err)
 		} else if norm != nil {
 			stats.SuccessCount++
 			normalized = append(normalized, norm)
@@ -359,3 +441,38 @@ func (pf *ParallelFetcher) fetchERC1155TransfersConcurrent(
 		NormalizationStats: stats,
 	}
 }
+
+// fetchBeaconWithdrawalsConcurrent fetches beacon chain withdrawals
+func (pf *ParallelFetcher) fetchBeaconWithdrawalsConcurrent(
+	ctx context.Context,
+	address string,
+	startPage, endPage int,
+) *FetchTypeResult {
+	if !hasBeaconWithdrawals(pf.provider) {
+		return &FetchTypeResult{TxType: TxTypeWithdrawal}
+	}
+	rawTxs, err := pf.provider.FetchBeaconWithdrawals(ctx, address, startPage, endPage)
+	if err != nil {
+		return &FetchTypeResult{TxType: TxTypeWithdrawal, Err: err}
+	}
+
+	var normalized []*models.Transaction
+	stats := NormalizationStats{}
+
+	for _, tx := range rawTxs {
+		stats.TotalProcessed++
+		if norm, err := pf.normalizer.NormalizeWithdrawalTx(tx); err != nil {
+			stats.record(TxTypeWithdrawal, "", tx, err)
+		} else if norm != nil {
+			stats.SuccessCount++
+			normalized = append(normalized, norm)
+		}
+	}
+
+	return &FetchTypeResult{
+		TxType:             TxTypeWithdrawal,
+		Txs:                normalized,
+		Count:              len(normalized),
+		NormalizationStats: stats,
+	}
+}
@@ -0,0 +1,128 @@
+package providers
+
+import (
+	"conintracker-hiring/pkg/models"
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// reverseOrderDelays delays earlier transaction types longer so fetches
+// complete in reverse canonical order
+func reverseOrderDelays() map[TransactionType]time.Duration {
+	delays := make(map[TransactionType]time.Duration, len(fetchTypeOrder))
+	for _, txType := range fetchTypeOrder {
+		delays[txType] = time.Duration(len(fetchTypeOrder)-int(txType)) * 10 * time.Millisecond
+	}
+	return delays
+}
+
+func TestFetchAllTransactionsParallelTypeOrder(t *testing.T) {
+	mockProvider := &ConfigurableProvider{
+		NormalTxs:  []EtherscanNormalTx{{Hash: "0x1", BlockNumber: "1", TimeStamp: "1000"}},
+		TokenTxs:   []EtherscanTokenTx{{Hash: "0x2", BlockNumber: "2", TimeStamp: "1001", TokenDecimal: "6"}},
+		ERC1155Txs: []EtherscanTokenTx{{Hash: "0x3", BlockNumber: "3", TimeStamp: "1002"}},
+		Delays:     reverseOrderDelays(),
+	}
+
+	fetcher := NewParallelFetcher(mockProvider, NewEtherscanNormalizer())
+	fetcher.SetMaxConcurrent(len(fetchTypeOrder))
+
+	txs, results, err := fetcher.FetchAllTransactionsParallel(context.Background(), "0xtest", 1, 1)
+	if err != nil {
+		t.Fatalf("FetchAllTransactionsParallel() error = %v", err)
+	}
+
+	if len(txs) != 3 {
+		t.Errorf("Expected 3 transactions, got %d", len(txs))
+	}
+
+	if len(results) != len(fetchTypeOrder) {
+		t.Fatalf("Expected %d type results, got %d", len(fetchTypeOrder), len(results))
+	}
+	for i, want := range fetchTypeOrder {
+		if results[i].TxType != want {
+			t.Errorf("Result %d type mismatch: got %s, want %s", i, results[i].TxType, want)
+		}
+	}
+
+	if results[TxTypeToken].NormalizationStats.SuccessCount != 1 {
+		t.Errorf("Expected 1 normalized ERC-20 transfer, got %d", results[TxTypeToken].NormalizationStats.SuccessCount)
+	}
+}
+
+// TestParallelFetchProgress runs every type concurrently with a callback that keeps
+// unsynchronized state; run with -race to check the calls are serialized
+func TestParallelFetchProgress(t *testing.T) {
+	mockProvider := &ConfigurableProvider{
+		NormalTxs:   []EtherscanNormalTx{{Hash: "0x1", BlockNumber: "1", TimeStamp: "1000"}},
+		InternalTxs: []EtherscanInternalTx{{Hash: "0x2", BlockNumber: "2", TimeStamp: "1001"}},
+		TokenTxs: []EtherscanTokenTx{
+			{Hash: "0x3", BlockNumber: "3", TimeStamp: "1002", TokenDecimal: "6"},
+			{Hash: "0x4", BlockNumber: "4", TimeStamp: "1003", TokenDecimal: "6"},
+		},
+	}
+
+	fetcher := NewParallelFetcher(mockProvider, NewEtherscanNormalizer())
+	fetcher.SetMaxConcurrent(len(fetchTypeOrder))
+
+	var updates []FetchProgress
+	fetcher.SetProgressCallback(func(p FetchProgress) {
+		updates = append(updates, p)
+	})
+
+	if _, _, err := fetcher.FetchAllTransactionsParallel(context.Background(), "0xtest", 1, 1); err != nil {
+		t.Fatalf("FetchAllTransactionsParallel() error = %v", err)
+	}
+
+	if len(updates) != len(fetchTypeOrder) {
+		t.Fatalf("Expected %d progress updates, got %d", len(fetchTypeOrder), len(updates))
+	}
+	for i, p := range updates {
+		if p.TypesComplete != i+1 {
+			t.Errorf("Update %d: TypesComplete mismatch: got %d, want %d", i, p.TypesComplete, i+1)
+		}
+		if i > 0 && p.Transactions < updates[i-1].Transactions {
+			t.Errorf("Update %d: running total went backwards (%d after %d)", i, p.Transactions, updates[i-1].Transactions)
+		}
+	}
+
+	last := updates[len(updates)-1]
+	if last.Transactions != 4 {
+		t.Errorf("Final total mismatch: got %d, want 4", last.Transactions)
+	}
+	if got, want := last.String(), "6 of 6 types complete, 4 transactions so far"; got != want {
+		t.Errorf("String mismatch: got %q, want %q", got, want)
+	}
+}
+
+func TestProgressTrackerConcurrentUpdates(t *testing.T) {
+	const workers = 50
+
+	calls := 0
+	maxSeen := 0
+	tracker := newProgressTracker(workers, func(p FetchProgress) {
+		calls++
+		if p.Transactions > maxSeen {
+			maxSeen = p.Transactions
+		}
+	})
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			tracker.complete(&FetchTypeResult{Txs: make([]*models.Transaction, 2)})
+		}()
+	}
+	wg.Wait()
+
+	if calls != workers {
+		t.Errorf("Callback count mismatch: got %d, want %d", calls, workers)
+	}
+	if maxSeen != 2*workers {
+		t.Errorf("Running total mismatch: got %d, want %d", maxSeen, 2*workers)
+	}
+}
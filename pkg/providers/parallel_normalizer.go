@@ -16,10 +16,59 @@ type ParallelNormalizer struct {
 
 // NormalizationStats tracks statistics about the normalization process
 type NormalizationStats struct {
-	TotalProcessed int
-	SuccessCount   int
-	ErrorCount     int
-	Errors         []error
+	TotalProcessed int     `json:"processed"`
+	SuccessCount   int     `json:"success"`
+	ErrorCount     int     `json:"errors"`
+	Errors         []error `json:"-"`
+}
+
+// ErrorRate returns the share of processed records that failed to normalize, or 0 when none were processed
+func (s NormalizationStats) ErrorRate() float64 {
+	if s.TotalProcessed == 0 {
+		return 0
+	}
+	return float64(s.ErrorCount) / float64(s.TotalProcessed)
+}
+
+// NormalizationError describes a raw transaction that failed to normalize and was
+// skipped. It keeps the raw record so skipped rows can be written out for review.
+type NormalizationError struct {
+	Type    string `json:"type"`
+	Hash    string `json:"hash,omitempty"`
+	Message string `json:"error"`
+	Raw     any    `json:"raw"`
+	Err     error  `json:"-"`
+}
+
+func (e *NormalizationError) Error() string {
+	if e.Hash == "" {
+		return fmt.Sprintf("failed to normalize %s transaction: %s", e.Type, e.Message)
+	}
+	return fmt.Sprintf("failed to normalize %s transaction %s: %s", e.Type, e.Hash, e.Message)
+}
+
+func (e *NormalizationError) Unwrap() error {
+	return e.Err
+}
+
+// record counts a failed raw transaction and keeps it in Errors
+func (s *NormalizationStats) record(txType TransactionType, hash string, raw any, err error) {
+	s.ErrorCount++
+	s.Errors = append(s.Errors, &NormalizationError{
+		Type:    txType.String(),
+		Hash:    hash,
+		Message: err.Error(),
+		Raw:     raw,
+		Err:     err,
+	})
+}
+
+// merge adds other's counts and errors to s
+func (s *NormalizationStats) merge(other NormalizationStats) {
+	s.TotalProcessed += other.TotalProcessed
+	s.SuccessCount += other.SuccessCount
+	s.ErrorCount += other.ErrorCount
+	s.Errors = append(s.Errors, other.Errors...)
 }
 
 // NewParallelNormalizer creates a new parallel normalizer
@@ -123,7 +172,9 @@ func normalizeWorkerPoolTyped[T any](
 	}
 }
 
-// NormalizeTransactionsParallel normalizes transactions in parallel with error tracking
+// NormalizeTransactionsParallel normalizes transactions in parallel with error tracking.
+// If ctx is canceled, collection stops early and the returned result only holds the
+// transactions and stats gathered so far; workers observe the cancellation and exit.
 func (pn *ParallelNormalizer) NormalizeTransactionsParallel(
 	ctx context.Context,
 	normalTxs []EtherscanNormalTx,
@@ -197,11 +248,11 @@ func (pn *ParallelNormalizer) NormalizeTransactionsParallel(
 			if !ok {
 				statsChan = nil
 			} else {
-				aggregateStats.TotalProcessed += stats.TotalProcessed
-				aggregateStats.SuccessCount += stats.SuccessCount
-				aggregateStats.ErrorCount += stats.ErrorCount
-				aggregateStats.Errors = append(aggregateStats.Errors, stats.Errors...)
+				aggregateStats.merge(stats)
 			}
+		case <-ctx.Done():
+			// Stop collecting; results are partial
+			done = true
 		}
 		
 		if resultChan == nil && statsChan == nil {
@@ -0,0 +1,75 @@
+package providers
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestNormalizeTransactionsParallelCanceledContext(t *testing.T) {
+	fixtures := GetMediumFixture()
+	parallelNormalizer := NewParallelNormalizer(NewEtherscanNormalizer())
+	parallelNormalizer.SetBufferSize(1) // Force workers to block on a full result channel
+
+	baseline := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	done := make(chan *NormalizationResult)
+	go func() {
+		done <- parallelNormalizer.NormalizeTransactionsParallel(
+			ctx,
+			fixtures.NormalTxs,
+			fixtures.InternalTxs,
+			fixtures.TokenTxs,
+			fixtures.NFTTxs,
+			fixtures.ERC1155Txs,
+		)
+	}()
+
+	select {
+	case result := <-done:
+		if result == nil {
+			t.Fatal("Expected a (possibly partial) result, got nil")
+		}
+		total := len(fixtures.NormalTxs) * 5
+		if len(result.Transactions) > total {
+			t.Errorf("Got %d transactions, more than the %d inputs", len(result.Transactions), total)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("NormalizeTransactionsParallel did not return after cancellation")
+	}
+
+	// Workers must wind down once the context is canceled
+	deadline := time.Now().Add(2 * time.Second)
+	for runtime.NumGoroutine() > baseline && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := runtime.NumGoroutine(); n > baseline {
+		t.Errorf("Goroutine leak: %d goroutines running, baseline %d", n, baseline)
+	}
+}
+
+func TestNormalizeTransactionsParallelCompletes(t *testing.T) {
+	fixtures := GetSmallFixture()
+	parallelNormalizer := NewParallelNormalizer(NewEtherscanNormalizer())
+
+	result := parallelNormalizer.NormalizeTransactionsParallel(
+		context.Background(),
+		fixtures.NormalTxs,
+		fixtures.InternalTxs,
+		fixtures.TokenTxs,
+		fixtures.NFTTxs,
+		fixtures.ERC1155Txs,
+	)
+
+	want := len(fixtures.NormalTxs) * 5
+	if len(result.Transactions) != want {
+		t.Errorf("Expected %d transactions, got %d", want, len(result.Transactions))
+	}
+	if result.Stats.SuccessCount != want {
+		t.Errorf("Expected %d successes, got %d", want, result.Stats.SuccessCount)
+	}
+}
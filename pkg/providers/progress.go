@@ -0,0 +1,61 @@
+package providers
+
+import (
+	"fmt"
+	"sync"
+)
+
+// FetchProgress is a snapshot of a parallel fetch, reported each time a transaction type finishes
+type FetchProgress struct {
+	TypesComplete int             // Types finished so far, successfully or not
+	TypesTotal    int             // Types being fetched
+	Transactions  int             // Normalized transactions collected so far
+	LastType      TransactionType // Type that just finished
+	LastCount     int             // Transactions it contributed; 0 when it failed
+	LastErr       error           // Its error, if it failed
+}
+
+// String renders the progress as "3 of 6 types complete, 120 transactions so far"
+func (p FetchProgress) String() string {
+	return fmt.Sprintf("%d of %d types complete, %d transactions so far", p.TypesComplete, p.TypesTotal, p.Transactions)
+}
+
+// ProgressFunc receives fetch progress. Calls are serialized, so implementations
+// need no locking of their own, and within one address's fetch each call sees
+// totals at least as large as the last.
+type ProgressFunc func(FetchProgress)
+
+// progressTracker aggregates per-type completions from concurrent fetch goroutines
+type progressTracker struct {
+	mu       sync.Mutex
+	progress FetchProgress
+	report   ProgressFunc
+}
+
+// newProgressTracker creates a tracker for total types; report may be nil
+func newProgressTracker(total int, report ProgressFunc) *progressTracker {
+	return &progressTracker{
+		progress: FetchProgress{TypesTotal: total},
+		report:   report,
+	}
+}
+
+// complete records a finished type and reports the updated totals. The callback runs
+// under the tracker's lock so concurrent completions are reported one at a time, in order.
+func (pt *progressTracker) complete(result *FetchTypeResult) {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+
+	pt.progress.TypesComplete++
+	pt.progress.LastType = result.TxType
+	pt.progress.LastErr = result.Err
+	pt.progress.LastCount = 0
+	if result.Err == nil {
+		pt.progress.LastCount = len(result.Txs)
+		pt.progress.Transactions += len(result.Txs)
+	}
+
+	if pt.report != nil {
+		pt.report(pt.progress)
+	}
+}
@@ -0,0 +1,215 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+)
+
+// ErrTransactionNotFound is returned when the chain has no mined transaction with the given hash
+var ErrTransactionNotFound = errors.New("transaction not found")
+
+// proxyTransaction is the subset of eth_getTransactionByHash used to build an EtherscanNormalTx.
+// Numeric fields are 0x-prefixed hex, though decimal is accepted too (see parseNumeric).
+type proxyTransaction struct {
+	BlockHash        string `json:"blockHash"`
+	BlockNumber      string `json:"blockNumber"`
+	From             string `json:"from"`
+	Gas              string `json:"gas"`
+	GasPrice         string `json:"gasPrice"`
+	Hash             string `json:"hash"`
+	Input            string `json:"input"`
+	Nonce            string `json:"nonce"`
+	To               string `json:"to"`
+	TransactionIndex string `json:"transactionIndex"`
+	Value            string `json:"value"`
+}
+
+// proxyReceipt is the subset of eth_getTransactionReceipt that carries execution results
+type proxyReceipt struct {
+	ContractAddress   string `json:"contractAddress"`
+	CumulativeGasUsed string `json:"cumulativeGasUsed"`
+	EffectiveGasPrice string `json:"effectiveGasPrice"`
+	GasUsed           string `json:"gasUsed"`
+	Status            string `json:"status"`
+}
+
+// proxyBlock is the subset of eth_getBlockByNumber needed for the transaction timestamp
+type proxyBlock struct {
+	Timestamp string `json:"timestamp"`
+}
+
+// FetchTransactionByHash fetches a single transaction through Etherscan's JSON-RPC proxy,
+// combining the transaction, its receipt (gas used, status), and its block (timestamp)
+// into the same shape the account endpoints return. Unknown and pending transactions
+// return ErrTransactionNotFound.
+func (c *EtherscanClient) FetchTransactionByHash(ctx context.Context, hash string) (*EtherscanNormalTx, error) {
+	params := c.proxyParams("eth_getTransactionByHash")
+	params.Set("txhash", hash)
+	var tx proxyTransaction
+	if found, err := fetchProxyResult(ctx, c, params, &tx); err != nil {
+		return nil, fmt.Errorf("failed to fetch transaction: %w", err)
+	} else if !found || tx.BlockNumber == "" {
+		return nil, fmt.Errorf("%w: %s", ErrTransactionNotFound, hash)
+	}
+
+	params = c.proxyParams("eth_getTransactionReceipt")
+	params.Set("txhash", hash)
+	var receipt proxyReceipt
+	if found, err := fetchProxyResult(ctx, c, params, &receipt); err != nil {
+		return nil, fmt.Errorf("failed to fetch transaction receipt: %w", err)
+	} else if !found {
+		return nil, fmt.Errorf("%w: %s has no receipt", ErrTransactionNotFound, hash)
+	}
+
+	params = c.proxyParams("eth_getBlockByNumber")
+	params.Set("tag", tx.BlockNumber)
+	params.Set("boolean", "false")
+	var block proxyBlock
+	if found, err := fetchProxyResult(ctx, c, params, &block); err != nil {
+		return nil, fmt.Errorf("failed to fetch block: %w", err)
+	} else if !found {
+		return nil, fmt.Errorf("block %s not found", tx.BlockNumber)
+	}
+
+	// EIP-1559 transactions pay the effective price, not the fee cap in gasPrice
+	gasPrice := tx.GasPrice
+	if receipt.EffectiveGasPrice != "" {
+		gasPrice = receipt.EffectiveGasPrice
+	}
+
+	isError := "0"
+	if receipt.Status == "0x0" {
+		isError = "1"
+	}
+
+	methodID := tx.Input
+	if len(methodID) > 10 {
+		methodID = methodID[:10]
+	}
+
+	return &EtherscanNormalTx{
+		BlockNumber:       numericToDecimal(tx.BlockNumber),
+		TimeStamp:         numericToDecimal(block.Timestamp),
+		Hash:              tx.Hash,
+		Nonce:             numericToDecimal(tx.Nonce),
+		BlockHash:         tx.BlockHash,
+		TransactionIndex:  numericToDecimal(tx.TransactionIndex),
+		From:              tx.From,
+		To:                tx.To,
+		Value:             numericToDecimal(tx.Value),
+		Gas:               numericToDecimal(tx.Gas),
+		GasPrice:          numericToDecimal(gasPrice),
+		IsError:           isError,
+		TxReceiptStatus:   numericToDecimal(receipt.Status),
+		Input:             tx.Input,
+		ContractAddress:   receipt.ContractAddress,
+		CumulativeGasUsed: numericToDecimal(receipt.CumulativeGasUsed),
+		GasUsed:           numericToDecimal(receipt.GasUsed),
+		MethodId:          methodID,
+	}, nil
+}
+
+// LatestBlockNumber returns the number of the most recent block via eth_blockNumber.
+// Its result is a bare quantity string, so unlike other proxy calls a string result
+// is only an error message when it is not a number.
+func (c *EtherscanClient) LatestBlockNumber(ctx context.Context) (uint64, error) {
+	body, err := c.executeRequest(ctx, c.proxyParams("eth_blockNumber"))
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch latest block: %w", err)
+	}
+
+	var resp struct {
+		Result string `json:"result"`
+		Error  *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return 0, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if resp.Error != nil {
+		return 0, fmt.Errorf("etherscan error: %s", resp.Error.Message)
+	}
+	n, ok := parseNumeric(resp.Result)
+	if !ok {
+		return 0, apiError(c.chainID, resp.Result)
+	}
+	if !n.IsUint64() {
+		return 0, fmt.Errorf("invalid block number %q", resp.Result)
+	}
+	return n.Uint64(), nil
+}
+
+// proxyParams creates query parameters for a proxy module action
+func (c *EtherscanClient) proxyParams(action string) url.Values {
+	params := c.buildParams(action, "proxy", "")
+	params.Del("address")
+	return params
+}
+
+// fetchProxyResult decodes a JSON-RPC proxy response into out. It reports false
+// for a null result; a string result is an Etherscan error message.
+func fetchProxyResult(ctx context.Context, c *EtherscanClient, params url.Values, out any) (bool, error) {
+	body, err := c.executeRequest(ctx, params)
+	if err != nil {
+		return false, err
+	}
+
+	var resp struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return false, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if resp.Error != nil {
+		return false, fmt.Errorf("etherscan error: %s", resp.Error.Message)
+	}
+
+	result := strings.TrimSpace(string(resp.Result))
+	if result == "" || result == "null" {
+		return false, nil
+	}
+	if strings.HasPrefix(result, `"`) {
+		var message string
+		json.Unmarshal(resp.Result, &message)
+		return false, apiError(c.chainID, message)
+	}
+
+	if err := json.Unmarshal(resp.Result, out); err != nil {
+		return false, fmt.Errorf("failed to parse result: %w", err)
+	}
+	return true, nil
+}
+
+// parseNumeric parses a non-negative quantity that may be 0x-prefixed hex, as JSON-RPC
+// proxy results are, or plain decimal, as the account endpoints return. Empty,
+// negative, and invalid input report false.
+func parseNumeric(s string) (*big.Int, bool) {
+	s = strings.TrimSpace(s)
+	base := 10
+	if len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
+		s, base = s[2:], 16
+	}
+	if s == "" || s[0] == '-' || s[0] == '+' {
+		return nil, false
+	}
+	return new(big.Int).SetString(s, base)
+}
+
+// numericToDecimal converts a hex or decimal quantity (see parseNumeric) to the
+// decimal string the normalizer expects; empty or invalid input yields ""
+func numericToDecimal(s string) string {
+	n, ok := parseNumeric(s)
+	if !ok {
+		return ""
+	}
+	return n.String()
+}
@@ -0,0 +1,148 @@
+package providers
+
+import (
+	"conintracker-hiring/internal/testdata"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// proxyServer serves canned proxy responses keyed by action
+func proxyServer(t *testing.T, responses map[string]string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if module := r.URL.Query().Get("module"); module != "proxy" {
+			t.Errorf("module mismatch: got %s, want proxy", module)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		response, ok := responses[r.URL.Query().Get("action")]
+		if !ok {
+			response = testdata.ProxyNullResponse
+		}
+		w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFetchTransactionByHash(t *testing.T) {
+	server := proxyServer(t, map[string]string{
+		"eth_getTransactionByHash":  testdata.ProxyTransactionResponse,
+		"eth_getTransactionReceipt": testdata.ProxyReceiptResponse,
+		"eth_getBlockByNumber":      testdata.ProxyBlockResponse,
+	})
+	client := NewEtherscanClient(ClientConfig{APIKey: "test-key", BaseURL: server.URL})
+
+	hash := "0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+	raw, err := client.FetchTransactionByHash(context.Background(), hash)
+	if err != nil {
+		t.Fatalf("FetchTransactionByHash() error = %v", err)
+	}
+
+	tx, err := NewEtherscanNormalizer().NormalizeNormalTx(*raw)
+	if err != nil {
+		t.Fatalf("NormalizeNormalTx() error = %v", err)
+	}
+
+	if tx.Hash != hash {
+		t.Errorf("Hash mismatch: got %s, want %s", tx.Hash, hash)
+	}
+	if tx.BlockNumber != 20000000 {
+		t.Errorf("BlockNumber mismatch: got %d, want 20000000", tx.BlockNumber)
+	}
+	if tx.Timestamp.Unix() != 1700000000 {
+		t.Errorf("Timestamp mismatch: got %d, want 1700000000", tx.Timestamp.Unix())
+	}
+	if tx.From != "0xa39b189482f984388a34460636fea9eb181ad1a6" {
+		t.Errorf("From mismatch: got %s", tx.From)
+	}
+	if tx.To != "0xd620aadabaa20d2af700853c4504028cba7c3333" {
+		t.Errorf("To mismatch: got %s", tx.To)
+	}
+	if tx.Amount != "1" {
+		t.Errorf("Amount mismatch: got %s, want 1", tx.Amount)
+	}
+	if tx.GasUsed != 21000 {
+		t.Errorf("GasUsed mismatch: got %d, want 21000", tx.GasUsed)
+	}
+	if tx.GasFeeETH != "0.00105" {
+		t.Errorf("GasFeeETH mismatch: got %s, want 0.00105", tx.GasFeeETH)
+	}
+	if tx.IsError {
+		t.Error("Expected successful transaction")
+	}
+}
+
+func TestFetchTransactionByHashNotFound(t *testing.T) {
+	server := proxyServer(t, nil)
+	client := NewEtherscanClient(ClientConfig{APIKey: "test-key", BaseURL: server.URL})
+
+	_, err := client.FetchTransactionByHash(context.Background(), "0xdeadbeef")
+	if !errors.Is(err, ErrTransactionNotFound) {
+		t.Fatalf("Expected ErrTransactionNotFound, got %v", err)
+	}
+}
+
+func TestFetchTransactionByHashAPIError(t *testing.T) {
+	server := proxyServer(t, map[string]string{
+		"eth_getTransactionByHash": testdata.ErrorResponse,
+	})
+	client := NewEtherscanClient(ClientConfig{APIKey: "bad-key", BaseURL: server.URL})
+
+	_, err := client.FetchTransactionByHash(context.Background(), "0xdeadbeef")
+	if err == nil || errors.Is(err, ErrTransactionNotFound) {
+		t.Fatalf("Expected API error, got %v", err)
+	}
+}
+
+func TestLatestBlockNumber(t *testing.T) {
+	server := proxyServer(t, map[string]string{
+		"eth_blockNumber": testdata.ProxyBlockNumberResponse,
+	})
+	client := NewEtherscanClient(ClientConfig{APIKey: "test-key", BaseURL: server.URL})
+
+	block, err := client.LatestBlockNumber(context.Background())
+	if err != nil {
+		t.Fatalf("LatestBlockNumber() error = %v", err)
+	}
+	if block != 20000000 {
+		t.Errorf("Block mismatch: got %d, want 20000000", block)
+	}
+}
+
+func TestParseNumeric(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		ok    bool
+	}{
+		{input: "21000", want: "21000", ok: true},
+		{input: "0x5208", want: "21000", ok: true},
+		{input: "0X5208", want: "21000", ok: true},
+		{input: "0x0", want: "0", ok: true},
+		{input: "0xde0b6b3a7640000", want: "1000000000000000000", ok: true},
+		{input: "", ok: false},
+		{input: "0x", ok: false},
+		{input: "0xzz", ok: false},
+		{input: "5208h", ok: false},
+		{input: "-1", ok: false},
+		{input: "Invalid API Key", ok: false},
+	}
+
+	for _, tt := range tests {
+		n, ok := parseNumeric(tt.input)
+		if ok != tt.ok {
+			t.Errorf("parseNumeric(%q) ok mismatch: got %v, want %v", tt.input, ok, tt.ok)
+			continue
+		}
+		if ok && n.String() != tt.want {
+			t.Errorf("parseNumeric(%q) mismatch: got %s, want %s", tt.input, n, tt.want)
+		}
+		if got := numericToDecimal(tt.input); got != tt.want {
+			t.Errorf("numericToDecimal(%q) mismatch: got %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
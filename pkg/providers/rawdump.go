@@ -0,0 +1,271 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// RawDump holds the raw provider records of a fetch, per transaction type
+type RawDump struct {
+	Normal      []EtherscanNormalTx
+	Internal    []EtherscanInternalTx
+	ERC20       []EtherscanTokenTx
+	ERC721      []EtherscanTokenTx
+	ERC1155     []EtherscanTokenTx
+	Withdrawals []EtherscanWithdrawalTx
+}
+
+// rawDumpFile returns the file holding one type's records, e.g. dir/erc20.json
+func rawDumpFile(dir string, txType TransactionType) string {
+	return filepath.Join(dir, txType.Name()+".json")
+}
+
+// SaveRawDump writes each type's records to dir as a JSON array in <type>.json
+// (normal.json, internal.json, erc20.json, ...), creating dir if needed
+func SaveRawDump(dir string, dump *RawDump) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create raw dump directory: %w", err)
+	}
+
+	files := map[TransactionType]any{
+		TxTypeNormal:     emptyIfNil(dump.Normal),
+		TxTypeInternal:   emptyIfNil(dump.Internal),
+		TxTypeToken:      emptyIfNil(dump.ERC20),
+		TxTypeNFT:        emptyIfNil(dump.ERC721),
+		TxTypeERC1155:    emptyIfNil(dump.ERC1155),
+		TxTypeWithdrawal: emptyIfNil(dump.Withdrawals),
+	}
+	for _, txType := range fetchTypeOrder {
+		data, err := json.MarshalIndent(files[txType], "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode %s records: %w", txType, err)
+		}
+		if err := os.WriteFile(rawDumpFile(dir, txType), append(data, '\n'), 0644); err != nil {
+			return fmt.Errorf("failed to write %s records: %w", txType, err)
+		}
+	}
+	return nil
+}
+
+// emptyIfNil makes a nil slice encode as [] rather than null
+func emptyIfNil[T any](records []T) []T {
+	if records == nil {
+		return []T{}
+	}
+	return records
+}
+
+// LoadRawDump reads the records SaveRawDump wrote to dir. Each file may also hold
+// an Etherscan API response as downloaded (records under "result"); a missing
+// file means no records of that type.
+func LoadRawDump(dir string) (*RawDump, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, fmt.Errorf("failed to open raw dump directory: %w", err)
+	}
+
+	dump := &RawDump{}
+	var err error
+	if dump.Normal, err = loadRawFile[EtherscanNormalTx](rawDumpFile(dir, TxTypeNormal)); err != nil {
+		return nil, err
+	}
+	if dump.Internal, err = loadRawFile[EtherscanInternalTx](rawDumpFile(dir, TxTypeInternal)); err != nil {
+		return nil, err
+	}
+	if dump.ERC20, err = loadRawFile[EtherscanTokenTx](rawDumpFile(dir, TxTypeToken)); err != nil {
+		return nil, err
+	}
+	if dump.ERC721, err = loadRawFile[EtherscanTokenTx](rawDumpFile(dir, TxTypeNFT)); err != nil {
+		return nil, err
+	}
+	if dump.ERC1155, err = loadRawFile[EtherscanTokenTx](rawDumpFile(dir, TxTypeERC1155)); err != nil {
+		return nil, err
+	}
+	if dump.Withdrawals, err = loadRawFile[EtherscanWithdrawalTx](rawDumpFile(dir, TxTypeWithdrawal)); err != nil {
+		return nil, err
+	}
+	return dump, nil
+}
+
+// loadRawFile decodes a JSON array of records, or an API response carrying them in "result"
+func loadRawFile[T any](path string) ([]T, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var records []T
+	if data = bytes.TrimSpace(data); len(data) > 0 && data[0] == '[' {
+		err = json.Unmarshal(data, &records)
+	} else {
+		var resp struct {
+			Result []T `json:"result"`
+		}
+		err = json.Unmarshal(data, &resp)
+		records = resp.Result
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return records, nil
+}
+
+// RecordingProvider passes requests to another provider and keeps every raw record
+// it returns, so a fetch can be saved with SaveRawDump and normalized again offline
+type RecordingProvider struct {
+	provider Provider
+	mu       sync.Mutex // Guards dump; types may be fetched concurrently
+	dump     RawDump
+}
+
+// NewRecordingProvider wraps provider
+func NewRecordingProvider(provider Provider) *RecordingProvider {
+	return &RecordingProvider{provider: provider}
+}
+
+// Dump returns a copy of the records fetched so far
+func (r *RecordingProvider) Dump() *RawDump {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	dump := r.dump
+	return &dump
+}
+
+// Chain reports the wrapped provider's chain, if it has one
+func (r *RecordingProvider) Chain() string {
+	if namer, ok := r.provider.(ChainNamer); ok {
+		return namer.Chain()
+	}
+	return ""
+}
+
+// PageSize reports the wrapped provider's page size, if it has one
+func (r *RecordingProvider) PageSize() int {
+	if limiter, ok := r.provider.(PageLimiter); ok {
+		return limiter.PageSize()
+	}
+	return 0
+}
+
+// MaxPage reports the wrapped provider's page limit, if it has one
+func (r *RecordingProvider) MaxPage() int {
+	if limiter, ok := r.provider.(PageLimiter); ok {
+		return limiter.MaxPage()
+	}
+	return 0
+}
+
+// record appends fetched records to the dump under the lock
+func record[T any](r *RecordingProvider, into *[]T, records []T) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	*into = append(*into, records...)
+}
+
+// FetchNormalTransactions implements Provider
+func (r *RecordingProvider) FetchNormalTransactions(ctx context.Context, address string, startPage, endPage int) ([]EtherscanNormalTx, error) {
+	txs, err := r.provider.FetchNormalTransactions(ctx, address, startPage, endPage)
+	record(r, &r.dump.Normal, txs)
+	return txs, err
+}
+
+// FetchInternalTransactions implements Provider
+func (r *RecordingProvider) FetchInternalTransactions(ctx context.Context, address string, startPage, endPage int) ([]EtherscanInternalTx, error) {
+	txs, err := r.provider.FetchInternalTransactions(ctx, address, startPage, endPage)
+	record(r, &r.dump.Internal, txs)
+	return txs, err
+}
+
+// FetchTokenTransfers implements Provider
+func (r *RecordingProvider) FetchTokenTransfers(ctx context.Context, address string, startPage, endPage int) ([]EtherscanTokenTx, error) {
+	txs, err := r.provider.FetchTokenTransfers(ctx, address, startPage, endPage)
+	record(r, &r.dump.ERC20, txs)
+	return txs, err
+}
+
+// FetchNFTTransfers implements Provider
+func (r *RecordingProvider) FetchNFTTransfers(ctx context.Context, address string, startPage, endPage int) ([]EtherscanTokenTx, error) {
+	txs, err := r.provider.FetchNFTTransfers(ctx, address, startPage, endPage)
+	record(r, &r.dump.ERC721, txs)
+	return txs, err
+}
+
+// FetchERC1155Transfers implements Provider
+func (r *RecordingProvider) FetchERC1155Transfers(ctx context.Context, address string, startPage, endPage int) ([]EtherscanTokenTx, error) {
+	txs, err := r.provider.FetchERC1155Transfers(ctx, address, startPage, endPage)
+	record(r, &r.dump.ERC1155, txs)
+	return txs, err
+}
+
+// FetchBeaconWithdrawals implements Provider
+func (r *RecordingProvider) FetchBeaconWithdrawals(ctx context.Context, address string, startPage, endPage int) ([]EtherscanWithdrawalTx, error) {
+	txs, err := r.provider.FetchBeaconWithdrawals(ctx, address, startPage, endPage)
+	record(r, &r.dump.Withdrawals, txs)
+	return txs, err
+}
+
+// RawDumpProvider serves a RawDump as a Provider, so saved records go through the
+// same fetcher and normalizer as a live fetch without any network calls. The
+// address is ignored, and the whole dump is returned for any page range that
+// includes page 1.
+type RawDumpProvider struct {
+	dump  *RawDump
+	chain string
+}
+
+// NewRawDumpProvider serves dump, labelling its transactions with chain
+func NewRawDumpProvider(dump *RawDump, chain string) *RawDumpProvider {
+	return &RawDumpProvider{dump: dump, chain: chain}
+}
+
+// Chain returns the chain the dump was fetched from
+func (p *RawDumpProvider) Chain() string {
+	return p.chain
+}
+
+// dumpPage returns records when the page range includes the first page
+func dumpPage[T any](records []T, startPage, endPage int) []T {
+	if startPage > 1 || endPage < 1 {
+		return nil
+	}
+	return records
+}
+
+// FetchNormalTransactions implements Provider
+func (p *RawDumpProvider) FetchNormalTransactions(ctx context.Context, address string, startPage, endPage int) ([]EtherscanNormalTx, error) {
+	return dumpPage(p.dump.Normal, startPage, endPage), nil
+}
+
+// FetchInternalTransactions implements Provider
+func (p *RawDumpProvider) FetchInternalTransactions(ctx context.Context, address string, startPage, endPage int) ([]EtherscanInternalTx, error) {
+	return dumpPage(p.dump.Internal, startPage, endPage), nil
+}
+
+// FetchTokenTransfers implements Provider
+func (p *RawDumpProvider) FetchTokenTransfers(ctx context.Context, address string, startPage, endPage int) ([]EtherscanTokenTx, error) {
+	return dumpPage(p.dump.ERC20, startPage, endPage), nil
+}
+
+// FetchNFTTransfers implements Provider
+func (p *RawDumpProvider) FetchNFTTransfers(ctx context.Context, address string, startPage, endPage int) ([]EtherscanTokenTx, error) {
+	return dumpPage(p.dump.ERC721, startPage, endPage), nil
+}
+
+// FetchERC1155Transfers implements Provider
+func (p *RawDumpProvider) FetchERC1155Transfers(ctx context.Context, address string, startPage, endPage int) ([]EtherscanTokenTx, error) {
+	return dumpPage(p.dump.ERC1155, startPage, endPage), nil
+}
+
+// FetchBeaconWithdrawals implements Provider
+func (p *RawDumpProvider) FetchBeaconWithdrawals(ctx context.Context, address string, startPage, endPage int) ([]EtherscanWithdrawalTx, error) {
+	return dumpPage(p.dump.Withdrawals, startPage, endPage), nil
+}
@@ -0,0 +1,81 @@
+package providers
+
+import (
+	"conintracker-hiring/internal/testdata"
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestRawDumpRoundTrip(t *testing.T) {
+	recorder := NewRecordingProvider(&ConfigurableProvider{
+		NormalTxs:   []EtherscanNormalTx{{Hash: "0x1", Value: "1000000000000000000"}},
+		InternalTxs: []EtherscanInternalTx{{Hash: "0x1", Value: "5", ErrCode: "Reverted"}},
+		TokenTxs:    []EtherscanTokenTx{{Hash: "0x2", TokenSymbol: "USDC", TokenDecimal: "6"}},
+	})
+	ctx := context.Background()
+	recorder.FetchNormalTransactions(ctx, "0xabc", 1, 1)
+	recorder.FetchInternalTransactions(ctx, "0xabc", 1, 1)
+	recorder.FetchTokenTransfers(ctx, "0xabc", 1, 1)
+	recorder.FetchNFTTransfers(ctx, "0xabc", 1, 1)
+
+	dir := filepath.Join(t.TempDir(), "dump")
+	if err := SaveRawDump(dir, recorder.Dump()); err != nil {
+		t.Fatalf("SaveRawDump() error = %v", err)
+	}
+	for _, name := range []string{"normal", "internal", "erc20", "erc721", "erc1155", "withdrawal"} {
+		if _, err := os.Stat(filepath.Join(dir, name+".json")); err != nil {
+			t.Errorf("Expected %s.json in dump: %v", name, err)
+		}
+	}
+
+	loaded, err := LoadRawDump(dir)
+	if err != nil {
+		t.Fatalf("LoadRawDump() error = %v", err)
+	}
+	want := recorder.Dump()
+	if !reflect.DeepEqual(loaded.Normal, want.Normal) || !reflect.DeepEqual(loaded.Internal, want.Internal) || !reflect.DeepEqual(loaded.ERC20, want.ERC20) {
+		t.Errorf("Loaded dump mismatch: got %+v, want %+v", loaded, want)
+	}
+	if len(loaded.ERC721) != 0 || len(loaded.Withdrawals) != 0 {
+		t.Errorf("Expected empty ERC721 and withdrawals, got %d and %d", len(loaded.ERC721), len(loaded.Withdrawals))
+	}
+}
+
+func TestLoadRawDumpAcceptsAPIResponses(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "normal.json"), []byte(testdata.NormalTxResponse), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	dump, err := LoadRawDump(dir)
+	if err != nil {
+		t.Fatalf("LoadRawDump() error = %v", err)
+	}
+	if len(dump.Normal) != 2 {
+		t.Errorf("Normal records mismatch: got %d, want 2", len(dump.Normal))
+	}
+	if len(dump.ERC20) != 0 {
+		t.Errorf("Expected no ERC20 records for a missing file, got %d", len(dump.ERC20))
+	}
+
+	if _, err := LoadRawDump(filepath.Join(dir, "missing")); err == nil {
+		t.Error("Expected error for a missing directory, got none")
+	}
+}
+
+func TestRawDumpProviderServesFirstPage(t *testing.T) {
+	provider := NewRawDumpProvider(&RawDump{Normal: []EtherscanNormalTx{{Hash: "0x1"}}}, "base")
+
+	if txs, _ := provider.FetchNormalTransactions(context.Background(), "", 1, 1); len(txs) != 1 {
+		t.Errorf("Page 1 mismatch: got %d records, want 1", len(txs))
+	}
+	if txs, _ := provider.FetchNormalTransactions(context.Background(), "", 2, 3); len(txs) != 0 {
+		t.Errorf("Page 2 mismatch: got %d records, want 0", len(txs))
+	}
+	if provider.Chain() != "base" {
+		t.Errorf("Chain mismatch: got %s, want base", provider.Chain())
+	}
+}
@@ -0,0 +1,105 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// DefaultFetchManyConcurrency is the number of addresses FetchMany works on at once
+const DefaultFetchManyConcurrency = 3
+
+// FetchManyOptions configures FetchMany
+type FetchManyOptions struct {
+	Concurrency int // Addresses fetched at once; 0 uses DefaultFetchManyConcurrency, negative is invalid
+	StartPage   int // 0 uses page 1
+	EndPage     int // 0 uses StartPage
+}
+
+// FetchMany fetches every transaction type for each address using a bounded pool of
+// workers. All workers share the fetcher's provider, so an EtherscanClient's rate
+// limiter paces the combined request stream: extra workers keep request slots busy
+// while others wait on responses, but never raise the request rate.
+// Each address gets its own FetchResult, so truncation and normalization errors stay
+// per wallet. The first failure cancels the remaining work and is returned with the address.
+func (tf *TransactionFetcher) FetchMany(ctx context.Context, addresses []string, opts FetchManyOptions) (map[string]*FetchResult, error) {
+	if opts.Concurrency < 0 {
+		return nil, fmt.Errorf("invalid concurrency %d: must be at least 1", opts.Concurrency)
+	}
+	if opts.Concurrency == 0 {
+		opts.Concurrency = DefaultFetchManyConcurrency
+	}
+	if opts.StartPage <= 0 {
+		opts.StartPage = 1
+	}
+	if opts.EndPage < opts.StartPage {
+		opts.EndPage = opts.StartPage
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobs := make(chan string)
+	results := make(map[string]*FetchResult, len(addresses))
+	var (
+		mu       sync.Mutex
+		firstErr error
+		wg       sync.WaitGroup
+	)
+
+	// Each address tracks its own progress, so serialize the callback across workers
+	var report ProgressFunc
+	if tf.onProgress != nil {
+		var reportMu sync.Mutex
+		report = func(p FetchProgress) {
+			reportMu.Lock()
+			defer reportMu.Unlock()
+			tf.onProgress(p)
+		}
+	}
+
+	for i := 0; i < opts.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for address := range jobs {
+				result, err := tf.fetchAll(ctx, address, opts.StartPage, opts.EndPage, report)
+
+				mu.Lock()
+				if err != nil {
+					if firstErr == nil {
+						firstErr = fmt.Errorf("failed to fetch %s: %w", address, err)
+						cancel()
+					}
+				} else {
+					results[address] = result
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	seen := make(map[string]bool, len(addresses))
+feed:
+	for _, address := range addresses {
+		if seen[address] {
+			continue
+		}
+		seen[address] = true
+		select {
+		case jobs <- address:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
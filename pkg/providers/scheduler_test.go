@@ -0,0 +1,204 @@
+package providers
+
+import (
+	"conintracker-hiring/internal/testdata"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFetchManyStaysWithinRateLimit(t *testing.T) {
+	const rateLimit = 20 * time.Millisecond
+
+	var (
+		mu        sync.Mutex
+		requests  []time.Time
+		addresses = map[string]int{}
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, time.Now())
+		addresses[r.URL.Query().Get("address")]++
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("action") == "txlist" {
+			w.Write([]byte(testdata.NormalTxResponse))
+			return
+		}
+		w.Write([]byte(testdata.NoTransactionsResponse))
+	}))
+	defer server.Close()
+
+	client := NewEtherscanClient(ClientConfig{
+		APIKey:     "test-key",
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		RateLimit:  rateLimit,
+	})
+	fetcher := NewTransactionFetcher(client, NewEtherscanNormalizer())
+
+	wallets := []string{
+		"0x1111111111111111111111111111111111111111",
+		"0x2222222222222222222222222222222222222222",
+		"0x3333333333333333333333333333333333333333",
+	}
+	results, err := fetcher.FetchMany(context.Background(), wallets, FetchManyOptions{Concurrency: 3})
+	if err != nil {
+		t.Fatalf("FetchMany() error = %v", err)
+	}
+
+	if len(results) != len(wallets) {
+		t.Fatalf("Expected results for %d addresses, got %d", len(wallets), len(results))
+	}
+	for _, wallet := range wallets {
+		if results[wallet] == nil || len(results[wallet].Transactions) == 0 {
+			t.Errorf("Expected transactions for %s", wallet)
+		}
+		if addresses[wallet] != len(fetchTypeOrder) {
+			t.Errorf("Expected %d requests for %s, got %d", len(fetchTypeOrder), wallet, addresses[wallet])
+		}
+	}
+
+	// n requests spaced rateLimit apart span at least (n-1)*rateLimit
+	elapsed := requests[len(requests)-1].Sub(requests[0])
+	if minSpan := time.Duration(len(requests)-1) * rateLimit; elapsed < minSpan-rateLimit/2 {
+		t.Errorf("%d requests arrived within %v, faster than the %v limit allows", len(requests), elapsed, rateLimit)
+	}
+}
+
+func TestFetchManyReturnsFirstError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(testdata.ErrorResponse))
+	}))
+	defer server.Close()
+
+	client := NewEtherscanClient(ClientConfig{
+		APIKey:     "test-key",
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		RateLimit:  time.Millisecond,
+	})
+	fetcher := NewTransactionFetcher(client, NewEtherscanNormalizer())
+
+	results, err := fetcher.FetchMany(context.Background(), []string{"0x1111111111111111111111111111111111111111"}, FetchManyOptions{})
+	if err == nil {
+		t.Fatal("Expected error from failing API")
+	}
+	if results != nil {
+		t.Errorf("Expected nil results on error, got %d", len(results))
+	}
+}
+
+func TestFetchManyRespectsConcurrency(t *testing.T) {
+	const (
+		rateLimit   = 5 * time.Millisecond
+		latency     = 30 * time.Millisecond // Long enough for other workers to start while one waits
+		concurrency = 2
+	)
+
+	var (
+		mu       sync.Mutex
+		requests []time.Time
+		inFlight = map[string]bool{}
+		peak     int
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		address := r.URL.Query().Get("address")
+		mu.Lock()
+		requests = append(requests, time.Now())
+		inFlight[address] = true
+		peak = max(peak, len(inFlight))
+		mu.Unlock()
+
+		time.Sleep(latency)
+
+		mu.Lock()
+		delete(inFlight, address)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(testdata.NoTransactionsResponse))
+	}))
+	defer server.Close()
+
+	client := NewEtherscanClient(ClientConfig{
+		APIKey:     "test-key",
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		RateLimit:  rateLimit,
+	})
+	fetcher := NewTransactionFetcher(client, NewEtherscanNormalizer())
+
+	wallets := []string{
+		"0x1111111111111111111111111111111111111111",
+		"0x2222222222222222222222222222222222222222",
+		"0x3333333333333333333333333333333333333333",
+		"0x4444444444444444444444444444444444444444",
+	}
+	results, err := fetcher.FetchMany(context.Background(), wallets, FetchManyOptions{Concurrency: concurrency})
+	if err != nil {
+		t.Fatalf("FetchMany() error = %v", err)
+	}
+	if len(results) != len(wallets) {
+		t.Fatalf("Expected results for %d addresses, got %d", len(wallets), len(results))
+	}
+
+	if peak != concurrency {
+		t.Errorf("Peak addresses in flight mismatch: got %d, want %d", peak, concurrency)
+	}
+
+	elapsed := requests[len(requests)-1].Sub(requests[0])
+	if minSpan := time.Duration(len(requests)-1) * rateLimit; elapsed < minSpan-rateLimit/2 {
+		t.Errorf("%d requests arrived within %v, faster than the %v limit allows", len(requests), elapsed, rateLimit)
+	}
+}
+
+func TestFetchManySerializesProgress(t *testing.T) {
+	fetcher := NewTransactionFetcher(&ConfigurableProvider{}, NewEtherscanNormalizer())
+
+	var (
+		inCallback atomic.Int32
+		overlaps   atomic.Int32
+		calls      atomic.Int32
+	)
+	fetcher.SetProgressCallback(func(FetchProgress) {
+		if inCallback.Add(1) > 1 {
+			overlaps.Add(1)
+		}
+		time.Sleep(time.Millisecond) // Widens the window for an overlapping call
+		inCallback.Add(-1)
+		calls.Add(1)
+	})
+
+	wallets := []string{
+		"0x1111111111111111111111111111111111111111",
+		"0x2222222222222222222222222222222222222222",
+		"0x3333333333333333333333333333333333333333",
+		"0x4444444444444444444444444444444444444444",
+	}
+	if _, err := fetcher.FetchMany(context.Background(), wallets, FetchManyOptions{Concurrency: len(wallets)}); err != nil {
+		t.Fatalf("FetchMany() error = %v", err)
+	}
+
+	if overlaps.Load() != 0 {
+		t.Errorf("Progress callback overlapped %d times", overlaps.Load())
+	}
+	if calls.Load() == 0 {
+		t.Error("Expected progress to be reported")
+	}
+}
+
+func TestFetchManyRejectsNegativeConcurrency(t *testing.T) {
+	fetcher := NewTransactionFetcher(&ConfigurableProvider{}, NewEtherscanNormalizer())
+
+	_, err := fetcher.FetchMany(context.Background(), []string{"0x1111111111111111111111111111111111111111"}, FetchManyOptions{Concurrency: -1})
+	if err == nil {
+		t.Fatal("Expected an error for negative concurrency")
+	}
+}
@@ -0,0 +1,182 @@
+package providers
+
+import (
+	"conintracker-hiring/pkg/models"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// SetShards sets how many block sub-ranges FetchTypeSharded splits a type into
+func (pf *ParallelFetcher) SetShards(n int) {
+	if n > 0 {
+		pf.shards = n
+	}
+}
+
+// SetBlockRange sets the blocks FetchTypeSharded covers (inclusive). Narrowing it to
+// the chain's current height keeps the shards evenly sized.
+func (pf *ParallelFetcher) SetBlockRange(startBlock, endBlock uint64) {
+	if startBlock <= endBlock {
+		pf.startBlock = startBlock
+		pf.endBlock = endBlock
+	}
+}
+
+// SetWindowSize sets the number of raw records a single shard's fetch can return.
+// FetchTypeSharded reports a type as truncated when any shard returns this many.
+func (pf *ParallelFetcher) SetWindowSize(size int) {
+	if size > 0 {
+		pf.windowSize = size
+	}
+}
+
+// FetchTypeSharded fetches a single transaction type by splitting the block range into
+// the configured number of shards and fetching them concurrently, at most maxConcurrent
+// at a time. Every shard requests pages startPage..endPage of its own range. Shards
+// share the provider, so an EtherscanClient's rate limiter still paces the combined
+// requests. Each shard keeps only the rows inside its own block range, so a row a
+// provider repeats across a shard boundary appears once while identical rows within
+// a block are all kept; the merged rows are sorted. Providers that do not implement
+// BlockRanger are fetched in one piece.
+func (pf *ParallelFetcher) FetchTypeSharded(
+	ctx context.Context,
+	txType TransactionType,
+	address string,
+	startPage, endPage int,
+) *FetchTypeResult {
+	ranger, ok := pf.provider.(BlockRanger)
+	if !ok || pf.shards <= 1 {
+		result := pf.fetchShard(ctx, pf.provider, txType, address, startPage, endPage)
+		result.Truncated = result.Err == nil && result.NormalizationStats.TotalProcessed >= pf.windowSize
+		return result
+	}
+
+	ranges := SplitBlockRange(pf.startBlock, pf.endBlock, pf.shards)
+	results := make([]*FetchTypeResult, len(ranges))
+	sem := make(chan struct{}, pf.maxConcurrent)
+	var wg sync.WaitGroup
+
+	for i, blocks := range ranges {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			provider := ranger.WithBlockRange(blocks[0], blocks[1])
+			result := pf.fetchShard(ctx, provider, txType, address, startPage, endPage)
+			if result.Err != nil {
+				result.Err = fmt.Errorf("blocks %d-%d: %w", blocks[0], blocks[1], result.Err)
+			}
+			results[i] = result
+		}()
+	}
+	wg.Wait()
+
+	merged := &FetchTypeResult{TxType: txType}
+	for i, result := range results {
+		if result.Err != nil {
+			return &FetchTypeResult{TxType: txType, Err: result.Err}
+		}
+		merged.NormalizationStats.merge(result.NormalizationStats)
+		if result.NormalizationStats.TotalProcessed >= pf.windowSize {
+			merged.Truncated = true
+		}
+		for _, tx := range result.Txs {
+			if tx.BlockNumber >= ranges[i][0] && tx.BlockNumber <= ranges[i][1] {
+				merged.Txs = append(merged.Txs, tx)
+			}
+		}
+	}
+
+	sort.Stable(models.TransactionList(merged.Txs))
+	merged.Count = len(merged.Txs)
+	return merged
+}
+
+// fetchShard fetches and normalizes one type from provider under the per-fetch timeout
+func (pf *ParallelFetcher) fetchShard(
+	ctx context.Context,
+	provider Provider,
+	txType TransactionType,
+	address string,
+	startPage, endPage int,
+) *FetchTypeResult {
+	fetchCtx, cancel := context.WithTimeout(ctx, pf.timeout)
+	defer cancel()
+
+	shard := *pf
+	shard.provider = provider
+	result := pf.executeFetch(fetchCtx, func() *FetchTypeResult {
+		return shard.fetchTypeConcurrent(fetchCtx, txType, address, startPage, endPage)
+	}, txType)
+	labelChain(pf.provider, result.Txs)
+	return result
+}
+
+// fetchTypeConcurrent dispatches to the fetch helper for txType
+func (pf *ParallelFetcher) fetchTypeConcurrent(
+	ctx context.Context,
+	txType TransactionType,
+	address string,
+	startPage, endPage int,
+) *FetchTypeResult {
+	switch txType {
+	case TxTypeNormal:
+		return pf.fetchNormalTransactionsConcurrent(ctx, address, startPage, endPage)
+	case TxTypeInternal:
+		return pf.fetchInternalTransactionsConcurrent(ctx, address, startPage, endPage)
+	case TxTypeToken:
+		return pf.fetchTokenTransfersConcurrent(ctx, address, startPage, endPage)
+	case TxTypeNFT:
+		return pf.fetchNFTTransfersConcurrent(ctx, address, startPage, endPage)
+	case TxTypeERC1155:
+		return pf.fetchERC1155TransfersConcurrent(ctx, address, startPage, endPage)
+	case TxTypeWithdrawal:
+		return pf.fetchBeaconWithdrawalsConcurrent(ctx, address, startPage, endPage)
+	default:
+		return &FetchTypeResult{TxType: txType, Err: fmt.Errorf("unknown transaction type %d", txType)}
+	}
+}
+
+// SplitBlockRange divides the blocks start..end (inclusive) into at most shards
+// contiguous, non-overlapping inclusive windows that together cover the range.
+// Window sizes differ by at most one block, with the larger windows first. There
+// are never more windows than blocks, so start == end yields a single window;
+// shards below 1 is treated as 1, and an empty range (start > end) yields none.
+func SplitBlockRange(start, end uint64, shards int) [][2]uint64 {
+	if start > end {
+		return nil
+	}
+	if shards < 1 {
+		shards = 1
+	}
+	// Work from the width, end - start, since the block count can exceed a uint64
+	width := end - start
+	if uint64(shards-1) > width {
+		shards = int(width + 1)
+	}
+	if shards == 1 {
+		return [][2]uint64{{start, end}}
+	}
+
+	n := uint64(shards)
+	size, extra := width/n, width%n+1 // The count is size*n + extra blocks
+	if extra == n {
+		size, extra = size+1, 0
+	}
+
+	ranges := make([][2]uint64, 0, shards)
+	from := start
+	for i := uint64(0); i < n; i++ {
+		to := from + size - 1
+		if i < extra {
+			to++
+		}
+		ranges = append(ranges, [2]uint64{from, to})
+		from = to + 1
+	}
+	return ranges
+}
@@ -0,0 +1,284 @@
+package providers
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// shardedMockProvider serves ERC-20 transfers by block. Ranged views also return
+// the block just before their range, so neighbouring shards overlap by one block.
+type shardedMockProvider struct {
+	ConfigurableProvider
+	transfers []EtherscanTokenTx
+
+	mu     sync.Mutex
+	ranges [][2]uint64
+}
+
+func (sp *shardedMockProvider) WithBlockRange(startBlock, endBlock uint64) Provider {
+	sp.mu.Lock()
+	sp.ranges = append(sp.ranges, [2]uint64{startBlock, endBlock})
+	sp.mu.Unlock()
+
+	var inRange []EtherscanTokenTx
+	for _, tx := range sp.transfers {
+		block, _ := strconv.ParseUint(tx.BlockNumber, 10, 64)
+		if block+1 >= startBlock && block <= endBlock {
+			inRange = append(inRange, tx)
+		}
+	}
+	return &ConfigurableProvider{TokenTxs: inRange}
+}
+
+func TestFetchTypeShardedMergesRanges(t *testing.T) {
+	// Listed out of order so sorting is exercised
+	provider := &shardedMockProvider{transfers: []EtherscanTokenTx{
+		{Hash: "0xd", BlockNumber: "90", TimeStamp: "1090", Value: "4", TokenDecimal: "0"},
+		{Hash: "0xa", BlockNumber: "10", TimeStamp: "1010", Value: "1", TokenDecimal: "0"},
+		{Hash: "0xb", BlockNumber: "49", TimeStamp: "1049", Value: "2", TokenDecimal: "0"},
+		{Hash: "0xc", BlockNumber: "50", TimeStamp: "1050", Value: "3", TokenDecimal: "0"},
+	}}
+
+	fetcher := NewParallelFetcher(provider, NewEtherscanNormalizer())
+	fetcher.SetShards(4)
+	fetcher.SetBlockRange(0, 99)
+
+	result := fetcher.FetchTypeSharded(context.Background(), TxTypeToken, "0xtest", 1, 1)
+	if result.Err != nil {
+		t.Fatalf("FetchTypeSharded() error = %v", result.Err)
+	}
+
+	if len(provider.ranges) != 4 {
+		t.Errorf("Expected 4 block ranges, got %d", len(provider.ranges))
+	}
+
+	// 0xb (block 49) is returned by shards 25-49 and 50-74; it must appear once
+	want := []string{"0xa", "0xb", "0xc", "0xd"}
+	if len(result.Txs) != len(want) {
+		t.Fatalf("Expected %d transactions, got %d", len(want), len(result.Txs))
+	}
+	for i, hash := range want {
+		if result.Txs[i].Hash != hash {
+			t.Errorf("Transaction %d hash mismatch: got %s, want %s", i, result.Txs[i].Hash, hash)
+		}
+	}
+	if result.Count != len(want) {
+		t.Errorf("Count mismatch: got %d, want %d", result.Count, len(want))
+	}
+	if result.NormalizationStats.TotalProcessed != 5 {
+		t.Errorf("Expected 5 raw transfers across shards, got %d", result.NormalizationStats.TotalProcessed)
+	}
+}
+
+// upperHashShardProvider is a shardedMockProvider whose later shards report hashes
+// in uppercase hex, so an overlapping transfer arrives in two cases
+type upperHashShardProvider struct {
+	shardedMockProvider
+}
+
+func (up *upperHashShardProvider) WithBlockRange(startBlock, endBlock uint64) Provider {
+	ranged := up.shardedMockProvider.WithBlockRange(startBlock, endBlock).(*ConfigurableProvider)
+	if startBlock > 0 {
+		for i, tx := range ranged.TokenTxs {
+			tx.Hash = "0x" + strings.ToUpper(tx.Hash[2:])
+			ranged.TokenTxs[i] = tx
+		}
+	}
+	return ranged
+}
+
+func TestFetchTypeShardedDedupesMixedCaseHashes(t *testing.T) {
+	provider := &upperHashShardProvider{shardedMockProvider{transfers: []EtherscanTokenTx{
+		{Hash: "0xabc1", BlockNumber: "10", TimeStamp: "1010", Value: "1", TokenDecimal: "0"},
+		{Hash: "0xabc2", BlockNumber: "49", TimeStamp: "1049", Value: "2", TokenDecimal: "0"},
+	}}}
+
+	fetcher := NewParallelFetcher(provider, NewEtherscanNormalizer())
+	fetcher.SetShards(2)
+	fetcher.SetBlockRange(0, 99)
+
+	result := fetcher.FetchTypeSharded(context.Background(), TxTypeToken, "0xtest", 1, 1)
+	if result.Err != nil {
+		t.Fatalf("FetchTypeSharded() error = %v", result.Err)
+	}
+
+	// 0xabc2 (block 49) is returned as 0xabc2 by shard 0-49 and 0xABC2 by shard 50-99
+	if result.NormalizationStats.TotalProcessed != 3 {
+		t.Fatalf("Expected 3 raw transfers across shards, got %d", result.NormalizationStats.TotalProcessed)
+	}
+	if len(result.Txs) != 2 {
+		t.Fatalf("Expected the mixed-case duplicate to collapse into 2 transactions, got %d", len(result.Txs))
+	}
+	if !strings.EqualFold(result.Txs[1].Hash, "0xabc2") {
+		t.Errorf("Second transaction hash mismatch: got %s, want 0xabc2", result.Txs[1].Hash)
+	}
+}
+
+func TestFetchTypeShardedKeepsIdenticalLegs(t *testing.T) {
+	// Two equal ERC-20 legs of one transaction, plus a transfer on a shard boundary
+	transfers := []EtherscanTokenTx{
+		{Hash: "0xa", BlockNumber: "30", TimeStamp: "1030", From: "0xf", To: "0xt", Value: "5", TokenDecimal: "0"},
+		{Hash: "0xa", BlockNumber: "30", TimeStamp: "1030", From: "0xf", To: "0xt", Value: "5", TokenDecimal: "0"},
+		{Hash: "0xb", BlockNumber: "49", TimeStamp: "1049", From: "0xf", To: "0xt", Value: "1", TokenDecimal: "0"},
+	}
+	provider := &shardedMockProvider{ConfigurableProvider: ConfigurableProvider{TokenTxs: transfers}, transfers: transfers}
+
+	hashes := func(shards int) []string {
+		fetcher := NewParallelFetcher(provider, NewEtherscanNormalizer())
+		fetcher.SetShards(shards)
+		fetcher.SetBlockRange(0, 99)
+		result := fetcher.FetchTypeSharded(context.Background(), TxTypeToken, "0xtest", 1, 1)
+		if result.Err != nil {
+			t.Fatalf("FetchTypeSharded(%d shards) error = %v", shards, result.Err)
+		}
+		var got []string
+		for _, tx := range result.Txs {
+			got = append(got, tx.Hash)
+		}
+		return got
+	}
+
+	want := []string{"0xa", "0xa", "0xb"}
+	for _, shards := range []int{1, 2, 4} {
+		if got := hashes(shards); strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("%d shards mismatch: got %v, want %v", shards, got, want)
+		}
+	}
+}
+
+func TestFetchTypeShardedFlagsTruncatedShard(t *testing.T) {
+	provider := &shardedMockProvider{transfers: []EtherscanTokenTx{
+		{Hash: "0xa", BlockNumber: "10", TimeStamp: "1010", Value: "1", TokenDecimal: "0"},
+		{Hash: "0xb", BlockNumber: "20", TimeStamp: "1020", Value: "2", TokenDecimal: "0"},
+		{Hash: "0xc", BlockNumber: "70", TimeStamp: "1070", Value: "3", TokenDecimal: "0"},
+	}}
+
+	tests := []struct {
+		name          string
+		windowSize    int
+		wantTruncated bool
+	}{
+		{name: "shard_fills_window", windowSize: 2, wantTruncated: true},
+		{name: "shards_below_window", windowSize: 3, wantTruncated: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetcher := NewParallelFetcher(provider, NewEtherscanNormalizer())
+			fetcher.SetShards(2)
+			fetcher.SetBlockRange(0, 99)
+			fetcher.SetWindowSize(tt.windowSize)
+
+			result := fetcher.FetchTypeSharded(context.Background(), TxTypeToken, "0xtest", 1, 1)
+			if result.Err != nil {
+				t.Fatalf("FetchTypeSharded() error = %v", result.Err)
+			}
+			if result.Truncated != tt.wantTruncated {
+				t.Errorf("Truncated mismatch: got %v, want %v", result.Truncated, tt.wantTruncated)
+			}
+		})
+	}
+}
+
+func TestBenchmarkFixturesMixHashCase(t *testing.T) {
+	fixtures := NewBenchmarkFixtures(16)
+
+	mixed := 0
+	for i, tx := range fixtures.TokenTxs {
+		if tx.Hash != strings.ToLower(tx.Hash) {
+			mixed++
+		}
+		if !strings.EqualFold(tx.Hash, fixtures.NormalTxs[i].Hash) {
+			t.Errorf("Token transfer %d hash %s does not match normal transaction %s", i, tx.Hash, fixtures.NormalTxs[i].Hash)
+		}
+	}
+	if mixed == 0 {
+		t.Error("Expected some token transfer hashes in uppercase hex")
+	}
+}
+
+func TestFetchTypeShardedWithoutBlockRanger(t *testing.T) {
+	provider := &ConfigurableProvider{TokenTxs: []EtherscanTokenTx{
+		{Hash: "0xa", BlockNumber: "10", TimeStamp: "1010", Value: "1", TokenDecimal: "0"},
+	}}
+
+	fetcher := NewParallelFetcher(provider, NewEtherscanNormalizer())
+	fetcher.SetShards(4)
+
+	result := fetcher.FetchTypeSharded(context.Background(), TxTypeToken, "0xtest", 1, 1)
+	if result.Err != nil {
+		t.Fatalf("FetchTypeSharded() error = %v", result.Err)
+	}
+	if len(result.Txs) != 1 {
+		t.Errorf("Expected 1 transaction, got %d", len(result.Txs))
+	}
+}
+
+func TestSplitBlockRange(t *testing.T) {
+	tests := []struct {
+		name       string
+		start, end uint64
+		n          int
+		want       [][2]uint64
+	}{
+		{"even", 0, 99, 4, [][2]uint64{{0, 24}, {25, 49}, {50, 74}, {75, 99}}},
+		{"remainder", 0, 9, 3, [][2]uint64{{0, 3}, {4, 6}, {7, 9}}},
+		{"more_shards_than_blocks", 5, 6, 4, [][2]uint64{{5, 5}, {6, 6}}},
+		{"single", 0, 99, 1, [][2]uint64{{0, 99}}},
+		{"single_block", 7, 7, 3, [][2]uint64{{7, 7}}},
+		{"zero_shards", 10, 19, 0, [][2]uint64{{10, 19}}},
+		{"empty_range", 20, 10, 3, nil},
+		{"full_uint64", 0, math.MaxUint64, 2, [][2]uint64{{0, math.MaxUint64 / 2}, {math.MaxUint64/2 + 1, math.MaxUint64}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SplitBlockRange(tt.start, tt.end, tt.n)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Range count mismatch: got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Range %d mismatch: got %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSplitBlockRangeCoversRange(t *testing.T) {
+	for _, start := range []uint64{0, 1, 17_000_000} {
+		for _, blocks := range []uint64{1, 2, 3, 7, 10, 100, 1001} {
+			for _, shards := range []int{1, 2, 3, 4, 7, 10, 64, 2000} {
+				end := start + blocks - 1
+				ranges := SplitBlockRange(start, end, shards)
+
+				if want := min(uint64(shards), blocks); uint64(len(ranges)) != want {
+					t.Fatalf("SplitBlockRange(%d, %d, %d): got %d windows, want %d", start, end, shards, len(ranges), want)
+				}
+				if ranges[0][0] != start || ranges[len(ranges)-1][1] != end {
+					t.Errorf("SplitBlockRange(%d, %d, %d) does not cover the range: %v", start, end, shards, ranges)
+				}
+
+				minSize, maxSize := uint64(math.MaxUint64), uint64(0)
+				for i, window := range ranges {
+					if window[0] > window[1] {
+						t.Errorf("SplitBlockRange(%d, %d, %d) window %d is empty: %v", start, end, shards, i, window)
+					}
+					if i > 0 && window[0] != ranges[i-1][1]+1 {
+						t.Errorf("SplitBlockRange(%d, %d, %d) windows %d and %d are not contiguous: %v", start, end, shards, i-1, i, ranges)
+					}
+					size := window[1] - window[0] + 1
+					minSize, maxSize = min(minSize, size), max(maxSize, size)
+				}
+				if maxSize-minSize > 1 {
+					t.Errorf("SplitBlockRange(%d, %d, %d) windows are uneven: %v", start, end, shards, ranges)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package providers
+
+import (
+	"context"
+	"sync"
+)
+
+// flightGroup deduplicates concurrent calls sharing a key: while a call is in
+// flight, later callers with the same key wait for it and receive its result
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+// flightCall is an in-flight or completed call
+type flightCall struct {
+	done      chan struct{} // Closed once body and err are set
+	body      []byte
+	err       error
+	abandoned bool // The caller running fn gave up, so its result is not the request's
+}
+
+// do runs fn once per key at a time and returns its result to every concurrent caller.
+// fn runs on the context of the caller that started it. If that caller is canceled,
+// the others retry on their own contexts instead of sharing its cancellation; each
+// caller stops waiting when its own ctx is done. The returned body is shared and must
+// not be modified.
+func (g *flightGroup) do(ctx context.Context, key string, fn func(context.Context) ([]byte, error)) ([]byte, error) {
+	for {
+		g.mu.Lock()
+		if g.calls == nil {
+			g.calls = make(map[string]*flightCall)
+		}
+		if call, ok := g.calls[key]; ok {
+			g.mu.Unlock()
+			select {
+			case <-call.done:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+			if call.abandoned {
+				continue
+			}
+			return call.body, call.err
+		}
+
+		call := &flightCall{done: make(chan struct{})}
+		g.calls[key] = call
+		g.mu.Unlock()
+
+		call.body, call.err = fn(ctx)
+		call.abandoned = ctx.Err() != nil
+
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		close(call.done)
+		return call.body, call.err
+	}
+}